@@ -1,6 +1,9 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -12,7 +15,7 @@ import (
 // TestCmdHittingSet tests the hitting-set command.
 func TestCmdHittingSet(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdHittingSet([]string{})
+		err := cmdHittingSet(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -26,7 +29,7 @@ func TestCmdHittingSet(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdHittingSet([]string{"-f", path})
+			err := cmdHittingSet(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdHittingSet failed: %v", err)
 			}
@@ -51,7 +54,7 @@ func TestCmdHittingSet(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdHittingSet([]string{"-f", "/nonexistent/file.json"})
+		err := cmdHittingSet(osEnv, []string{"-f", "/nonexistent/file.json"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -62,10 +65,10 @@ func TestCmdHittingSet(t *testing.T) {
 		path := filepath.Join(dir, "empty.json")
 
 		hg := hypergraph.NewHypergraph[string]()
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdHittingSet([]string{"-f", path})
+			err := cmdHittingSet(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdHittingSet failed: %v", err)
 			}
@@ -81,7 +84,7 @@ func TestCmdHittingSet(t *testing.T) {
 // TestCmdTransversals tests the transversals command.
 func TestCmdTransversals(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdTransversals([]string{})
+		err := cmdTransversals(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -95,7 +98,7 @@ func TestCmdTransversals(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdTransversals([]string{"-f", path})
+			err := cmdTransversals(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdTransversals failed: %v", err)
 			}
@@ -112,7 +115,7 @@ func TestCmdTransversals(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdTransversals([]string{"-f", path, "-max", "1"})
+			err := cmdTransversals(osEnv, []string{"-f", path, "-max", "1"})
 			if err != nil {
 				t.Fatalf("cmdTransversals failed: %v", err)
 			}
@@ -131,7 +134,7 @@ func TestCmdTransversals(t *testing.T) {
 
 		start := time.Now()
 		captureStdout(t, func() {
-			_ = cmdTransversals([]string{"-f", path, "-timeout", "100ms"})
+			_ = cmdTransversals(osEnv, []string{"-f", path, "-timeout", "100ms"})
 		})
 		elapsed := time.Since(start)
 
@@ -142,7 +145,7 @@ func TestCmdTransversals(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdTransversals([]string{"-f", "/nonexistent/file.json"})
+		err := cmdTransversals(osEnv, []string{"-f", "/nonexistent/file.json"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -152,7 +155,7 @@ func TestCmdTransversals(t *testing.T) {
 // TestCmdColoring tests the coloring command.
 func TestCmdColoring(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdColoring([]string{})
+		err := cmdColoring(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -166,7 +169,7 @@ func TestCmdColoring(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdColoring([]string{"-f", path})
+			err := cmdColoring(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdColoring failed: %v", err)
 			}
@@ -192,7 +195,7 @@ func TestCmdColoring(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			cmdColoring([]string{"-f", path})
+			cmdColoring(osEnv, []string{"-f", path})
 		})
 
 		// Parse coloring
@@ -219,7 +222,7 @@ func TestCmdColoring(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdColoring([]string{"-f", "/nonexistent/file.json"})
+		err := cmdColoring(osEnv, []string{"-f", "/nonexistent/file.json"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -230,10 +233,10 @@ func TestCmdColoring(t *testing.T) {
 		path := filepath.Join(dir, "empty.json")
 
 		hg := hypergraph.NewHypergraph[string]()
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdColoring([]string{"-f", path})
+			err := cmdColoring(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdColoring failed: %v", err)
 			}
@@ -249,7 +252,7 @@ func TestCmdColoring(t *testing.T) {
 // TestCmdIncidence tests the incidence command.
 func TestCmdIncidence(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdIncidence([]string{})
+		err := cmdIncidence(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -263,7 +266,7 @@ func TestCmdIncidence(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdIncidence([]string{"-f", path})
+			err := cmdIncidence(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdIncidence failed: %v", err)
 			}
@@ -286,7 +289,7 @@ func TestCmdIncidence(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			cmdIncidence([]string{"-f", path})
+			cmdIncidence(osEnv, []string{"-f", path})
 		})
 
 		// Should have coordinate entries in format (row, col)
@@ -296,7 +299,7 @@ func TestCmdIncidence(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdIncidence([]string{"-f", "/nonexistent/file.json"})
+		err := cmdIncidence(osEnv, []string{"-f", "/nonexistent/file.json"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -307,10 +310,10 @@ func TestCmdIncidence(t *testing.T) {
 		path := filepath.Join(dir, "empty.json")
 
 		hg := hypergraph.NewHypergraph[string]()
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdIncidence([]string{"-f", path})
+			err := cmdIncidence(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdIncidence failed: %v", err)
 			}
@@ -338,11 +341,11 @@ func TestAlgorithms_LargerGraph(t *testing.T) {
 	hg.AddEdge("e2", []string{"v2", "v4"})
 	hg.AddEdge("e3", []string{"v3", "v5"})
 	hg.AddEdge("e4", []string{"v4", "v5", "v6"})
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	t.Run("hitting_set_larger", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			err := cmdHittingSet([]string{"-f", path})
+			err := cmdHittingSet(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdHittingSet failed: %v", err)
 			}
@@ -356,7 +359,7 @@ func TestAlgorithms_LargerGraph(t *testing.T) {
 
 	t.Run("transversals_larger", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			err := cmdTransversals([]string{"-f", path, "-max", "5"})
+			err := cmdTransversals(osEnv, []string{"-f", path, "-max", "5"})
 			if err != nil {
 				t.Fatalf("cmdTransversals failed: %v", err)
 			}
@@ -369,7 +372,7 @@ func TestAlgorithms_LargerGraph(t *testing.T) {
 
 	t.Run("coloring_larger", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			err := cmdColoring([]string{"-f", path})
+			err := cmdColoring(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdColoring failed: %v", err)
 			}
@@ -383,7 +386,7 @@ func TestAlgorithms_LargerGraph(t *testing.T) {
 
 	t.Run("incidence_larger", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			err := cmdIncidence([]string{"-f", path})
+			err := cmdIncidence(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdIncidence failed: %v", err)
 			}
@@ -404,11 +407,11 @@ func TestAlgorithms_EdgeCases(t *testing.T) {
 
 		hg := hypergraph.NewHypergraph[string]()
 		hg.AddVertex("only")
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		// Hitting set on graph with no edges
 		output := captureStdout(t, func() {
-			cmdHittingSet([]string{"-f", path})
+			cmdHittingSet(osEnv, []string{"-f", path})
 		})
 		if strings.TrimSpace(output) != "" {
 			t.Errorf("no edges means empty hitting set")
@@ -416,7 +419,7 @@ func TestAlgorithms_EdgeCases(t *testing.T) {
 
 		// Coloring on single vertex
 		output = captureStdout(t, func() {
-			cmdColoring([]string{"-f", path})
+			cmdColoring(osEnv, []string{"-f", path})
 		})
 		if !strings.Contains(output, "only:") {
 			t.Error("should color the single vertex")
@@ -435,10 +438,10 @@ func TestAlgorithms_EdgeCases(t *testing.T) {
 		hg.AddEdge("e1", []string{"a", "b"})
 		hg.AddEdge("e2", []string{"a", "c"})
 		hg.AddEdge("e3", []string{"a", "b", "c"})
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			cmdHittingSet([]string{"-f", path})
+			cmdHittingSet(osEnv, []string{"-f", path})
 		})
 
 		// 'a' alone should be a hitting set (covers all edges)
@@ -447,3 +450,497 @@ func TestAlgorithms_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+// TestCmdHomology tests the homology command.
+func TestCmdHomology(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdHomology(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	t.Run("hollow_triangle", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "triangle.json")
+		hg := hypergraph.NewHypergraph[string]()
+		_ = hg.AddEdge("ab", []string{"a", "b"})
+		_ = hg.AddEdge("bc", []string{"b", "c"})
+		_ = hg.AddEdge("ac", []string{"a", "c"})
+		if err := osEnv.saveGraph(hg, path); err != nil {
+			t.Fatal(err)
+		}
+
+		output := captureStdout(t, func() {
+			if err := cmdHomology(osEnv, []string{"-f", path, "-cycles"}); err != nil {
+				t.Fatalf("cmdHomology failed: %v", err)
+			}
+		})
+
+		for _, want := range []string{
+			"Dimension:   1",
+			"Simplices:   3 3",
+			"Euler:       0",
+			"Betti (Z/2): 1 1",
+			"H1 generator 1: {a,b} + {a,c} + {b,c}",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output should contain %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("max_dim", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+
+		output := captureStdout(t, func() {
+			if err := cmdHomology(osEnv, []string{"-f", path, "-max-dim", "0"}); err != nil {
+				t.Fatalf("cmdHomology failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "Betti (Z/2): 3") {
+			t.Errorf("0-skeleton should have three components, got:\n%s", output)
+		}
+	})
+
+	t.Run("large_edge", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "big.json")
+		hg := hypergraph.NewHypergraph[string]()
+		members := make([]string, 30)
+		for i := range members {
+			members[i] = fmt.Sprintf("v%02d", i)
+		}
+		_ = hg.AddEdge("big", members)
+		if err := osEnv.saveGraph(hg, path); err != nil {
+			t.Fatal(err)
+		}
+
+		// The default cap keeps a 30-member edge tractable.
+		output := captureStdout(t, func() {
+			if err := cmdHomology(osEnv, []string{"-f", path}); err != nil {
+				t.Fatalf("cmdHomology failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "Dimension:   3") {
+			t.Errorf("default cap should be dimension 3, got:\n%s", output)
+		}
+		err := cmdHomology(osEnv, []string{"-f", path, "-max-dim", "20"})
+		if err == nil || !strings.Contains(err.Error(), "-max-dim -1") {
+			t.Errorf("expected refusal for a huge complex, got %v", err)
+		}
+	})
+}
+
+// TestCmdMinCut tests the mincut command.
+func TestCmdMinCut(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdMinCut(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	t.Run("source_without_sink", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdMinCut(osEnv, []string{"-f", path, "-s", "a"}); err == nil {
+			t.Fatal("expected error when -t is missing")
+		}
+	})
+
+	t.Run("st_cut", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "w.json")
+		hg := createTestGraph(t)
+		_ = hg.SetEdgeWeight("e1", 3)
+		if err := osEnv.saveGraph(hg, path); err != nil {
+			t.Fatal(err)
+		}
+
+		output := captureStdout(t, func() {
+			if err := cmdMinCut(osEnv, []string{"-f", path, "-s", "a", "-t", "c"}); err != nil {
+				t.Fatalf("cmdMinCut failed: %v", err)
+			}
+		})
+		for _, want := range []string{"Weight: 1\n", "Edges:  e2\n", "Side:   a, b\n"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output should contain %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("global_cut", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+
+		output := captureStdout(t, func() {
+			if err := cmdMinCut(osEnv, []string{"-f", path}); err != nil {
+				t.Fatalf("cmdMinCut failed: %v", err)
+			}
+		})
+		if !strings.Contains(output, "Weight: 1\n") {
+			t.Errorf("unexpected output:\n%s", output)
+		}
+	})
+
+	t.Run("unknown_vertex", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdMinCut(osEnv, []string{"-f", path, "-s", "a", "-t", "zz"}); err == nil {
+			t.Fatal("expected error for unknown vertex")
+		}
+	})
+}
+
+func TestCmdEdgeCover(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdEdgeCover(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	t.Run("unknown_method", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdEdgeCover(osEnv, []string{"-f", path, "-method", "magic"}); err == nil {
+			t.Fatal("expected error for unknown method")
+		}
+	})
+
+	for _, method := range []string{"greedy", "lp", "exact"} {
+		t.Run(method, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "w.json")
+			hg := createTestGraph(t)
+			_ = hg.AddEdge("all", []string{"a", "b", "c"})
+			_ = hg.SetEdgeWeight("all", 3)
+			if err := osEnv.saveGraph(hg, path); err != nil {
+				t.Fatal(err)
+			}
+
+			output := captureStdout(t, func() {
+				if err := cmdEdgeCover(osEnv, []string{"-f", path, "-method", method}); err != nil {
+					t.Fatalf("cmdEdgeCover failed: %v", err)
+				}
+			})
+			for _, want := range []string{"Weight: 2\n", "Edges:  e1, e2\n"} {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got:\n%s", want, output)
+				}
+			}
+		})
+	}
+
+	t.Run("uncoverable_vertex", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "iso.json")
+		hg := createTestGraph(t)
+		hg.AddVertex("lonely")
+		if err := osEnv.saveGraph(hg, path); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmdEdgeCover(osEnv, []string{"-f", path}); err == nil {
+			t.Fatal("expected error for vertex in no edge")
+		}
+	})
+}
+
+func TestCmdIndependentSet(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdIndependentSet(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	// Test graph: e1={a,b}, e2={b,c}.
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"greedy_strong", nil, "a c\n"},
+		{"exact_strong", []string{"-exact"}, "a c\n"},
+		{"greedy_weak", []string{"-weak"}, "a c\n"},
+		{"exact_weak", []string{"-weak", "-exact"}, "a c\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := writeTestGraphFile(t, dir, "test.json")
+			output := captureStdout(t, func() {
+				if err := cmdIndependentSet(osEnv, append([]string{"-f", path}, tt.args...)); err != nil {
+					t.Fatalf("cmdIndependentSet failed: %v", err)
+				}
+			})
+			if output != tt.want {
+				t.Errorf("output = %q, want %q", output, tt.want)
+			}
+		})
+	}
+}
+
+func TestCmdHittingSet_Exact(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestGraphFile(t, dir, "test.json")
+	output := captureStdout(t, func() {
+		if err := cmdHittingSet(osEnv, []string{"-f", path, "-exact"}); err != nil {
+			t.Fatalf("cmdHittingSet failed: %v", err)
+		}
+	})
+	if output != "b\n" {
+		t.Errorf("output = %q, want %q", output, "b\n")
+	}
+}
+
+func TestCmdCore(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdCore(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	t.Run("l_without_k", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdCore(osEnv, []string{"-f", path, "-l", "2"}); err == nil {
+			t.Fatal("expected error for -l without -k")
+		}
+	})
+
+	t.Run("k_without_output", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdCore(osEnv, []string{"-f", path, "-k", "1"}); err == nil {
+			t.Fatal("expected error for -k without -o")
+		}
+	})
+
+	t.Run("coreness", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		output := captureStdout(t, func() {
+			if err := cmdCore(osEnv, []string{"-f", path}); err != nil {
+				t.Fatalf("cmdCore failed: %v", err)
+			}
+		})
+		if output != "a: 1\nb: 1\nc: 1\n" {
+			t.Errorf("unexpected output:\n%s", output)
+		}
+	})
+
+	t.Run("kl_core", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "g.json")
+		hg := createTestGraph(t)
+		_ = hg.AddEdge("e3", []string{"a", "b", "c"})
+		if err := osEnv.saveGraph(hg, path); err != nil {
+			t.Fatal(err)
+		}
+		out := filepath.Join(dir, "core.json")
+		if err := cmdCore(osEnv, []string{"-f", path, "-k", "2", "-l", "2", "-o", out}); err != nil {
+			t.Fatalf("cmdCore failed: %v", err)
+		}
+		core, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Every vertex has degree 2, so nothing is peeled.
+		if core.NumVertices() != 3 || core.NumEdges() != 3 {
+			t.Errorf("core has %d vertices and %d edges, want 3 and 3", core.NumVertices(), core.NumEdges())
+		}
+
+		// Without e1 and e2 no vertex keeps degree 2.
+		if err := cmdCore(osEnv, []string{"-f", path, "-k", "2", "-l", "3", "-o", out}); err != nil {
+			t.Fatal(err)
+		}
+		if core, _ := osEnv.loadGraph(out); core.NumVertices() != 0 {
+			t.Errorf("(2,3)-core has vertices %v", core.Vertices())
+		}
+		// -l 1 gives the k-core.
+		kOut := filepath.Join(dir, "k.json")
+		_ = cmdCore(osEnv, []string{"-f", path, "-k", "2", "-o", kOut})
+		_ = cmdCore(osEnv, []string{"-f", path, "-k", "2", "-l", "1", "-o", out})
+		a, _ := os.ReadFile(kOut)
+		b, _ := os.ReadFile(out)
+		if string(a) != string(b) {
+			t.Errorf("-k 2 -l 1 wrote %s, -k 2 wrote %s", b, a)
+		}
+	})
+}
+
+func TestCmdColoring_Workers(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestGraphFile(t, dir, "test.json")
+
+	outputs := make([]string, 0, 2)
+	for _, w := range []string{"1", "4"} {
+		outputs = append(outputs, captureStdout(t, func() {
+			if err := cmdColoring(osEnv, []string{"-f", path, "-workers", w}); err != nil {
+				t.Fatalf("cmdColoring failed: %v", err)
+			}
+		}))
+	}
+	if outputs[0] != outputs[1] {
+		t.Errorf("coloring depends on worker count:\n%s\nvs\n%s", outputs[0], outputs[1])
+	}
+	colors := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(outputs[0]), "\n") {
+		v, c, _ := strings.Cut(line, ": ")
+		colors[v] = c
+	}
+	if colors["a"] == colors["b"] || colors["b"] == colors["c"] {
+		t.Errorf("invalid coloring: %v", colors)
+	}
+}
+
+func TestCmdMatch(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		if err := cmdMatch(osEnv, []string{}); err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdMatch(osEnv, []string{"-f", path}); err == nil || !strings.Contains(err.Error(), "-pattern") {
+			t.Fatalf("expected missing -pattern error, got %v", err)
+		}
+	})
+
+	// Test graph: e1={a,b}, e2={b,c}; pattern: p={x,y}, q={y,z}.
+	dir := t.TempDir()
+	path := writeTestGraphFile(t, dir, "test.json")
+	patternPath := filepath.Join(dir, "pattern.json")
+	pattern := hypergraph.NewHypergraph[string]()
+	_ = pattern.AddEdge("p", []string{"x", "y"})
+	_ = pattern.AddEdge("q", []string{"y", "z"})
+	if err := osEnv.saveGraph(pattern, patternPath); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdMatch(osEnv, []string{"-f", path, "-pattern", patternPath}); err != nil {
+				t.Fatalf("cmdMatch failed: %v", err)
+			}
+		})
+		want := "x=a y=b z=c p=e1 q=e2\nx=c y=b z=a p=e2 q=e1\n"
+		if output != want {
+			t.Errorf("output = %q, want %q", output, want)
+		}
+	})
+
+	t.Run("count_max", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdMatch(osEnv, []string{"-f", path, "-pattern", patternPath, "-count", "-max", "1"}); err != nil {
+				t.Fatalf("cmdMatch failed: %v", err)
+			}
+		})
+		if output != "1\n" {
+			t.Errorf("output = %q, want 1", output)
+		}
+	})
+}
+
+func TestCmdMotifs(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		if err := cmdMotifs(osEnv, []string{}); err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "path.json")
+	hg := createTestGraph(t)
+	_ = hg.AddEdge("e3", []string{"c", "d"})
+	if err := osEnv.saveGraph(hg, path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdMotifs(osEnv, []string{"-f", path}); err != nil {
+				t.Fatalf("cmdMotifs failed: %v", err)
+			}
+		})
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		if len(lines) != 3 || !strings.Contains(lines[1], "open    B,C,AB,AC") || lines[2] != "Total: 1" {
+			t.Errorf("unexpected output:\n%s", output)
+		}
+	})
+
+	t.Run("all_sampled", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdMotifs(osEnv, []string{"-f", path, "-all", "-samples", "30", "-seed", "3"}); err != nil {
+				t.Fatalf("cmdMotifs failed: %v", err)
+			}
+		})
+		if n := strings.Count(output, "\n"); n != hypergraph.NumEdgeMotifs+2 {
+			t.Errorf("got %d lines, want %d:\n%s", n, hypergraph.NumEdgeMotifs+2, output)
+		}
+	})
+}
+
+func TestCmdRobustness(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		if err := cmdRobustness(osEnv, []string{}); err == nil || !strings.Contains(err.Error(), "missing required flag") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	dir := t.TempDir()
+	path := writeTestGraphFile(t, dir, "test.json")
+
+	t.Run("bad_flags", func(t *testing.T) {
+		if err := cmdRobustness(osEnv, []string{"-f", path, "-strategy", "pagerank"}); err == nil {
+			t.Error("expected error for unknown strategy")
+		}
+		if err := cmdRobustness(osEnv, []string{"-f", path, "-target", "roles"}); err == nil {
+			t.Error("expected error for unknown target")
+		}
+	})
+
+	// Test graph: e1={a,b}, e2={b,c}.
+	t.Run("degree", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdRobustness(osEnv, []string{"-f", path, "-strategy", "degree"}); err != nil {
+				t.Fatalf("cmdRobustness failed: %v", err)
+			}
+		})
+		want := "Removed  Fraction  Largest  Components  Next\n" +
+			"0        0.0000    3        1           b\n" +
+			"1        0.3333    1        2           a\n" +
+			"2        0.6667    1        1           c\n" +
+			"3        1.0000    0        0\n"
+		if output != want {
+			t.Errorf("output =\n%s\nwant\n%s", output, want)
+		}
+	})
+
+	t.Run("edges_json", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			if err := cmdRobustness(osEnv, []string{"-f", path, "-target", "edges", "-s", "1", "-json"}); err != nil {
+				t.Fatalf("cmdRobustness failed: %v", err)
+			}
+		})
+		var got struct {
+			Order []string                      `json:"order"`
+			Curve []hypergraph.PercolationPoint `json:"curve"`
+		}
+		if err := json.Unmarshal([]byte(output), &got); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, output)
+		}
+		if len(got.Order) != 2 || len(got.Curve) != 3 {
+			t.Fatalf("got %+v", got)
+		}
+		if first := got.Curve[0]; first.LargestComponent != 3 || first.SComponents != 1 || first.LargestSComponent != 2 {
+			t.Errorf("intact point = %+v", first)
+		}
+		if last := got.Curve[2]; last.Components != 3 || last.SComponents != 0 {
+			t.Errorf("final point = %+v", last)
+		}
+	})
+}
@@ -1,38 +1,109 @@
 package main
 
 import (
-	"flag"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"math"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/watchthelight/HypergraphGo/hypergraph"
 )
 
-func cmdInfo(args []string) error {
-	fs := flag.NewFlagSet("info", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdInfo(env *cmdEnv, args []string) error {
+	fs := env.flagSet("info")
+	src := sourceFlags(env, fs)
+	properties := fs.Bool("properties", false, "check structural properties")
+	timeout := fs.Duration("timeout", 10*time.Second, "maximum time for the balancedness check")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" {
-		return fmt.Errorf("missing required flag: -f FILE")
+	if src.missing() {
+		return fmt.Errorf("missing required flag: -f FILE (or -db DIR)")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Vertices: %d\n", hg.NumVertices())
-	fmt.Printf("Edges:    %d\n", hg.NumEdges())
-	fmt.Printf("Empty:    %v\n", hg.IsEmpty())
+	fmt.Fprintf(env.stdout(), "Vertices: %d\n", hg.NumVertices())
+	fmt.Fprintf(env.stdout(), "Edges:    %d\n", hg.NumEdges())
+	fmt.Fprintf(env.stdout(), "Empty:    %v\n", hg.IsEmpty())
+	fmt.Fprintf(env.stdout(), "Max core: %d\n", hg.Degeneracy())
+	if *properties {
+		printProperties(env.stdout(), hg, *timeout)
+	}
 	return nil
 }
 
-func cmdNew(args []string) error {
-	fs := flag.NewFlagSet("new", flag.ExitOnError)
+// printProperties prints each structural property as yes or no, with a
+// witness for every no.
+func printProperties(out io.Writer, hg *hypergraph.Hypergraph[string], timeout time.Duration) {
+	fmt.Fprintln(out, "Properties:")
+
+	edges := hg.Edges()
+	slices.Sort(edges)
+	if len(edges) == 0 {
+		fmt.Fprintln(out, "  Uniform:   yes")
+	} else {
+		k, _ := hg.EdgeSize(edges[0])
+		if ok, e := hg.IsKUniform(k); ok {
+			fmt.Fprintf(out, "  Uniform:   yes (k=%d)\n", k)
+		} else {
+			size, _ := hg.EdgeSize(e)
+			fmt.Fprintf(out, "  Uniform:   no (%s has %d members, %s has %d)\n", edges[0], k, e, size)
+		}
+	}
+
+	if ok, w := hg.IsLinear(); ok {
+		fmt.Fprintln(out, "  Linear:    yes")
+	} else {
+		shared := slices.Collect(hg.EdgeIntersection(w[0], w[1], hypergraph.Sorted))
+		fmt.Fprintf(out, "  Linear:    no (%s and %s share %s)\n", w[0], w[1], strings.Join(shared, ", "))
+	}
+
+	if ok, w := hg.IsSimple(); ok {
+		fmt.Fprintln(out, "  Simple:    yes")
+	} else {
+		fmt.Fprintf(out, "  Simple:    no (%s is contained in %s)\n", w[0], w[1])
+	}
+
+	if ok, clique := hg.IsConformal(); ok {
+		fmt.Fprintln(out, "  Conformal: yes")
+	} else {
+		fmt.Fprintf(out, "  Conformal: no (clique %s is in no edge)\n", strings.Join(clique, ", "))
+	}
+
+	if ok, family := hg.IsHelly(); ok {
+		fmt.Fprintln(out, "  Helly:     yes")
+	} else {
+		fmt.Fprintf(out, "  Helly:     no (%s pairwise intersect with no common vertex)\n", strings.Join(family, ", "))
+	}
+
+	ok, cycle, err := hg.IsBalanced(timeout)
+	switch {
+	case errors.Is(err, hypergraph.ErrCutoff):
+		fmt.Fprintln(out, "  Balanced:  unknown (no odd special cycle found before timeout)")
+	case ok:
+		fmt.Fprintln(out, "  Balanced:  yes")
+	default:
+		var b strings.Builder
+		for i, v := range cycle.Vertices {
+			fmt.Fprintf(&b, "%s -%s- ", v, cycle.Edges[i])
+		}
+		b.WriteString(cycle.Vertices[0])
+		fmt.Fprintf(out, "  Balanced:  no (odd special cycle %s)\n", b.String())
+	}
+}
+
+func cmdNew(env *cmdEnv, args []string) error {
+	fs := env.flagSet("new")
 	output := fs.String("o", "", "output file")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -42,13 +113,15 @@ func cmdNew(args []string) error {
 		return fmt.Errorf("missing required flag: -o FILE")
 	}
 
-	hg := hypergraph.NewHypergraph[string]()
-	return saveGraph(hg, *output)
+	return env.saveGraph(newGraph(env.vertexType), *output)
 }
 
-func cmdValidate(args []string) error {
-	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+func cmdValidate(env *cmdEnv, args []string) error {
+	fs := env.flagSet("validate")
 	file := fs.String("f", "", "input hypergraph JSON file")
+	strict := fs.Bool("strict", false, "treat warnings as errors")
+	fix := fs.Bool("fix", false, "write the canonical form of the file")
+	output := fs.String("o", "", "output file for -fix (default: modify in-place)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -57,29 +130,80 @@ func cmdValidate(args []string) error {
 		return fmt.Errorf("missing required flag: -f FILE")
 	}
 
-	_, err := loadGraph(*file)
-	if err != nil {
-		return fmt.Errorf("invalid: %w", err)
+	var hg *hypergraph.Hypergraph[string]
+	var diags []hypergraph.Diagnostic
+	if isNDJSON(*file) {
+		// NDJSON is checked by loading it; errors carry the line number.
+		loaded, err := env.loadGraph(*file)
+		if err != nil {
+			return fmt.Errorf("invalid: %w", err)
+		}
+		hg = loaded
+	} else {
+		data, err := env.readInput(*file)
+		if err != nil {
+			return err
+		}
+		t := jsonVertexType(data)
+		if t == hypergraph.VertexTypeInt {
+			var ints *hypergraph.Hypergraph[int]
+			if ints, diags = hypergraph.ValidateJSON[int](data); ints != nil {
+				hg, _ = hypergraph.MapVertices(ints, formatIntVertex)
+			}
+		} else {
+			hg, diags = hypergraph.ValidateJSON[string](data)
+		}
+		if hg != nil {
+			env.adoptGraph(hg, t)
+		}
+	}
+	errorCount := 0
+	for _, d := range diags {
+		fmt.Fprintf(env.stdout(), "%s:%s\n", *file, d)
+		if d.Severity == hypergraph.SeverityError {
+			errorCount++
+		}
+	}
+	warnings := len(diags) - errorCount
+
+	switch {
+	case hg == nil:
+		return fmt.Errorf("invalid: %d errors, %d warnings", errorCount, warnings)
+	case *fix:
+		outFile := *output
+		if outFile == "" {
+			outFile = *file
+		}
+		return env.saveGraph(hg, outFile)
+	case *strict && warnings > 0:
+		return fmt.Errorf("invalid: %d warnings", warnings)
+	case warnings > 0:
+		fmt.Fprintf(env.stdout(), "valid with %d warnings\n", warnings)
+	default:
+		fmt.Fprintln(env.stdout(), "valid")
 	}
-
-	fmt.Println("valid")
 	return nil
 }
 
-func cmdAddVertex(args []string) error {
-	fs := flag.NewFlagSet("add-vertex", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdAddVertex(env *cmdEnv, args []string) error {
+	fs := env.flagSet("add-vertex")
+	src := sourceFlags(env, fs)
 	vertex := fs.String("v", "", "vertex to add")
 	output := fs.String("o", "", "output file (default: modify in-place)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *vertex == "" {
-		return fmt.Errorf("missing required flags: -f FILE -v VERTEX")
+	if src.missing() || *vertex == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -v VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	if *src.db != "" {
+		return src.update(*output, func(s *hypergraph.Store[string]) error {
+			return s.AddVertex(*vertex)
+		})
+	}
+	hg, err := env.loadGraph(*src.file)
 	if err != nil {
 		return err
 	}
@@ -88,25 +212,33 @@ func cmdAddVertex(args []string) error {
 
 	outFile := *output
 	if outFile == "" {
-		outFile = *file
+		outFile = *src.file
 	}
-	return saveGraph(hg, outFile)
+	return env.saveGraph(hg, outFile)
 }
 
-func cmdRemoveVertex(args []string) error {
-	fs := flag.NewFlagSet("remove-vertex", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdRemoveVertex(env *cmdEnv, args []string) error {
+	fs := env.flagSet("remove-vertex")
+	src := sourceFlags(env, fs)
 	vertex := fs.String("v", "", "vertex to remove")
 	output := fs.String("o", "", "output file (default: modify in-place)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *vertex == "" {
-		return fmt.Errorf("missing required flags: -f FILE -v VERTEX")
+	if src.missing() || *vertex == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -v VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	if *src.db != "" {
+		return src.update(*output, func(s *hypergraph.Store[string]) error {
+			if !s.Graph().HasVertex(*vertex) {
+				return fmt.Errorf("vertex not found: %s", *vertex)
+			}
+			return s.RemoveVertex(*vertex)
+		})
+	}
+	hg, err := env.loadGraph(*src.file)
 	if err != nil {
 		return err
 	}
@@ -118,53 +250,54 @@ func cmdRemoveVertex(args []string) error {
 
 	outFile := *output
 	if outFile == "" {
-		outFile = *file
+		outFile = *src.file
 	}
-	return saveGraph(hg, outFile)
+	return env.saveGraph(hg, outFile)
 }
 
-func cmdHasVertex(args []string) error {
-	fs := flag.NewFlagSet("has-vertex", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdHasVertex(env *cmdEnv, args []string) error {
+	fs := env.flagSet("has-vertex")
+	src := sourceFlags(env, fs)
 	vertex := fs.String("v", "", "vertex to check")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *vertex == "" {
-		return fmt.Errorf("missing required flags: -f FILE -v VERTEX")
+	if src.missing() || *vertex == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -v VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
 
 	if hg.HasVertex(*vertex) {
-		fmt.Println("true")
+		fmt.Fprintln(env.stdout(), "true")
 		return nil
 	}
-	fmt.Println("false")
+	fmt.Fprintln(env.stdout(), "false")
 	return nil
 }
 
-func cmdAddEdge(args []string) error {
-	fs := flag.NewFlagSet("add-edge", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdAddEdge(env *cmdEnv, args []string) error {
+	fs := env.flagSet("add-edge")
+	src := sourceFlags(env, fs)
 	edgeID := fs.String("id", "", "edge ID")
 	members := fs.String("m", "", "comma-separated member vertices")
+	weight := fs.Float64("w", 1, "edge weight")
+	roles := fs.String("roles", "", "comma-separated roles, one per member")
+	ordered := fs.Bool("ordered", false, "keep member order as a tuple")
 	output := fs.String("o", "", "output file (default: modify in-place)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *edgeID == "" || *members == "" {
-		return fmt.Errorf("missing required flags: -f FILE -id ID -m MEMBERS")
+	if src.missing() || *edgeID == "" || *members == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -id ID -m MEMBERS")
 	}
-
-	hg, err := loadGraph(*file)
-	if err != nil {
-		return err
+	if *weight < 0 || math.IsNaN(*weight) || math.IsInf(*weight, 0) {
+		return fmt.Errorf("invalid edge weight %v", *weight)
 	}
 
 	memberList := strings.Split(*members, ",")
@@ -172,31 +305,80 @@ func cmdAddEdge(args []string) error {
 		memberList[i] = strings.TrimSpace(memberList[i])
 	}
 
-	if err := hg.AddEdge(*edgeID, memberList); err != nil {
+	var bindings []hypergraph.RoleBinding[string]
+	switch {
+	case *roles != "" && *ordered:
+		return fmt.Errorf("flags -roles and -ordered are mutually exclusive")
+	case *roles != "":
+		roleList := splitList(*roles)
+		if len(roleList) != len(memberList) {
+			return fmt.Errorf("got %d roles for %d members", len(roleList), len(memberList))
+		}
+		bindings = make([]hypergraph.RoleBinding[string], len(memberList))
+		for i := range memberList {
+			bindings[i] = hypergraph.RoleBinding[string]{Role: roleList[i], Vertex: memberList[i]}
+		}
+	}
+
+	// addEdge adds the edge to a hypergraph or a store.
+	addEdge := func(g edgeAdder) error {
+		var err error
+		switch {
+		case bindings != nil:
+			err = g.AddRoleEdge(*edgeID, bindings)
+		case *ordered:
+			err = g.AddOrderedEdge(*edgeID, memberList)
+		default:
+			err = g.AddEdge(*edgeID, memberList)
+		}
+		if err != nil || *weight == 1 {
+			return err
+		}
+		return g.SetEdgeWeight(*edgeID, *weight)
+	}
+
+	if *src.db != "" {
+		return src.update(*output, func(s *hypergraph.Store[string]) error {
+			return addEdge(s)
+		})
+	}
+	hg, err := env.loadGraph(*src.file)
+	if err != nil {
+		return err
+	}
+	if err := addEdge(hg); err != nil {
 		return err
 	}
 
 	outFile := *output
 	if outFile == "" {
-		outFile = *file
+		outFile = *src.file
 	}
-	return saveGraph(hg, outFile)
+	return env.saveGraph(hg, outFile)
 }
 
-func cmdRemoveEdge(args []string) error {
-	fs := flag.NewFlagSet("remove-edge", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdRemoveEdge(env *cmdEnv, args []string) error {
+	fs := env.flagSet("remove-edge")
+	src := sourceFlags(env, fs)
 	edgeID := fs.String("id", "", "edge ID to remove")
 	output := fs.String("o", "", "output file (default: modify in-place)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *edgeID == "" {
-		return fmt.Errorf("missing required flags: -f FILE -id ID")
+	if src.missing() || *edgeID == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -id ID")
 	}
 
-	hg, err := loadGraph(*file)
+	if *src.db != "" {
+		return src.update(*output, func(s *hypergraph.Store[string]) error {
+			if !s.Graph().HasEdge(*edgeID) {
+				return fmt.Errorf("edge not found: %s", *edgeID)
+			}
+			return s.RemoveEdge(*edgeID)
+		})
+	}
+	hg, err := env.loadGraph(*src.file)
 	if err != nil {
 		return err
 	}
@@ -208,72 +390,72 @@ func cmdRemoveEdge(args []string) error {
 
 	outFile := *output
 	if outFile == "" {
-		outFile = *file
+		outFile = *src.file
 	}
-	return saveGraph(hg, outFile)
+	return env.saveGraph(hg, outFile)
 }
 
-func cmdHasEdge(args []string) error {
-	fs := flag.NewFlagSet("has-edge", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdHasEdge(env *cmdEnv, args []string) error {
+	fs := env.flagSet("has-edge")
+	src := sourceFlags(env, fs)
 	edgeID := fs.String("id", "", "edge ID to check")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *edgeID == "" {
-		return fmt.Errorf("missing required flags: -f FILE -id ID")
+	if src.missing() || *edgeID == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -id ID")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
 
 	if hg.HasEdge(*edgeID) {
-		fmt.Println("true")
+		fmt.Fprintln(env.stdout(), "true")
 		return nil
 	}
-	fmt.Println("false")
+	fmt.Fprintln(env.stdout(), "false")
 	return nil
 }
 
-func cmdVertices(args []string) error {
-	fs := flag.NewFlagSet("vertices", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdVertices(env *cmdEnv, args []string) error {
+	fs := env.flagSet("vertices")
+	src := sourceFlags(env, fs)
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" {
-		return fmt.Errorf("missing required flag: -f FILE")
+	if src.missing() {
+		return fmt.Errorf("missing required flag: -f FILE (or -db DIR)")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
 
 	verts := hg.Vertices()
-	slices.Sort(verts)
+	slices.SortFunc(verts, hg.Compare)
 	for _, v := range verts {
-		fmt.Println(v)
+		fmt.Fprintln(env.stdout(), v)
 	}
 	return nil
 }
 
-func cmdEdges(args []string) error {
-	fs := flag.NewFlagSet("edges", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdEdges(env *cmdEnv, args []string) error {
+	fs := env.flagSet("edges")
+	src := sourceFlags(env, fs)
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" {
-		return fmt.Errorf("missing required flag: -f FILE")
+	if src.missing() {
+		return fmt.Errorf("missing required flag: -f FILE (or -db DIR)")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
@@ -282,25 +464,25 @@ func cmdEdges(args []string) error {
 	slices.Sort(edges)
 	for _, id := range edges {
 		members := hg.EdgeMembers(id)
-		slices.Sort(members)
-		fmt.Printf("%s: %s\n", id, strings.Join(members, ", "))
+		slices.SortFunc(members, hg.Compare)
+		fmt.Fprintf(env.stdout(), "%s: %s\n", id, strings.Join(members, ", "))
 	}
 	return nil
 }
 
-func cmdDegree(args []string) error {
-	fs := flag.NewFlagSet("degree", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdDegree(env *cmdEnv, args []string) error {
+	fs := env.flagSet("degree")
+	src := sourceFlags(env, fs)
 	vertex := fs.String("v", "", "vertex")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *vertex == "" {
-		return fmt.Errorf("missing required flags: -f FILE -v VERTEX")
+	if src.missing() || *vertex == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -v VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
@@ -308,23 +490,23 @@ func cmdDegree(args []string) error {
 	if !hg.HasVertex(*vertex) {
 		return fmt.Errorf("vertex not found: %s", *vertex)
 	}
-	fmt.Println(hg.VertexDegree(*vertex))
+	fmt.Fprintln(env.stdout(), hg.VertexDegree(*vertex))
 	return nil
 }
 
-func cmdEdgeSize(args []string) error {
-	fs := flag.NewFlagSet("edge-size", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdEdgeSize(env *cmdEnv, args []string) error {
+	fs := env.flagSet("edge-size")
+	src := sourceFlags(env, fs)
 	edgeID := fs.String("id", "", "edge ID")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *edgeID == "" {
-		return fmt.Errorf("missing required flags: -f FILE -id ID")
+	if src.missing() || *edgeID == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -id ID")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
@@ -333,26 +515,79 @@ func cmdEdgeSize(args []string) error {
 	if !ok {
 		return fmt.Errorf("edge not found: %s", *edgeID)
 	}
-	fmt.Println(size)
+	fmt.Fprintln(env.stdout(), size)
 	return nil
 }
 
-func cmdCopy(args []string) error {
-	fs := flag.NewFlagSet("copy", flag.ExitOnError)
-	file := fs.String("f", "", "input hypergraph JSON file")
+func cmdCopy(env *cmdEnv, args []string) error {
+	fs := env.flagSet("copy")
+	src := sourceFlags(env, fs)
 	output := fs.String("o", "", "output file")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
-	if *file == "" || *output == "" {
-		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
+	if src.missing() || *output == "" {
+		return fmt.Errorf("missing required flags: -f FILE (or -db DIR) -o OUTPUT")
+	}
+
+	hg, err := src.load()
+	if err != nil {
+		return err
+	}
+
+	return env.saveGraph(hg.Copy(), *output)
+}
+
+func cmdStats(env *cmdEnv, args []string) error {
+	fs := env.flagSet("stats")
+	src := sourceFlags(env, fs)
+	asJSON := fs.Bool("json", false, "print statistics as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if src.missing() {
+		return fmt.Errorf("missing required flag: -f FILE (or -db DIR)")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := src.load()
 	if err != nil {
 		return err
 	}
 
-	return saveGraph(hg.Copy(), *output)
+	s := hg.Stats()
+	if *asJSON {
+		enc := json.NewEncoder(env.stdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(s)
+	}
+
+	fmt.Fprintf(env.stdout(), "Vertices:      %d\n", s.Vertices)
+	fmt.Fprintf(env.stdout(), "Edges:         %d\n", s.Edges)
+	fmt.Fprintf(env.stdout(), "Density:       %.4f\n", s.Density)
+	fmt.Fprintf(env.stdout(), "Degree:        mean %.2f, max %d\n", s.MeanDegree, s.MaxDegree)
+	fmt.Fprintf(env.stdout(), "Edge size:     mean %.2f, max %d\n", s.MeanEdgeSize, s.MaxEdgeSize)
+	fmt.Fprintf(env.stdout(), "Clustering:    pairwise %.4f (global %.4f), higher-order %.4f (global %.4f)\n",
+		s.Clustering.PairwiseAverage, s.Clustering.PairwiseGlobal,
+		s.Clustering.HigherOrderAverage, s.Clustering.HigherOrderGlobal)
+	fmt.Fprintf(env.stdout(), "Assortativity: %.4f\n", s.Assortativity)
+	fmt.Fprintf(env.stdout(), "Components:    %d\n", s.Components)
+	printDistribution(env.stdout(), "Degree distribution (degree: vertices):", s.DegreeDistribution)
+	printDistribution(env.stdout(), "Edge size distribution (size: edges):", s.EdgeSizeDistribution)
+	printDistribution(env.stdout(), "Component sizes (size: components):", s.ComponentSizes)
+	fmt.Fprintln(env.stdout(), "Joint degree-size distribution (degree, size: memberships):")
+	for _, c := range s.JointDegreeSize {
+		fmt.Fprintf(env.stdout(), "  %d, %d: %d\n", c.Degree, c.Size, c.Count)
+	}
+	return nil
+}
+
+// printDistribution prints a title and then one "value: count" line per
+// value, in ascending order.
+func printDistribution(w io.Writer, title string, dist map[int]int) {
+	fmt.Fprintln(w, title)
+	for _, k := range slices.Sorted(maps.Keys(dist)) {
+		fmt.Fprintf(w, "  %d: %d\n", k, dist[k])
+	}
 }
@@ -2,9 +2,12 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -49,7 +52,7 @@ func TestCmdInfo(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := cmdInfo(tc.args)
+			err := cmdInfo(osEnv, tc.args)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatal("expected error")
@@ -69,7 +72,7 @@ func TestCmdInfo_ValidFile(t *testing.T) {
 	path := writeTestGraphFile(t, dir, "test.json")
 
 	output := captureStdout(t, func() {
-		err := cmdInfo([]string{"-f", path})
+		err := cmdInfo(osEnv, []string{"-f", path})
 		if err != nil {
 			t.Fatalf("cmdInfo failed: %v", err)
 		}
@@ -81,10 +84,13 @@ func TestCmdInfo_ValidFile(t *testing.T) {
 	if !strings.Contains(output, "Edges:    2") {
 		t.Errorf("output should contain 'Edges:    2', got: %s", output)
 	}
+	if !strings.Contains(output, "Max core: 1") {
+		t.Errorf("output should contain 'Max core: 1', got: %s", output)
+	}
 }
 
 func TestCmdInfo_MissingFile(t *testing.T) {
-	err := cmdInfo([]string{"-f", "/nonexistent/file.json"})
+	err := cmdInfo(osEnv, []string{"-f", "/nonexistent/file.json"})
 	if err == nil {
 		t.Fatal("expected error for missing file")
 	}
@@ -93,7 +99,7 @@ func TestCmdInfo_MissingFile(t *testing.T) {
 // TestCmdNew tests the new command.
 func TestCmdNew(t *testing.T) {
 	t.Run("missing_output_flag", func(t *testing.T) {
-		err := cmdNew([]string{})
+		err := cmdNew(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -106,12 +112,12 @@ func TestCmdNew(t *testing.T) {
 		dir := t.TempDir()
 		path := filepath.Join(dir, "new.json")
 
-		err := cmdNew([]string{"-o", path})
+		err := cmdNew(osEnv, []string{"-o", path})
 		if err != nil {
 			t.Fatalf("cmdNew failed: %v", err)
 		}
 
-		hg, err := loadGraph(path)
+		hg, err := osEnv.loadGraph(path)
 		if err != nil {
 			t.Fatalf("failed to load new graph: %v", err)
 		}
@@ -124,7 +130,7 @@ func TestCmdNew(t *testing.T) {
 // TestCmdValidate tests the validate command.
 func TestCmdValidate(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdValidate([]string{})
+		err := cmdValidate(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -135,7 +141,7 @@ func TestCmdValidate(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "valid.json")
 
 		output := captureStdout(t, func() {
-			err := cmdValidate([]string{"-f", path})
+			err := cmdValidate(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdValidate failed: %v", err)
 			}
@@ -151,7 +157,10 @@ func TestCmdValidate(t *testing.T) {
 		path := filepath.Join(dir, "invalid.json")
 		os.WriteFile(path, []byte("not json"), 0644)
 
-		err := cmdValidate([]string{"-f", path})
+		var err error
+		captureStdout(t, func() {
+			err = cmdValidate(osEnv, []string{"-f", path})
+		})
 		if err == nil {
 			t.Fatal("expected error for invalid file")
 		}
@@ -159,12 +168,69 @@ func TestCmdValidate(t *testing.T) {
 			t.Errorf("error should contain 'invalid', got: %v", err)
 		}
 	})
+
+	t.Run("diagnostics", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "g.json")
+		os.WriteFile(path, []byte("{\"edges\": {\"e1\": [\"a\", \"a\"], \"e2\": []}}"), 0644)
+
+		var err error
+		output := captureStdout(t, func() {
+			err = cmdValidate(osEnv, []string{"-f", path})
+		})
+		if err == nil || err.Error() != "invalid: 1 errors, 2 warnings" {
+			t.Errorf("unexpected error: %v", err)
+		}
+		want := path + ":1:19: warning: vertex a in edge \"e1\" is not listed in \"vertices\"\n" +
+			path + ":1:24: warning: vertex a appears more than once in edge \"e1\"\n" +
+			path + ":1:36: error: edge \"e2\" has no members\n"
+		if output != want {
+			t.Errorf("output:\n%s\nwant:\n%s", output, want)
+		}
+	})
+
+	t.Run("strict_and_fix", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "g.json")
+		os.WriteFile(path, []byte("{\"vertices\": [\"b\"], \"edges\": {\"e1\": [\"b\", \"a\", \"b\"]}}"), 0644)
+
+		output := captureStdout(t, func() {
+			if err := cmdValidate(osEnv, []string{"-f", path}); err != nil {
+				t.Errorf("warnings alone should not fail: %v", err)
+			}
+		})
+		if !strings.HasSuffix(output, "valid with 2 warnings\n") {
+			t.Errorf("output = %q", output)
+		}
+		captureStdout(t, func() {
+			if err := cmdValidate(osEnv, []string{"-f", path, "-strict"}); err == nil {
+				t.Error("-strict should fail on warnings")
+			}
+		})
+
+		fixed := filepath.Join(dir, "fixed.json")
+		captureStdout(t, func() {
+			if err := cmdValidate(osEnv, []string{"-f", path, "-fix", "-o", fixed}); err != nil {
+				t.Fatal(err)
+			}
+		})
+		data, _ := os.ReadFile(fixed)
+		if want := "{\"edges\":{\"e1\":[\"a\",\"b\"]},\"vertices\":[\"a\",\"b\"]}\n"; string(data) != want {
+			t.Errorf("fixed file = %s, want %s", data, want)
+		}
+		output = captureStdout(t, func() {
+			_ = cmdValidate(osEnv, []string{"-f", fixed, "-strict"})
+		})
+		if output != "valid\n" {
+			t.Errorf("fixed file output = %q", output)
+		}
+	})
 }
 
 // TestCmdAddVertex tests the add-vertex command.
 func TestCmdAddVertex(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdAddVertex([]string{})
+		err := cmdAddVertex(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -177,12 +243,12 @@ func TestCmdAddVertex(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdAddVertex([]string{"-f", path, "-v", "d"})
+		err := cmdAddVertex(osEnv, []string{"-f", path, "-v", "d"})
 		if err != nil {
 			t.Fatalf("cmdAddVertex failed: %v", err)
 		}
 
-		hg, err := loadGraph(path)
+		hg, err := osEnv.loadGraph(path)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -199,19 +265,19 @@ func TestCmdAddVertex(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "output.json")
 
-		err := cmdAddVertex([]string{"-f", inputPath, "-v", "newvert", "-o", outputPath})
+		err := cmdAddVertex(osEnv, []string{"-f", inputPath, "-v", "newvert", "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdAddVertex failed: %v", err)
 		}
 
 		// Check input unchanged
-		inputHg, _ := loadGraph(inputPath)
+		inputHg, _ := osEnv.loadGraph(inputPath)
 		if inputHg.HasVertex("newvert") {
 			t.Error("input should be unchanged")
 		}
 
 		// Check output has new vertex
-		outputHg, _ := loadGraph(outputPath)
+		outputHg, _ := osEnv.loadGraph(outputPath)
 		if !outputHg.HasVertex("newvert") {
 			t.Error("output should have new vertex")
 		}
@@ -221,7 +287,7 @@ func TestCmdAddVertex(t *testing.T) {
 // TestCmdRemoveVertex tests the remove-vertex command.
 func TestCmdRemoveVertex(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdRemoveVertex([]string{})
+		err := cmdRemoveVertex(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -231,12 +297,12 @@ func TestCmdRemoveVertex(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdRemoveVertex([]string{"-f", path, "-v", "a"})
+		err := cmdRemoveVertex(osEnv, []string{"-f", path, "-v", "a"})
 		if err != nil {
 			t.Fatalf("cmdRemoveVertex failed: %v", err)
 		}
 
-		hg, _ := loadGraph(path)
+		hg, _ := osEnv.loadGraph(path)
 		if hg.HasVertex("a") {
 			t.Error("vertex 'a' should have been removed")
 		}
@@ -246,7 +312,7 @@ func TestCmdRemoveVertex(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdRemoveVertex([]string{"-f", path, "-v", "nonexistent"})
+		err := cmdRemoveVertex(osEnv, []string{"-f", path, "-v", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent vertex")
 		}
@@ -259,7 +325,7 @@ func TestCmdRemoveVertex(t *testing.T) {
 // TestCmdHasVertex tests the has-vertex command.
 func TestCmdHasVertex(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdHasVertex([]string{})
+		err := cmdHasVertex(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -270,7 +336,7 @@ func TestCmdHasVertex(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdHasVertex([]string{"-f", path, "-v", "a"})
+			err := cmdHasVertex(osEnv, []string{"-f", path, "-v", "a"})
 			if err != nil {
 				t.Fatalf("cmdHasVertex failed: %v", err)
 			}
@@ -286,7 +352,7 @@ func TestCmdHasVertex(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdHasVertex([]string{"-f", path, "-v", "nonexistent"})
+			err := cmdHasVertex(osEnv, []string{"-f", path, "-v", "nonexistent"})
 			if err != nil {
 				t.Fatalf("cmdHasVertex failed: %v", err)
 			}
@@ -301,7 +367,7 @@ func TestCmdHasVertex(t *testing.T) {
 // TestCmdAddEdge tests the add-edge command.
 func TestCmdAddEdge(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdAddEdge([]string{})
+		err := cmdAddEdge(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -314,12 +380,12 @@ func TestCmdAddEdge(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdAddEdge([]string{"-f", path, "-id", "e3", "-m", "a,c"})
+		err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "e3", "-m", "a,c"})
 		if err != nil {
 			t.Fatalf("cmdAddEdge failed: %v", err)
 		}
 
-		hg, _ := loadGraph(path)
+		hg, _ := osEnv.loadGraph(path)
 		if !hg.HasEdge("e3") {
 			t.Error("edge 'e3' should have been added")
 		}
@@ -333,12 +399,12 @@ func TestCmdAddEdge(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdAddEdge([]string{"-f", path, "-id", "e3", "-m", " a , c "})
+		err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "e3", "-m", " a , c "})
 		if err != nil {
 			t.Fatalf("cmdAddEdge failed: %v", err)
 		}
 
-		hg, _ := loadGraph(path)
+		hg, _ := osEnv.loadGraph(path)
 		members := hg.EdgeMembers("e3")
 		hasA, hasC := false, false
 		for _, m := range members {
@@ -353,12 +419,77 @@ func TestCmdAddEdge(t *testing.T) {
 			t.Error("whitespace should be trimmed from member list")
 		}
 	})
+
+	t.Run("add_edge_with_weight", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "graph.json")
+
+		if err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "e3", "-m", "a,c", "-w", "2.5"}); err != nil {
+			t.Fatalf("cmdAddEdge failed: %v", err)
+		}
+
+		hg, _ := osEnv.loadGraph(path)
+		if w := hg.EdgeWeight("e3"); w != 2.5 {
+			t.Errorf("EdgeWeight(e3) = %v, want 2.5", w)
+		}
+	})
+
+	t.Run("add_edge_with_roles", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "graph.json")
+
+		err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "fact", "-m", "a,c,a", "-roles", "subject,object,witness"})
+		if err != nil {
+			t.Fatalf("cmdAddEdge failed: %v", err)
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if got := hg.VertexRoles("fact", "a"); !slices.Equal(got, []string{"subject", "witness"}) {
+			t.Errorf("roles of a = %v, want [subject witness]", got)
+		}
+	})
+
+	t.Run("add_ordered_edge", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "graph.json")
+
+		if err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "t", "-m", "c,a,b", "-ordered"}); err != nil {
+			t.Fatalf("cmdAddEdge failed: %v", err)
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if got := hg.OrderedMembers("t"); !slices.Equal(got, []string{"c", "a", "b"}) {
+			t.Errorf("OrderedMembers = %v, want [c a b]", got)
+		}
+	})
+
+	t.Run("role_count_mismatch", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "graph.json")
+		if err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "x", "-m", "a,b", "-roles", "subject"}); err == nil {
+			t.Fatal("expected error for mismatched roles")
+		}
+		if err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "x", "-m", "a,b", "-roles", "r,s", "-ordered"}); err == nil {
+			t.Fatal("expected error for -roles with -ordered")
+		}
+	})
+
+	t.Run("negative_weight", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "graph.json")
+
+		if err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "e3", "-m", "a,c", "-w", "-1"}); err == nil {
+			t.Fatal("expected error for negative weight")
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if hg.HasEdge("e3") {
+			t.Error("file should be unchanged after a failed add")
+		}
+	})
 }
 
 // TestCmdRemoveEdge tests the remove-edge command.
 func TestCmdRemoveEdge(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdRemoveEdge([]string{})
+		err := cmdRemoveEdge(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -368,12 +499,12 @@ func TestCmdRemoveEdge(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdRemoveEdge([]string{"-f", path, "-id", "e1"})
+		err := cmdRemoveEdge(osEnv, []string{"-f", path, "-id", "e1"})
 		if err != nil {
 			t.Fatalf("cmdRemoveEdge failed: %v", err)
 		}
 
-		hg, _ := loadGraph(path)
+		hg, _ := osEnv.loadGraph(path)
 		if hg.HasEdge("e1") {
 			t.Error("edge 'e1' should have been removed")
 		}
@@ -383,7 +514,7 @@ func TestCmdRemoveEdge(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdRemoveEdge([]string{"-f", path, "-id", "nonexistent"})
+		err := cmdRemoveEdge(osEnv, []string{"-f", path, "-id", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent edge")
 		}
@@ -396,7 +527,7 @@ func TestCmdRemoveEdge(t *testing.T) {
 // TestCmdHasEdge tests the has-edge command.
 func TestCmdHasEdge(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdHasEdge([]string{})
+		err := cmdHasEdge(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -407,7 +538,7 @@ func TestCmdHasEdge(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdHasEdge([]string{"-f", path, "-id", "e1"})
+			err := cmdHasEdge(osEnv, []string{"-f", path, "-id", "e1"})
 			if err != nil {
 				t.Fatalf("cmdHasEdge failed: %v", err)
 			}
@@ -423,7 +554,7 @@ func TestCmdHasEdge(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdHasEdge([]string{"-f", path, "-id", "nonexistent"})
+			err := cmdHasEdge(osEnv, []string{"-f", path, "-id", "nonexistent"})
 			if err != nil {
 				t.Fatalf("cmdHasEdge failed: %v", err)
 			}
@@ -438,7 +569,7 @@ func TestCmdHasEdge(t *testing.T) {
 // TestCmdVertices tests the vertices command.
 func TestCmdVertices(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdVertices([]string{})
+		err := cmdVertices(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -449,7 +580,7 @@ func TestCmdVertices(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdVertices([]string{"-f", path})
+			err := cmdVertices(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdVertices failed: %v", err)
 			}
@@ -469,7 +600,7 @@ func TestCmdVertices(t *testing.T) {
 // TestCmdEdges tests the edges command.
 func TestCmdEdges(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdEdges([]string{})
+		err := cmdEdges(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -480,7 +611,7 @@ func TestCmdEdges(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdEdges([]string{"-f", path})
+			err := cmdEdges(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdEdges failed: %v", err)
 			}
@@ -499,7 +630,7 @@ func TestCmdEdges(t *testing.T) {
 // TestCmdDegree tests the degree command.
 func TestCmdDegree(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdDegree([]string{})
+		err := cmdDegree(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -510,7 +641,7 @@ func TestCmdDegree(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdDegree([]string{"-f", path, "-v", "b"})
+			err := cmdDegree(osEnv, []string{"-f", path, "-v", "b"})
 			if err != nil {
 				t.Fatalf("cmdDegree failed: %v", err)
 			}
@@ -526,7 +657,7 @@ func TestCmdDegree(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdDegree([]string{"-f", path, "-v", "nonexistent"})
+		err := cmdDegree(osEnv, []string{"-f", path, "-v", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent vertex")
 		}
@@ -539,7 +670,7 @@ func TestCmdDegree(t *testing.T) {
 // TestCmdEdgeSize tests the edge-size command.
 func TestCmdEdgeSize(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdEdgeSize([]string{})
+		err := cmdEdgeSize(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -550,7 +681,7 @@ func TestCmdEdgeSize(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "graph.json")
 
 		output := captureStdout(t, func() {
-			err := cmdEdgeSize([]string{"-f", path, "-id", "e1"})
+			err := cmdEdgeSize(osEnv, []string{"-f", path, "-id", "e1"})
 			if err != nil {
 				t.Fatalf("cmdEdgeSize failed: %v", err)
 			}
@@ -566,7 +697,7 @@ func TestCmdEdgeSize(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "graph.json")
 
-		err := cmdEdgeSize([]string{"-f", path, "-id", "nonexistent"})
+		err := cmdEdgeSize(osEnv, []string{"-f", path, "-id", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent edge")
 		}
@@ -579,7 +710,7 @@ func TestCmdEdgeSize(t *testing.T) {
 // TestCmdCopy tests the copy command.
 func TestCmdCopy(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdCopy([]string{})
+		err := cmdCopy(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -593,18 +724,18 @@ func TestCmdCopy(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "output.json")
 
-		err := cmdCopy([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdCopy(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdCopy failed: %v", err)
 		}
 
 		// Verify copy was made
-		outputHg, err := loadGraph(outputPath)
+		outputHg, err := osEnv.loadGraph(outputPath)
 		if err != nil {
 			t.Fatalf("failed to load copied graph: %v", err)
 		}
 
-		inputHg, _ := loadGraph(inputPath)
+		inputHg, _ := osEnv.loadGraph(inputPath)
 		if outputHg.NumVertices() != inputHg.NumVertices() {
 			t.Errorf("copy should have same number of vertices")
 		}
@@ -618,13 +749,13 @@ func TestCmdCopy(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "output.json")
 
-		cmdCopy([]string{"-f", inputPath, "-o", outputPath})
+		cmdCopy(osEnv, []string{"-f", inputPath, "-o", outputPath})
 
 		// Modify original
-		cmdAddVertex([]string{"-f", inputPath, "-v", "new"})
+		cmdAddVertex(osEnv, []string{"-f", inputPath, "-v", "new"})
 
 		// Check copy is unchanged
-		outputHg, _ := loadGraph(outputPath)
+		outputHg, _ := osEnv.loadGraph(outputPath)
 		if outputHg.HasVertex("new") {
 			t.Error("copy should be independent of original")
 		}
@@ -635,7 +766,7 @@ func TestCmdCopy(t *testing.T) {
 func TestCmdHelp(t *testing.T) {
 	t.Run("no_args_shows_usage", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			err := cmdHelp([]string{})
+			err := cmdHelp(osEnv, []string{})
 			if err != nil {
 				t.Fatalf("cmdHelp failed: %v", err)
 			}
@@ -659,7 +790,7 @@ func TestCmdHelp(t *testing.T) {
 		for _, cmd := range commands {
 			t.Run(cmd, func(t *testing.T) {
 				output := captureStdout(t, func() {
-					err := cmdHelp([]string{cmd})
+					err := cmdHelp(osEnv, []string{cmd})
 					if err != nil {
 						t.Fatalf("cmdHelp %s failed: %v", cmd, err)
 					}
@@ -673,7 +804,7 @@ func TestCmdHelp(t *testing.T) {
 	})
 
 	t.Run("unknown_command", func(t *testing.T) {
-		err := cmdHelp([]string{"nonexistent"})
+		err := cmdHelp(osEnv, []string{"nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for unknown command")
 		}
@@ -688,7 +819,7 @@ func TestCmdHelp(t *testing.T) {
 func TestCommands_MissingInputFile(t *testing.T) {
 	commands := []struct {
 		name string
-		fn   func([]string) error
+		fn   func(*cmdEnv, []string) error
 		args []string
 	}{
 		{"info", cmdInfo, []string{"-f", "/nonexistent/file.json"}},
@@ -708,7 +839,7 @@ func TestCommands_MissingInputFile(t *testing.T) {
 
 	for _, tc := range commands {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.fn(tc.args)
+			err := tc.fn(osEnv, tc.args)
 			if err == nil {
 				t.Errorf("%s should fail for missing input file", tc.name)
 			}
@@ -722,11 +853,11 @@ func TestCommands_EmptyGraph(t *testing.T) {
 
 	// Create empty graph
 	hg := hypergraph.NewHypergraph[string]()
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	t.Run("info_empty", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdInfo([]string{"-f", path})
+			cmdInfo(osEnv, []string{"-f", path})
 		})
 		if !strings.Contains(output, "Vertices: 0") {
 			t.Errorf("should show 0 vertices, got: %s", output)
@@ -738,7 +869,7 @@ func TestCommands_EmptyGraph(t *testing.T) {
 
 	t.Run("vertices_empty", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdVertices([]string{"-f", path})
+			cmdVertices(osEnv, []string{"-f", path})
 		})
 		if strings.TrimSpace(output) != "" {
 			t.Errorf("should have no output for empty graph, got: %s", output)
@@ -747,7 +878,7 @@ func TestCommands_EmptyGraph(t *testing.T) {
 
 	t.Run("edges_empty", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdEdges([]string{"-f", path})
+			cmdEdges(osEnv, []string{"-f", path})
 		})
 		if strings.TrimSpace(output) != "" {
 			t.Errorf("should have no output for empty graph, got: %s", output)
@@ -757,7 +888,7 @@ func TestCommands_EmptyGraph(t *testing.T) {
 
 // TestCmdNew_InvalidOutputPath tests cmdNew with an invalid output path.
 func TestCmdNew_InvalidOutputPath(t *testing.T) {
-	err := cmdNew([]string{"-o", "/nonexistent/dir/file.json"})
+	err := cmdNew(osEnv, []string{"-o", "/nonexistent/dir/file.json"})
 	if err == nil {
 		t.Fatal("expected error for invalid output path")
 	}
@@ -768,7 +899,7 @@ func TestCmdCopy_InvalidOutputPath(t *testing.T) {
 	dir := t.TempDir()
 	inputPath := writeTestGraphFile(t, dir, "input.json")
 
-	err := cmdCopy([]string{"-f", inputPath, "-o", "/nonexistent/dir/file.json"})
+	err := cmdCopy(osEnv, []string{"-f", inputPath, "-o", "/nonexistent/dir/file.json"})
 	if err == nil {
 		t.Fatal("expected error for invalid output path")
 	}
@@ -779,7 +910,7 @@ func TestCmdAddVertex_InvalidOutputPath(t *testing.T) {
 	dir := t.TempDir()
 	inputPath := writeTestGraphFile(t, dir, "input.json")
 
-	err := cmdAddVertex([]string{"-f", inputPath, "-v", "x", "-o", "/nonexistent/dir/file.json"})
+	err := cmdAddVertex(osEnv, []string{"-f", inputPath, "-v", "x", "-o", "/nonexistent/dir/file.json"})
 	if err == nil {
 		t.Fatal("expected error for invalid output path")
 	}
@@ -790,7 +921,7 @@ func TestCmdAddEdge_InvalidOutputPath(t *testing.T) {
 	dir := t.TempDir()
 	inputPath := writeTestGraphFile(t, dir, "input.json")
 
-	err := cmdAddEdge([]string{"-f", inputPath, "-id", "e3", "-m", "a,b", "-o", "/nonexistent/dir/file.json"})
+	err := cmdAddEdge(osEnv, []string{"-f", inputPath, "-id", "e3", "-m", "a,b", "-o", "/nonexistent/dir/file.json"})
 	if err == nil {
 		t.Fatal("expected error for invalid output path")
 	}
@@ -802,8 +933,165 @@ func TestCmdAddEdge_DuplicateEdge(t *testing.T) {
 	path := writeTestGraphFile(t, dir, "graph.json")
 
 	// Try to add edge with ID that already exists (e1)
-	err := cmdAddEdge([]string{"-f", path, "-id", "e1", "-m", "a,c"})
+	err := cmdAddEdge(osEnv, []string{"-f", path, "-id", "e1", "-m", "a,c"})
 	if err == nil {
 		t.Fatal("expected error for duplicate edge ID")
 	}
 }
+
+func TestCmdInfo_Properties(t *testing.T) {
+	dir := t.TempDir()
+	hg := hypergraph.NewHypergraph[string]()
+	_ = hg.AddEdge("ab", []string{"a", "b"})
+	_ = hg.AddEdge("bc", []string{"b", "c"})
+	_ = hg.AddEdge("ac", []string{"a", "c"})
+	_ = hg.AddEdge("abc", []string{"a", "b", "c"})
+	path := filepath.Join(dir, "tri.json")
+	if err := osEnv.saveGraph(hg, path); err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdInfo(osEnv, []string{"-f", path, "--properties"}); err != nil {
+			t.Fatalf("cmdInfo failed: %v", err)
+		}
+	})
+
+	for _, want := range []string{
+		"Uniform:   no (ab has 2 members, abc has 3)",
+		"Linear:    no (ab and abc share a, b)",
+		"Simple:    no (ab is contained in abc)",
+		"Conformal: yes",
+		"Helly:     no (ab, abc, ac, bc pairwise intersect with no common vertex)",
+		"Balanced:  no (odd special cycle a -ab- b -bc- c -ac- a)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	plain := captureStdout(t, func() {
+		_ = cmdInfo(osEnv, []string{"-f", path})
+	})
+	if strings.Contains(plain, "Properties:") {
+		t.Error("properties printed without --properties")
+	}
+}
+
+func TestCmdStats(t *testing.T) {
+	t.Run("missing_file_flag", func(t *testing.T) {
+		err := cmdStats(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flag: -f FILE") {
+			t.Fatalf("expected missing flag error, got %v", err)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		output := captureStdout(t, func() {
+			if err := cmdStats(osEnv, []string{"-f", path}); err != nil {
+				t.Fatalf("cmdStats failed: %v", err)
+			}
+		})
+		for _, want := range []string{"Vertices:      3", "Density:       0.6667", "Components:    1", "  2: 2"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		output := captureStdout(t, func() {
+			if err := cmdStats(osEnv, []string{"-f", path, "--json"}); err != nil {
+				t.Fatalf("cmdStats failed: %v", err)
+			}
+		})
+		var s hypergraph.Stats
+		if err := json.Unmarshal([]byte(output), &s); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, output)
+		}
+		if s.Vertices != 3 || s.Edges != 2 || s.EdgeSizeDistribution[2] != 2 || s.DegreeDistribution[2] != 1 {
+			t.Errorf("stats = %+v", s)
+		}
+	})
+}
+
+// TestCmdStore tests the core commands against a store directory.
+func TestCmdStore(t *testing.T) {
+	db := filepath.Join(t.TempDir(), "db")
+
+	t.Run("missing_store", func(t *testing.T) {
+		err := cmdVertices(osEnv, []string{"-db", db})
+		if err == nil {
+			t.Fatal("reading a missing store should fail")
+		}
+		if _, statErr := os.Stat(db); !os.IsNotExist(statErr) {
+			t.Error("reading should not create the store")
+		}
+	})
+
+	t.Run("mutations", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"-db", db, "-id", "e1", "-m", "a,b"},
+			{"-db", db, "-id", "e2", "-m", "b,c", "-w", "2.5"},
+			{"-db", db, "-id", "t", "-m", "c,a", "-ordered"},
+		} {
+			if err := cmdAddEdge(osEnv, args); err != nil {
+				t.Fatalf("cmdAddEdge(osEnv, %v): %v", args, err)
+			}
+		}
+		if err := cmdAddVertex(osEnv, []string{"-db", db, "-v", "d"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmdRemoveEdge(osEnv, []string{"-db", db, "-id", "t"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmdRemoveVertex(osEnv, []string{"-db", db, "-v", "zz"}); err == nil || !strings.Contains(err.Error(), "vertex not found") {
+			t.Errorf("remove missing vertex: %v", err)
+		}
+		if err := cmdAddEdge(osEnv, []string{"-db", db, "-id", "e1", "-m", "c"}); !errors.Is(err, hypergraph.ErrDuplicateEdge) {
+			t.Errorf("duplicate edge: %v", err)
+		}
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			_ = cmdEdges(osEnv, []string{"-db", db})
+			_ = cmdDegree(osEnv, []string{"-db", db, "-v", "b"})
+			_ = cmdHasVertex(osEnv, []string{"-db", db, "-v", "d"})
+		})
+		want := "e1: a, b\ne2: b, c\n2\ntrue\n"
+		if output != want {
+			t.Errorf("output:\n%s\nwant:\n%s", output, want)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out.json")
+		if err := cmdCopy(osEnv, []string{"-db", db, "-o", out}); err != nil {
+			t.Fatal(err)
+		}
+		hg, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hg.NumVertices() != 4 || hg.EdgeWeight("e2") != 2.5 {
+			t.Errorf("exported %d vertices, e2 weight %v", hg.NumVertices(), hg.EdgeWeight("e2"))
+		}
+	})
+
+	t.Run("flag_errors", func(t *testing.T) {
+		if err := cmdAddVertex(osEnv, []string{"-db", db, "-v", "x", "-o", "out.json"}); err == nil || !strings.Contains(err.Error(), "-o cannot be used with -db") {
+			t.Errorf("-o with -db: %v", err)
+		}
+		if err := cmdEdges(osEnv, []string{"-db", db, "-f", "x.json"}); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+			t.Errorf("-f with -db: %v", err)
+		}
+		if err := cmdAddEdge(osEnv, []string{"-db", db, "-id", "e9", "-m", "a", "-w", "-1"}); err == nil || !strings.Contains(err.Error(), "invalid edge weight") {
+			t.Errorf("negative weight: %v", err)
+		}
+	})
+}
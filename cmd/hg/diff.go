@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+)
+
+func cmdDiff(env *cmdEnv, args []string) error {
+	fs := env.flagSet("diff")
+	from := fs.String("from", "", "original hypergraph JSON file")
+	to := fs.String("to", "", "changed hypergraph JSON file")
+	output := fs.String("o", "", "write the patch to a file")
+	asJSON := fs.Bool("json", false, "print the patch as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == "" || *to == "" {
+		return fmt.Errorf("missing required flags: -from OLD -to NEW")
+	}
+
+	a, err := env.loadGraph(*from)
+	if err != nil {
+		return err
+	}
+	b, err := env.loadGraph(*to)
+	if err != nil {
+		return err
+	}
+
+	p := hypergraph.Diff(a, b)
+	switch {
+	case *output != "":
+		return env.savePatch(p, *output)
+	case *asJSON:
+		return p.SaveJSON(env.stdout())
+	}
+	printPatch(env.stdout(), p)
+	return nil
+}
+
+// printPatch prints one line per change: "+" for additions, "-" for
+// removals and "~" for edges whose members or attributes changed.
+func printPatch(w io.Writer, p *hypergraph.Patch[string]) {
+	for _, v := range p.AddedVertices {
+		fmt.Fprintf(w, "+ vertex %s\n", v)
+	}
+	for _, v := range p.RemovedVertices {
+		fmt.Fprintf(w, "- vertex %s\n", v)
+	}
+	for _, e := range p.RemovedEdges {
+		fmt.Fprintf(w, "- edge %s\n", formatPatchEdge(e))
+	}
+	for _, c := range p.ChangedEdges {
+		if c.AddedMembers != nil || c.RemovedMembers != nil {
+			var parts []string
+			for _, v := range c.AddedMembers {
+				parts = append(parts, "+"+v)
+			}
+			for _, v := range c.RemovedMembers {
+				parts = append(parts, "-"+v)
+			}
+			fmt.Fprintf(w, "~ edge %s %s\n", c.ID, strings.Join(parts, " "))
+		}
+		if c.Weight != nil {
+			fmt.Fprintf(w, "~ edge %s weight %g -> %g\n", c.ID, c.Weight.From, c.Weight.To)
+		}
+		if c.Roles != nil {
+			fmt.Fprintf(w, "~ edge %s roles %s -> %s\n", c.ID, formatRoles(c.Roles.From), formatRoles(c.Roles.To))
+		}
+	}
+	for _, e := range p.AddedEdges {
+		fmt.Fprintf(w, "+ edge %s\n", formatPatchEdge(e))
+	}
+}
+
+func formatPatchEdge(e hypergraph.PatchEdge[string]) string {
+	s := fmt.Sprintf("%s {%s}", e.ID, strings.Join(e.Members, ", "))
+	if e.Weight != 1 {
+		s += fmt.Sprintf(" weight %g", e.Weight)
+	}
+	if e.Roles != nil {
+		s += " roles " + formatRoles(e.Roles)
+	}
+	return s
+}
+
+func formatRoles(bindings []hypergraph.RoleBinding[string]) string {
+	parts := make([]string, len(bindings))
+	for i, b := range bindings {
+		parts[i] = b.Role + "=" + b.Vertex
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+func cmdPatch(env *cmdEnv, args []string) error {
+	fs := env.flagSet("patch")
+	file := fs.String("f", "", "input hypergraph JSON file")
+	patch := fs.String("p", "", "patch file from hg diff -o")
+	output := fs.String("o", "", "output file (default: modify in-place)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || *patch == "" {
+		return fmt.Errorf("missing required flags: -f FILE -p PATCH")
+	}
+
+	hg, err := env.loadGraph(*file)
+	if err != nil {
+		return err
+	}
+	p, err := env.loadPatch(*patch)
+	if err != nil {
+		return err
+	}
+	if err := hg.ApplyPatch(p); err != nil {
+		return err
+	}
+
+	outFile := *output
+	if outFile == "" {
+		outFile = *file
+	}
+	return env.saveGraph(hg, outFile)
+}
+
+func cmdMerge(env *cmdEnv, args []string) error {
+	fs := env.flagSet("merge")
+	base := fs.String("base", "", "common ancestor hypergraph JSON file")
+	ours := fs.String("ours", "", "our hypergraph JSON file")
+	theirs := fs.String("theirs", "", "their hypergraph JSON file")
+	output := fs.String("o", "", "output file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *base == "" || *ours == "" || *theirs == "" || *output == "" {
+		return fmt.Errorf("missing required flags: -base BASE -ours OURS -theirs THEIRS -o OUTPUT")
+	}
+
+	graphs := make([]*hypergraph.Hypergraph[string], 3)
+	for i, name := range []string{*base, *ours, *theirs} {
+		hg, err := env.loadGraph(name)
+		if err != nil {
+			return err
+		}
+		graphs[i] = hg
+	}
+
+	merged, conflicts := hypergraph.Merge(graphs[0], graphs[1], graphs[2])
+	if err := env.saveGraph(merged, *output); err != nil {
+		return err
+	}
+	for _, c := range conflicts {
+		fmt.Fprintf(env.stdout(), "CONFLICT (%s): %s\n", c.Kind, c.Message)
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%d merge conflicts; kept ours in %s", len(conflicts), *output)
+	}
+	return nil
+}
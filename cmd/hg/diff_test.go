@@ -0,0 +1,135 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestCmdDiffPatch tests the diff and patch commands together.
+func TestCmdDiffPatch(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		if err := cmdDiff(osEnv, []string{"-from", "a.json"}); err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Errorf("diff: unexpected error: %v", err)
+		}
+		if err := cmdPatch(osEnv, []string{"-f", "a.json"}); err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Errorf("patch: unexpected error: %v", err)
+		}
+	})
+
+	dir := t.TempDir()
+	oldPath := writeTestGraphFile(t, dir, "old.json")
+	newPath := writeTestGraphFile(t, dir, "new.json")
+	hg, _ := osEnv.loadGraph(newPath)
+	hg.RemoveEdge("e1")
+	hg.RemoveVertex("a")
+	_ = hg.AddEdge("e3", []string{"c", "d"})
+	_ = hg.SetEdgeWeight("e2", 2.5)
+	if err := osEnv.saveGraph(hg, newPath); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("print_diff", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() {
+			err = cmdDiff(osEnv, []string{"-from", oldPath, "-to", newPath})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "+ vertex d\n- vertex a\n- edge e1 {a, b}\n~ edge e2 weight 1 -> 2.5\n+ edge e3 {c, d}\n"
+		if output != want {
+			t.Errorf("diff output:\n%s\nwant:\n%s", output, want)
+		}
+	})
+
+	t.Run("no_changes", func(t *testing.T) {
+		output := captureStdout(t, func() {
+			_ = cmdDiff(osEnv, []string{"-from", oldPath, "-to", oldPath})
+		})
+		if output != "" {
+			t.Errorf("identical files should print nothing, got %q", output)
+		}
+	})
+
+	t.Run("apply_patch", func(t *testing.T) {
+		patchPath := filepath.Join(dir, "change.patch")
+		if err := cmdDiff(osEnv, []string{"-from", oldPath, "-to", newPath, "-o", patchPath}); err != nil {
+			t.Fatal(err)
+		}
+		outPath := filepath.Join(dir, "patched.json")
+		if err := cmdPatch(osEnv, []string{"-f", oldPath, "-p", patchPath, "-o", outPath}); err != nil {
+			t.Fatalf("cmdPatch failed: %v", err)
+		}
+		patched, _ := osEnv.loadGraph(outPath)
+		if edges := slices.Sorted(slices.Values(patched.Edges())); !slices.Equal(edges, []string{"e2", "e3"}) {
+			t.Errorf("edges = %v", patched.Edges())
+		}
+		if patched.EdgeWeight("e2") != 2.5 || patched.HasVertex("a") {
+			t.Error("patch not fully applied")
+		}
+
+		// The patch no longer applies to its own result.
+		err := cmdPatch(osEnv, []string{"-f", outPath, "-p", patchPath})
+		if err == nil || !strings.Contains(err.Error(), "patch conflict") {
+			t.Errorf("expected conflict, got %v", err)
+		}
+	})
+}
+
+// TestCmdMerge tests the merge command.
+func TestCmdMerge(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		err := cmdMerge(osEnv, []string{"-base", "b.json"})
+		if err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	dir := t.TempDir()
+	base := writeTestGraphFile(t, dir, "base.json")
+	ours := writeTestGraphFile(t, dir, "ours.json")
+	theirs := writeTestGraphFile(t, dir, "theirs.json")
+	out := filepath.Join(dir, "merged.json")
+
+	o, _ := osEnv.loadGraph(ours)
+	_ = o.SetEdgeWeight("e1", 2)
+	_ = osEnv.saveGraph(o, ours)
+	th, _ := osEnv.loadGraph(theirs)
+	_ = th.AddEdge("e3", []string{"c", "d"})
+	_ = osEnv.saveGraph(th, theirs)
+
+	t.Run("clean", func(t *testing.T) {
+		var err error
+		output := captureStdout(t, func() {
+			err = cmdMerge(osEnv, []string{"-base", base, "-ours", ours, "-theirs", theirs, "-o", out})
+		})
+		if err != nil || output != "" {
+			t.Fatalf("err = %v, output = %q", err, output)
+		}
+		merged, _ := osEnv.loadGraph(out)
+		if merged.EdgeWeight("e1") != 2 || !merged.HasEdge("e3") {
+			t.Error("merge lost a change")
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_ = th.SetEdgeWeight("e1", 3)
+		_ = osEnv.saveGraph(th, theirs)
+		var err error
+		output := captureStdout(t, func() {
+			err = cmdMerge(osEnv, []string{"-base", base, "-ours", ours, "-theirs", theirs, "-o", out})
+		})
+		if err == nil || !strings.Contains(err.Error(), "1 merge conflicts") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !strings.Contains(output, `CONFLICT (weight): edge "e1" weight changed to 2 by ours and 3 by theirs`) {
+			t.Errorf("output = %q", output)
+		}
+		merged, _ := osEnv.loadGraph(out)
+		if merged.EdgeWeight("e1") != 2 || !merged.HasEdge("e3") {
+			t.Error("conflicting merge should keep ours and the clean changes")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+)
+
+// cmdEnv is where a command reads its inputs and writes its output. The
+// command-line tool runs every command in osEnv, with files and the
+// process's standard streams; hg serve runs commands against hypergraphs
+// in memory and captures what they print.
+type cmdEnv struct {
+	// out and errOut receive standard output and standard error; nil
+	// means the process's own.
+	out, errOut io.Writer
+	// graph, if set, resolves every input name to a hypergraph in memory,
+	// "-" to the one the command runs against. Files and stores are then
+	// unavailable, and output named "-" is the only output.
+	graph func(name string) (*hypergraph.Hypergraph[string], error)
+	// vertexType is the vertex type of the hypergraph files the command
+	// writes, hypergraph.VertexTypeString or hypergraph.VertexTypeInt. The
+	// global --vertex-type flag sets it in osEnv; otherwise the first file
+	// loaded does. In memory vertices are always strings.
+	vertexType string
+}
+
+// osEnv runs commands against the file system and standard streams.
+var osEnv = &cmdEnv{}
+
+// clone returns a copy of e. Commands that load several unrelated files,
+// such as hg serve, load each through its own clone so that each keeps its
+// own vertex type.
+func (e *cmdEnv) clone() *cmdEnv {
+	c := *e
+	return &c
+}
+
+func (e *cmdEnv) stdout() io.Writer {
+	if e.out == nil {
+		return os.Stdout
+	}
+	return e.out
+}
+
+func (e *cmdEnv) stderr() io.Writer {
+	if e.errOut == nil {
+		return os.Stderr
+	}
+	return e.errOut
+}
+
+// flagSet returns a flag set for a command. In memory, bad flags and -h
+// return an error rather than exit, so they cannot stop a server.
+func (e *cmdEnv) flagSet(name string) *flag.FlagSet {
+	if e.graph == nil {
+		return flag.NewFlagSet(name, flag.ExitOnError)
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr())
+	return fs
+}
+
+// open opens an input like openInput. In memory it reads the named
+// hypergraph as a JSON document.
+func (e *cmdEnv) open(filename string) (io.ReadCloser, error) {
+	if e.graph == nil {
+		return openInput(filename)
+	}
+	hg, err := e.graph(filename)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := hg.SaveJSON(&buf); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(&buf), nil
+}
+
+// create checks that a command may write filename, which in memory must
+// be "-".
+func (e *cmdEnv) create(filename string) error {
+	if e.graph != nil && filename != stdio {
+		return fmt.Errorf("cannot write %s: only - (the command output) is available", filename)
+	}
+	return nil
+}
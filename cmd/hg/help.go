@@ -5,10 +5,42 @@ import "fmt"
 var commandHelp = map[string]string{
 	"info": `hg info - Display hypergraph statistics
 
-Usage: hg info -f FILE
+Usage: hg info (-f FILE | -db DIR) [--properties] [-timeout D]
+
+Prints the vertex and edge counts and, as "Max core", the degeneracy (the
+largest k for which the k-core is non-empty).
+
+With --properties, also reports whether the hypergraph is uniform (all
+edges the same size), linear (edges share at most one vertex), simple (no
+edge contained in another), conformal (every clique of the 2-section lies
+in an edge), Helly (pairwise intersecting edges share a vertex) and
+balanced (no odd special cycle). Each failed check names a witness.
+Balancedness is an exponential search; it reports "unknown" if -timeout
+expires first.
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE        Input hypergraph JSON file
+  -db DIR        Hypergraph store directory, instead of -f
+  --properties   Check structural properties
+  -timeout D     Maximum time for the balancedness check (default 10s)`,
+
+	"stats": `hg stats - Degree, size and clustering statistics
+
+Usage: hg stats (-f FILE | -db DIR) [--json]
+
+Prints the degree and edge size distributions, density (the filled
+fraction of the incidence matrix), the joint degree-size distribution,
+clustering coefficients, degree assortativity and the component size
+histogram.
+
+Pairwise clustering is that of the 2-section. Higher-order clustering
+counts only triangles whose three sides come from three different edges,
+so a single large edge does not look clustered.
+
+Flags:
+  -f FILE    Input hypergraph JSON file
+  -db DIR    Hypergraph store directory, instead of -f
+  --json     Print the statistics as a JSON object`,
 
 	"new": `hg new - Create empty hypergraph
 
@@ -19,106 +51,191 @@ Flags:
 
 	"validate": `hg validate - Validate JSON file format
 
-Usage: hg validate -f FILE
+Usage: hg validate -f FILE [-strict] [-fix [-o OUTPUT]]
+
+Checks the whole file and prints every problem as
+FILE:LINE:COLUMN: SEVERITY: MESSAGE. Errors are content the loader
+rejects or silently loses, such as bad syntax, empty edges, duplicate
+edge IDs or weights for unknown edges. Warnings are content that loads
+but is probably a mistake: duplicate vertices or members, members
+missing from "vertices", edge IDs equal to a vertex name and unknown
+keys. The command fails if there are errors, or warnings with -strict.
+
+With -fix, a file without errors is rewritten in canonical form: keys
+and vertices sorted, members sorted and deduplicated, and every member
+listed as a vertex.
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE      Input hypergraph JSON file (required)
+  -strict      Treat warnings as errors
+  -fix         Write the canonical form of the file
+  -o OUTPUT    Output file for -fix (default: modify in-place)`,
 
 	"add-vertex": `hg add-vertex - Add a vertex
 
-Usage: hg add-vertex -f FILE -v VERTEX [-o OUTPUT]
+Usage: hg add-vertex (-f FILE | -db DIR) -v VERTEX [-o OUTPUT]
+
+With -db, the change is appended to the store's log, creating the store
+if it does not exist.
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -v VERTEX    Vertex to add (required)
-  -o OUTPUT    Output file (default: modify in-place)`,
+  -o OUTPUT    Output file (default: modify in-place; not with -db)`,
 
 	"remove-vertex": `hg remove-vertex - Remove a vertex
 
-Usage: hg remove-vertex -f FILE -v VERTEX [-o OUTPUT]
+Usage: hg remove-vertex (-f FILE | -db DIR) -v VERTEX [-o OUTPUT]
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -v VERTEX    Vertex to remove (required)
-  -o OUTPUT    Output file (default: modify in-place)`,
+  -o OUTPUT    Output file (default: modify in-place; not with -db)`,
 
 	"has-vertex": `hg has-vertex - Check vertex existence
 
-Usage: hg has-vertex -f FILE -v VERTEX
+Usage: hg has-vertex (-f FILE | -db DIR) -v VERTEX
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -v VERTEX    Vertex to check (required)
 
 Prints "true" or "false".`,
 
 	"add-edge": `hg add-edge - Add a hyperedge
 
-Usage: hg add-edge -f FILE -id ID -m MEMBERS [-o OUTPUT]
+Usage: hg add-edge (-f FILE | -db DIR) -id ID -m MEMBERS [-w WEIGHT]
+                   [-roles ROLES | -ordered] [-o OUTPUT]
+
+With -roles, the edge records which role each member plays, pairing the
+roles with the members by position; a vertex may be listed more than once
+with different roles. With -ordered, the member order is kept as a tuple.
+
+With -db, the change is appended to the store's log, creating the store
+if it does not exist.
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
-  -id ID       Edge ID (required)
-  -m MEMBERS   Comma-separated member vertices (required)
-  -o OUTPUT    Output file (default: modify in-place)`,
+  -f FILE       Input hypergraph JSON file
+  -db DIR       Hypergraph store directory, instead of -f
+  -id ID        Edge ID (required)
+  -m MEMBERS    Comma-separated member vertices (required)
+  -w WEIGHT     Edge weight (default: 1)
+  -roles ROLES  Comma-separated roles, one per member
+  -ordered      Keep member order as a tuple
+  -o OUTPUT     Output file (default: modify in-place; not with -db)`,
 
 	"remove-edge": `hg remove-edge - Remove a hyperedge
 
-Usage: hg remove-edge -f FILE -id ID [-o OUTPUT]
+Usage: hg remove-edge (-f FILE | -db DIR) -id ID [-o OUTPUT]
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -id ID       Edge ID to remove (required)
-  -o OUTPUT    Output file (default: modify in-place)`,
+  -o OUTPUT    Output file (default: modify in-place; not with -db)`,
 
 	"has-edge": `hg has-edge - Check edge existence
 
-Usage: hg has-edge -f FILE -id ID
+Usage: hg has-edge (-f FILE | -db DIR) -id ID
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -id ID       Edge ID to check (required)
 
 Prints "true" or "false".`,
 
 	"vertices": `hg vertices - List all vertices
 
-Usage: hg vertices -f FILE
+Usage: hg vertices (-f FILE | -db DIR)
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE    Input hypergraph JSON file
+  -db DIR    Hypergraph store directory, instead of -f`,
 
 	"edges": `hg edges - List all edges
 
-Usage: hg edges -f FILE
+Usage: hg edges (-f FILE | -db DIR)
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE    Input hypergraph JSON file
+  -db DIR    Hypergraph store directory, instead of -f`,
 
 	"degree": `hg degree - Get vertex degree
 
-Usage: hg degree -f FILE -v VERTEX
+Usage: hg degree (-f FILE | -db DIR) -v VERTEX
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -v VERTEX    Vertex (required)`,
 
 	"edge-size": `hg edge-size - Get edge size
 
-Usage: hg edge-size -f FILE -id ID
+Usage: hg edge-size (-f FILE | -db DIR) -id ID
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -id ID       Edge ID (required)`,
 
 	"copy": `hg copy - Copy hypergraph
 
-Usage: hg copy -f FILE -o OUTPUT
+Usage: hg copy (-f FILE | -db DIR) -o OUTPUT
+
+With -db, exports the hypergraph in the store to a file.
 
 Flags:
-  -f FILE      Input hypergraph JSON file (required)
+  -f FILE      Input hypergraph JSON file
+  -db DIR      Hypergraph store directory, instead of -f
   -o OUTPUT    Output file (required)`,
 
+	"query": `hg query - Select edges or vertices by predicate
+
+Usage: hg query -f FILE [-o OUTPUT] QUERY
+
+Evaluates a query and prints the matching edges or vertices. With -o,
+writes the result as a hypergraph instead: the selected edges with their
+members, or the subhypergraph induced by the selected vertices.
+
+Query syntax:
+  edges [where EXPR]
+  vertices [where EXPR]
+
+Predicates (combine with and, or, not, parentheses):
+  size OP N               Edge size (edges only)
+  degree OP N             Vertex degree (vertices only)
+  weight OP X             Edge weight, such as 2 or 0.5 (edges only)
+  id OP "S"               Edge ID or vertex name; edge IDs compare as text,
+                          vertices in vertex order (numerically for int)
+  id matches "REGEX"      Edge ID or vertex name matches a regular expression
+  contains "V"            Edge contains vertex V (edges only)
+  in "E"                  Vertex belongs to edge E (vertices only)
+  within D of "V"         Vertex (or some edge member) within D hops of V
+  role "R" is "V"         Vertex V plays role R in the edge (edges only)
+  role "R" matches "REGEX"
+                          The vertex playing role R in the edge matches a
+                          regular expression (edges only)
+  plays "R"               Vertex plays role R in some edge (vertices only)
+
+OP is one of = != < <= > >=. Edge IDs, vertex names and role bindings are
+the attributes a pattern can match.
+
+Examples:
+  hg query -f g.json 'edges where size >= 3 and contains "A"'
+  hg query -f g.json 'vertices where degree >= 2 and degree <= 5'
+  hg query -f g.json 'edges where weight > 1.5'
+  hg query -f g.json -o near.json 'vertices where within 2 of "A"'
+  hg query -f facts.json 'edges where role "subject" is "alice"'
+  hg query -f facts.json 'edges where role "object" matches "^doc-"'
+
+Flags:
+  -f FILE      Input hypergraph JSON file (required)
+  -o OUTPUT    Write the result as a hypergraph file`,
+
 	"dual": `hg dual - Compute dual hypergraph
 
 Usage: hg dual -f FILE -o OUTPUT
@@ -143,22 +260,77 @@ Flags:
 
 	"line-graph": `hg line-graph - Compute line graph
 
-Usage: hg line-graph -f FILE -o OUTPUT
+Usage: hg line-graph -f FILE -o OUTPUT [-workers N]
 
 The line graph has edges as vertices, connected if the original edges
 shared a vertex.
 
+Flags:
+  -f FILE       Input hypergraph JSON file (required)
+  -o OUTPUT     Output file (required)
+  -workers N    Build the line graph with N goroutines`,
+
+	"star": `hg star - Compute star expansion (incidence graph)
+
+Usage: hg star -f FILE -o OUTPUT
+
+The star expansion is a bipartite graph with a node "v:NAME" for every
+vertex and a node "e:ID" for every edge, joined when the vertex is a
+member of the edge. "hg from-graph -mode star" reverses it.
+
 Flags:
   -f FILE      Input hypergraph JSON file (required)
   -o OUTPUT    Output file (required)`,
 
+	"from-graph": `hg from-graph - Rebuild a hypergraph from a graph
+
+Usage: hg from-graph -f FILE -o OUTPUT [-mode MODE]
+
+Reads a graph in the format written by two-section, line-graph and star,
+and writes a hypergraph built from it.
+
+Modes:
+  cliques    One edge per maximal clique, named c0, c1, ... (default).
+             The 2-section of the result is the input graph.
+  star       Read a star expansion: "e:" nodes become edges of their
+             neighbors, "v:" nodes become vertices.
+
+Flags:
+  -f FILE      Input graph JSON file (required)
+  -o OUTPUT    Output file (required)
+  -mode MODE   cliques or star (default cliques)`,
+
+	"slice": `hg slice - Time-window slice of a temporal hypergraph
+
+Usage: hg slice -f FILE -o OUTPUT [-from T1] [-to T2] [-aggregate MODE]
+
+Reads a temporal hypergraph, whose "times" object maps edge IDs to lists
+of {"start": T, "end": T} activation intervals, and writes the plain
+hypergraph of edges active at some time in [T1, T2]. All vertices are
+kept. Use -from T -to T for a snapshot at time T.
+
+With -aggregate count, each edge's weight becomes the number of its
+activations in the window; with -aggregate duration, their total length
+clipped to the window.
+
+Flags:
+  -f FILE          Input temporal hypergraph JSON file (required)
+  -o OUTPUT        Output file (required)
+  -from T1         Window start time (default: unbounded)
+  -to T2           Window end time (default: unbounded)
+  -aggregate MODE  Weight edges by activity: count or duration`,
+
 	"bfs": `hg bfs - Breadth-first search
 
-Usage: hg bfs -f FILE -start VERTEX
+Usage: hg bfs -f FILE -start VERTEX [-workers N]
+
+With -workers, runs a level-synchronous BFS on N goroutines and prints
+vertices by distance, sorted within each level.
 
 Flags:
   -f FILE        Input hypergraph JSON file (required)
-  -start VERTEX  Starting vertex (required)`,
+  -start VERTEX  Starting vertex (required)
+  -workers N     Use the parallel BFS with N goroutines`,
 
 	"dfs": `hg dfs - Depth-first search
 
@@ -170,20 +342,65 @@ Flags:
 
 	"components": `hg components - Connected components
 
-Usage: hg components -f FILE
+Usage: hg components -f FILE [-workers N]
+
+With -workers, uses a concurrent union-find on N goroutines. Components
+are then listed in order of their smallest vertex.
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE       Input hypergraph JSON file (required)
+  -workers N    Use parallel union-find with N goroutines`,
 
 	"hitting-set": `hg hitting-set - Greedy hitting set
 
-Usage: hg hitting-set -f FILE
+Usage: hg hitting-set -f FILE [-exact] [-timeout DURATION]
 
 Computes a hitting set using a greedy algorithm. A hitting set contains
-at least one vertex from each edge.
+at least one vertex from each edge. With -exact, computes a minimum
+hitting set by branch and bound; on timeout the best set found is printed
+with a warning.
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE             Input hypergraph JSON file (required)
+  -exact              Compute a minimum hitting set
+  -timeout DURATION   Maximum time for -exact (default: 10s)`,
+
+	"core": `hg core - Core decomposition
+
+Usage: hg core -f FILE [-k K [-l L] -o OUTPUT]
+
+Without -k, prints the coreness of every vertex: the largest k such that
+the vertex lies in the k-core. The k-core is the largest induced
+subhypergraph in which every vertex belongs to at least k edges; edges
+leave the core as soon as one of their members does.
+
+With -k, writes the k-core to OUTPUT instead. With -l as well, writes the
+(k,l)-core: the k-core after dropping the edges of fewer than l members.
+Edges are never trimmed, so -l 1 gives the k-core.
+
+Flags:
+  -f FILE      Input hypergraph JSON file (required)
+  -k K         Extract the k-core
+  -l L         With -k, extract the (k,l)-core
+  -o OUTPUT    Output file for the core (required with -k)`,
+
+	"independent": `hg independent - Independent vertex set
+
+Usage: hg independent -f FILE [-weak] [-exact] [-timeout DURATION]
+
+Computes a set of vertices no two of which share an edge (strong
+independence). With -weak, only forbids edges contained entirely in the
+set; the complement of a weak independent set is a hitting set.
+
+By default a greedy maximal set is printed. With -exact, a maximum set is
+computed by branch and bound; on timeout the best set found is printed
+with a warning.
+
+Flags:
+  -f FILE             Input hypergraph JSON file (required)
+  -weak               Use weak instead of strong independence
+  -exact              Compute a maximum independent set
+  -timeout DURATION   Maximum time for -exact (default: 10s)`,
 
 	"transversals": `hg transversals - Minimal transversals
 
@@ -199,13 +416,138 @@ Flags:
 
 	"coloring": `hg coloring - Greedy vertex coloring
 
-Usage: hg coloring -f FILE
+Usage: hg coloring -f FILE [-workers N]
 
 Computes a vertex coloring using a greedy algorithm. No two vertices
-sharing a hyperedge receive the same color.
+sharing a hyperedge receive the same color. With -workers, uses
+Jones-Plassmann coloring on N goroutines; its result is the same for
+every N but may differ from the greedy coloring.
 
 Flags:
-  -f FILE    Input hypergraph JSON file (required)`,
+  -f FILE       Input hypergraph JSON file (required)
+  -workers N    Use parallel coloring with N goroutines`,
+
+	"homology": `hg homology - Simplicial homology over Z/2
+
+Usage: hg homology -f FILE [-max-dim N] [-cycles]
+
+Builds the downward-closed simplicial complex of the hypergraph (every
+non-empty subset of an edge is a simplex) and prints its dimension,
+simplex counts per dimension, Euler characteristic and Z/2 Betti numbers.
+
+An edge with n members yields 2^n - 1 simplices, so -max-dim caps the
+simplex dimension. With a cap, the top Betti number counts all cycles in
+that dimension. The command refuses inputs whose capped complex would
+exceed 2,000,000 simplices; -max-dim -1 builds the full closure with no
+such check.
+
+Flags:
+  -f FILE      Input hypergraph JSON file (required)
+  -max-dim N   Maximum simplex dimension, or -1 for no limit (default: 3)
+  -cycles      Print representative cycles for each homology group`,
+
+	"mincut": `hg mincut - Minimum hyperedge cut
+
+Usage: hg mincut -f FILE [-s SOURCES -t SINKS]
+
+With -s and -t, finds the lightest set of hyperedges whose removal
+separates every source vertex from every sink vertex (Lawler's flow
+construction with Dinic's max-flow). Without them, finds the lightest
+set of hyperedges splitting the hypergraph into two parts.
+
+Edge weights from the file are used; edges default to weight 1.
+Prints the cut weight, the cut edges and the source side.
+
+Flags:
+  -f FILE      Input hypergraph JSON file (required)
+  -s SOURCES   Comma-separated source vertices
+  -t SINKS     Comma-separated sink vertices`,
+
+	"edge-cover": `hg edge-cover - Minimum-weight edge cover
+
+Usage: hg edge-cover -f FILE [-method greedy|lp|exact] [-timeout DURATION]
+
+Finds a set of hyperedges containing every vertex with small total
+weight. Edge weights from the file are used; edges default to weight 1.
+Fails if some vertex belongs to no edge.
+
+Methods:
+  greedy   Pick the cheapest edge per newly covered vertex (within
+           1 + ln k of optimal for edges of size at most k)
+  lp       Round the linear programming relaxation (within f of optimal
+           where f is the largest vertex degree)
+  exact    Branch and bound; on timeout prints the best cover found
+
+Flags:
+  -f FILE             Input hypergraph JSON file (required)
+  -method METHOD      Solver to use (default: greedy)
+  -timeout DURATION   Maximum time for -method exact (default: 10s)`,
+
+	"match": `hg match - Find embeddings of a pattern hypergraph
+
+Usage: hg match -f FILE -pattern PATTERN [-induced] [-max N] [-timeout DURATION] [-count]
+
+Finds every way to map the vertices of PATTERN to distinct vertices of
+FILE such that each pattern edge maps onto an edge of FILE with exactly
+the image members. Prints one embedding per line as pattern=host pairs,
+vertices first, then edges. A symmetric pattern matches each occurrence
+once per symmetry.
+
+Flags:
+  -f FILE             Input hypergraph JSON file (required)
+  -pattern PATTERN    Pattern hypergraph JSON file (required)
+  -induced            Also forbid other edges among the image vertices
+  -max N              Stop after N embeddings (default: 0, no limit)
+  -timeout DURATION   Maximum search time (default: 10s)
+  -count              Print only the number of embeddings`,
+
+	"motifs": `hg motifs - Count 3-edge motifs
+
+Usage: hg motifs -f FILE [-samples N] [-seed SEED] [-all]
+
+Counts the connected triples of distinct hyperedges by which regions of
+their Venn diagram are non-empty (h-motifs). There are 26 such motifs;
+in an open motif two of the edges are disjoint. The counts, normalized,
+form a motif profile for comparing hypergraphs.
+
+Prints the motif number, whether it is open or closed, its non-empty
+regions for edges A, B and C, and its count.
+
+Flags:
+  -f FILE       Input hypergraph JSON file (required)
+  -samples N    Estimate the counts from N edges sampled at random
+                (default: 0, exact counts)
+  -seed SEED    Random seed for -samples (default: 1)
+  -all          Also list motifs with no instances`,
+
+	"robustness": `hg robustness - Percolation curve under vertex or edge removal
+
+Usage: hg robustness -f FILE [-strategy STRATEGY] [-target vertices|edges] [-s S] [-seed SEED] [-json]
+
+Removes vertices (or edges) one at a time and reports, after each
+removal, the size of the largest connected component and the number of
+components. A removed vertex leaves its edges; removed edges leave their
+vertices behind as components of their own. Each line also names the
+next vertex or edge to be removed.
+
+Strategies:
+  random       Uniformly random order (see -seed)
+  degree       Highest degree first; for edges, largest first
+  coreness     Highest core number first, ties by degree
+  centrality   Highest betweenness first
+
+Targeted orders are computed once on the intact hypergraph; for edges
+they are computed on the dual.
+
+Flags:
+  -f FILE            Input hypergraph JSON file (required)
+  -strategy NAME     Removal order (default: random)
+  -target TARGET     Remove vertices or edges (default: vertices)
+  -s S               Also report the largest s-component and the number
+                     of s-components, where edges sharing at least S
+                     vertices are s-adjacent
+  -seed SEED         Random seed for -strategy random (default: 1)
+  -json              Print the removal order and curve as JSON`,
 
 	"incidence": `hg incidence - Print incidence matrix
 
@@ -216,6 +558,85 @@ Prints the incidence matrix in COO (coordinate) format.
 Flags:
   -f FILE    Input hypergraph JSON file (required)`,
 
+	"render": `hg render - Draw hypergraph as SVG
+
+Usage: hg render -f FILE -o OUTPUT [-style star|hull] [-seed N]
+                 [-color none|communities|coloring] [-labels=false]
+                 [-width W] [-height H] [-iterations N]
+                 [-max-vertices N] [-max-edges N]
+
+Lays out the hypergraph with a force-directed layout of its star
+expansion and writes an SVG image. The star style draws each edge as a
+hub with spokes to its members; the hull style draws a rounded region
+around them. The same seed always gives the same image.
+
+With -color communities, vertices are colored by Louvain community; with
+-color coloring, by greedy color class. Edges take the majority color of
+their members. Inputs above the size limits are refused, since the
+layout is quadratic in their size.
+
+Flags:
+  -f FILE            Input hypergraph JSON file (required)
+  -o OUTPUT          Output SVG file (required)
+  -style S           Edge style: star or hull (default: star)
+  -seed N            Layout seed (default: 0)
+  -color C           Vertex coloring: none, communities or coloring
+  -labels            Draw vertex and edge labels (default: true)
+  -width W           Image width in pixels (default: 800)
+  -height H          Image height in pixels (default: 600)
+  -iterations N      Layout iterations, 0 for the initial layout
+                     (default: 200)
+  -max-vertices N    Vertex limit, -1 for none (default: 500)
+  -max-edges N       Edge limit, -1 for none (default: 500)`,
+
+	"diff": `hg diff - Structural diff of two hypergraph files
+
+Usage: hg diff -from OLD -to NEW [-json | -o PATCH]
+
+Compares two hypergraphs by structure rather than by text. Prints one
+line per change, sorted: "+"/"-" for added and removed vertices and
+edges, and "~" for edges whose members, weight or roles changed. Prints
+nothing if the files are equivalent.
+
+With -o, writes a JSON patch that hg patch can apply instead; -json
+prints the same patch to stdout.
+
+Flags:
+  -from OLD    Original hypergraph JSON file (required)
+  -to NEW      Changed hypergraph JSON file (required)
+  -o PATCH     Write the patch to a file
+  -json        Print the patch as JSON`,
+
+	"patch": `hg patch - Apply a patch from diff
+
+Usage: hg patch -f FILE -p PATCH [-o OUTPUT]
+
+Applies a patch written by hg diff -o. Every change is checked against
+the file first; if any does not match (an edge to remove differs, a
+vertex to add already exists, ...), nothing is written.
+
+Flags:
+  -f FILE      Input hypergraph JSON file (required)
+  -p PATCH     Patch file (required)
+  -o OUTPUT    Output file (default: modify in-place)`,
+
+	"merge": `hg merge - Three-way merge of hypergraph files
+
+Usage: hg merge -base BASE -ours OURS -theirs THEIRS -o OUTPUT
+
+Combines the changes OURS and THEIRS each made to BASE. Vertices, edge
+members, weights and roles merge independently, so edits to different
+parts of the same edge both survive. Conflicting edits, such as both
+sides setting different weights or one removing an edge the other
+changed, keep OURS and are reported; the command then fails after
+writing OUTPUT.
+
+Flags:
+  -base BASE       Common ancestor file (required)
+  -ours OURS       Our version (required)
+  -theirs THEIRS   Their version (required)
+  -o OUTPUT        Output file (required)`,
+
 	"repl": `hg repl - Interactive mode
 
 Usage: hg repl [-f FILE]
@@ -240,15 +661,71 @@ Operations (without flags):
   remove-edge ID       Remove edge
   vertices             List vertices
   edges                List edges
+  query QUERY          Select edges or vertices
   bfs V                BFS from vertex
   dfs V                DFS from vertex
   components           Show components
   hitting-set          Compute hitting set
   coloring             Compute coloring
   dual                 Compute dual (replaces current)`,
+
+	"serve": `hg serve - Serve hypergraphs over HTTP
+
+Usage: hg serve [-addr ADDR] [NAME=]FILE...
+
+Loads each file once and answers commands against the hypergraphs in
+memory, so many small queries do not each reload a file. A hypergraph is
+named NAME, or after its file without extensions.
+
+Endpoints (JSON):
+  GET  /graphs              List hypergraphs with their sizes
+  POST /graphs/NAME/exec    Run {"command": "..."}; returns {"output": "..."}
+  POST /graphs/NAME/save    Write the hypergraph back to its file
+
+Commands use the REPL grammar (see hg help repl) except :load, :save, :new
+and :quit. The commands info, stats, copy, two-section, line-graph, star,
+transversals, core, independent, homology, mincut, edge-cover, match,
+motifs, robustness, incidence and render also run, with their usual flags
+and the served hypergraph as -f. Other inputs name served hypergraphs
+(match -pattern NAME); output that would go to a file needs -o -, and
+these commands never change a hypergraph.
+
+A failed command returns status 422 and {"error": "..."}. Read-only
+commands on the same hypergraph run concurrently; a command that changes
+it waits for the others and runs alone. Changes stay in memory
+until saved; they are discarded when the server stops. Go programs can
+call the server with package hypergraph/hgclient.
+
+Flags:
+  -addr ADDR   Address to listen on (default: 127.0.0.1:7070)`,
+
+	"run": `hg run - Run a script of REPL commands
+
+Usage: hg run [-f FILE] [-o OUTPUT] [--dry-run] SCRIPT
+
+Runs the commands in SCRIPT, one per line in the REPL grammar (see hg help
+repl), against one hypergraph in memory. Blank lines and lines starting
+with # are skipped; :load, :save, :new and :quit are not allowed.
+
+The script stops at the first failing command, reporting its line, and
+then nothing is saved. Otherwise the result is written to OUTPUT, or back
+to FILE if any command changed it. A script that changes the hypergraph
+without FILE or OUTPUT fails rather than discard its changes. Command
+output goes to stdout, or to stderr when the hypergraph is written to
+stdout.
+
+FILE, OUTPUT and SCRIPT may be - for stdin or stdout, so hg run can sit
+in a pipeline:
+
+  hg run -f - -o - edits.hg < in.json | hg stats -f -
+
+Flags:
+  -f FILE      Input hypergraph file (default: empty hypergraph)
+  -o OUTPUT    Output file (default: modify in-place)
+  --dry-run    Run the script but save nothing`,
 }
 
-func cmdHelp(args []string) error {
+func cmdHelp(env *cmdEnv, args []string) error {
 	if len(args) == 0 {
 		printUsage()
 		return nil
@@ -256,7 +733,7 @@ func cmdHelp(args []string) error {
 
 	cmd := args[0]
 	if help, ok := commandHelp[cmd]; ok {
-		fmt.Println(help)
+		fmt.Fprintln(env.stdout(), help)
 		return nil
 	}
 
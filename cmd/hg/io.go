@@ -1,24 +1,344 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/watchthelight/HypergraphGo/hypergraph"
 )
 
-// loadGraph loads a hypergraph from a JSON file.
-func loadGraph(filename string) (*hypergraph.Hypergraph[string], error) {
-	f, err := os.Open(filename)
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+var errZstd = errors.New("zstd compression is not supported; use gzip (.gz)")
+
+// isNDJSON reports whether a file name, ignoring a .gz suffix, selects the
+// line-delimited format (.ndjson or .jsonl) rather than a JSON document.
+func isNDJSON(filename string) bool {
+	name := strings.TrimSuffix(filename, ".gz")
+	return strings.HasSuffix(name, ".ndjson") || strings.HasSuffix(name, ".jsonl")
+}
+
+// stdio is the file name that stands for standard input when reading and
+// standard output when writing, so commands compose in pipelines.
+const stdio = "-"
+
+// openInput opens a file, or standard input for "-", for reading. Gzipped
+// content is decompressed transparently, whatever the file is called.
+func openInput(filename string) (io.ReadCloser, error) {
+	f, closeFile := os.Stdin, func() error { return nil }
+	if filename != stdio {
+		var err error
+		if f, err = os.Open(filename); err != nil {
+			return nil, err
+		}
+		closeFile = f.Close
+	}
+	br := bufio.NewReader(f)
+	magic, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			_ = closeFile()
+			return nil, err
+		}
+		return &inputFile{zr, func() error { return errors.Join(zr.Close(), closeFile()) }}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		_ = closeFile()
+		return nil, errZstd
+	}
+	return &inputFile{br, closeFile}, nil
+}
+
+type inputFile struct {
+	io.Reader
+	close func() error
+}
+
+func (f *inputFile) Close() error { return f.close() }
+
+// readInput reads a whole file, decompressing it like openInput.
+func (e *cmdEnv) readInput(filename string) ([]byte, error) {
+	r, err := e.open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return io.ReadAll(r)
+}
+
+// setVertexType sets the vertex type of the files e writes, as the global
+// --vertex-type flag does.
+func (e *cmdEnv) setVertexType(t string) error {
+	switch t {
+	case hypergraph.VertexTypeString, hypergraph.VertexTypeInt:
+		e.vertexType = t
+		return nil
+	}
+	return fmt.Errorf("unknown vertex type %q (want int or string)", t)
+}
+
+// compareFor returns the vertex order of vertex type t: numeric for int,
+// lexical otherwise.
+func compareFor(t string) func(a, b string) int {
+	if t == hypergraph.VertexTypeInt {
+		return compareIntVertices
+	}
+	return strings.Compare
+}
+
+// compareIntVertices orders vertices numerically, with any non-integers
+// after the integers in lexical order.
+func compareIntVertices(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Or(cmp.Compare(x, y), strings.Compare(a, b))
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// newGraph creates an empty hypergraph ordered for vertex type t.
+func newGraph(t string) *hypergraph.Hypergraph[string] {
+	return hypergraph.NewHypergraphFunc(compareFor(t))
+}
+
+// sniffVertexType returns the vertex type recorded in a hypergraph file,
+// under "vertex_type" in a JSON document or in the header line of NDJSON,
+// and a reader for the whole file. A file that records none has string
+// vertices.
+func sniffVertexType(r io.Reader, ndjson bool) (string, io.Reader, error) {
+	if ndjson {
+		br := bufio.NewReader(r)
+		head, _ := br.Peek(512)
+		line, _, _ := bytes.Cut(head, []byte("\n"))
+		return jsonVertexType(line), br, nil
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+	return jsonVertexType(data), bytes.NewReader(data), nil
+}
+
+// jsonVertexType returns the "vertex_type" of a JSON object, or string if
+// it has none. Loading the object reports any syntax errors.
+func jsonVertexType(data []byte) string {
+	var header struct {
+		VertexType string `json:"vertex_type"`
+	}
+	_ = json.Unmarshal(data, &header)
+	return cmp.Or(header.VertexType, hypergraph.VertexTypeString)
+}
+
+// loadGraph loads a hypergraph from a JSON file, or an NDJSON file if the
+// name ends in .ndjson or .jsonl. Either may be gzipped. Integer vertices
+// are converted to strings, and the file's vertex type becomes the one e
+// writes unless --vertex-type or an earlier file set it. In memory the
+// named hypergraph is returned instead.
+func (e *cmdEnv) loadGraph(filename string) (*hypergraph.Hypergraph[string], error) {
+	if e.graph != nil {
+		return e.graph(filename)
+	}
+	f, err := openInput(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = f.Close() }()
-	return hypergraph.LoadJSON[string](f)
+	t, r, err := sniffVertexType(f, isNDJSON(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	// Integer vertices become strings as they are decoded, so the
+	// hypergraph is never held twice.
+	var hg *hypergraph.Hypergraph[string]
+	switch ndjson := isNDJSON(filename); {
+	case t == hypergraph.VertexTypeInt && ndjson:
+		hg, err = hypergraph.LoadNDJSONFunc(r, formatIntVertex)
+	case t == hypergraph.VertexTypeInt:
+		hg, err = hypergraph.LoadJSONFunc(r, formatIntVertex)
+	case ndjson:
+		hg, err = hypergraph.LoadNDJSON[string](r)
+	default:
+		hg, err = hypergraph.LoadJSON[string](r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.adoptGraph(hg, t)
+	return hg, nil
+}
+
+// adoptGraph makes t, the vertex type of a file just loaded, the one e
+// writes if none is set yet, and orders the hypergraph or temporal
+// hypergraph loaded from it for that type.
+func (e *cmdEnv) adoptGraph(hg interface{ SetCompare(func(a, b string) int) }, t string) {
+	if e.vertexType == "" {
+		e.vertexType = t
+	}
+	hg.SetCompare(compareFor(e.vertexType))
 }
 
-// saveGraph saves a hypergraph to a JSON file atomically.
-// It writes to a temp file first, then renames to the target.
-func saveGraph(hg *hypergraph.Hypergraph[string], filename string) error {
+func keepVertex(v string) (string, error) {
+	return v, nil
+}
+
+func formatIntVertex(v int) (string, error) {
+	return strconv.Itoa(v), nil
+}
+
+func parseIntVertex(v string) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || strconv.Itoa(n) != v {
+		return 0, fmt.Errorf("vertex %q is not an integer (vertex type is int)", v)
+	}
+	return n, nil
+}
+
+// graphSource is the -f FILE and -db DIR flag pair of commands that work
+// on either a hypergraph file or a store directory.
+type graphSource struct {
+	env      *cmdEnv
+	file, db *string
+}
+
+func sourceFlags(env *cmdEnv, fs *flag.FlagSet) graphSource {
+	return graphSource{
+		env:  env,
+		file: fs.String("f", "", "input hypergraph JSON file"),
+		db:   fs.String("db", "", "hypergraph store directory (instead of -f)"),
+	}
+}
+
+// missing reports whether neither -f nor -db was given.
+func (src graphSource) missing() bool {
+	return *src.file == "" && *src.db == ""
+}
+
+func (src graphSource) check() error {
+	if *src.file != "" && *src.db != "" {
+		return fmt.Errorf("flags -f and -db are mutually exclusive")
+	}
+	if *src.db != "" && src.env.graph != nil {
+		return fmt.Errorf("flag -db is not available here")
+	}
+	return nil
+}
+
+// load loads the hypergraph from the file or the store. A store must
+// already exist.
+func (src graphSource) load() (*hypergraph.Hypergraph[string], error) {
+	if err := src.check(); err != nil {
+		return nil, err
+	}
+	if *src.db == "" {
+		return src.env.loadGraph(*src.file)
+	}
+	if _, err := os.Stat(*src.db); err != nil {
+		return nil, err
+	}
+	s, err := hypergraph.Open[string](*src.db)
+	if err != nil {
+		return nil, err
+	}
+	s.Graph().SetCompare(compareFor(src.env.vertexType))
+	return s.Graph(), s.Close()
+}
+
+// update applies fn to the store named by -db and closes it. Stores are
+// changed in place, so -o is an error.
+func (src graphSource) update(output string, fn func(*hypergraph.Store[string]) error) error {
+	if err := src.check(); err != nil {
+		return err
+	}
+	if output != "" {
+		return fmt.Errorf("flag -o cannot be used with -db")
+	}
+	s, err := hypergraph.Open[string](*src.db)
+	if err != nil {
+		return err
+	}
+	return errors.Join(fn(s), s.Close())
+}
+
+// edgeAdder is implemented by both hypergraphs and stores.
+type edgeAdder interface {
+	AddEdge(id string, members []string) error
+	AddRoleEdge(id string, bindings []hypergraph.RoleBinding[string]) error
+	AddOrderedEdge(id string, members []string) error
+	SetEdgeWeight(id string, w float64) error
+}
+
+// loadTemporalGraph loads a temporal hypergraph from a JSON file. A plain
+// hypergraph file loads with no activation times. Vertex types are handled
+// as by loadGraph.
+func (e *cmdEnv) loadTemporalGraph(filename string) (*hypergraph.TemporalHypergraph[string], error) {
+	f, err := e.open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	t, r, err := sniffVertexType(f, false)
+	if err != nil {
+		return nil, err
+	}
+	var th *hypergraph.TemporalHypergraph[string]
+	if t == hypergraph.VertexTypeInt {
+		th, err = hypergraph.LoadTemporalJSONFunc(r, formatIntVertex)
+	} else {
+		th, err = hypergraph.LoadTemporalJSON[string](r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.adoptGraph(th, t)
+	return th, nil
+}
+
+// loadSimpleGraph loads a simple graph, as written by two-section, from a
+// JSON file.
+func (e *cmdEnv) loadSimpleGraph(filename string) (*hypergraph.Graph[string], error) {
+	r, err := e.open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return hypergraph.LoadGraphJSON[string](r)
+}
+
+// writeFileAtomic calls write with a temp file next to filename, then
+// renames it into place, so readers never see a partial file. Output is
+// gzipped if the name ends in .gz. The name "-" writes to standard output.
+func (e *cmdEnv) writeFileAtomic(filename string, write func(io.Writer) error) error {
+	if err := e.create(filename); err != nil {
+		return err
+	}
+	if strings.HasSuffix(filename, ".zst") {
+		return errZstd
+	}
+	if filename == stdio {
+		return write(e.stdout())
+	}
+
 	// Write to temp file first
 	tmpFile := filename + ".tmp"
 	f, err := os.Create(tmpFile)
@@ -26,7 +346,13 @@ func saveGraph(hg *hypergraph.Hypergraph[string], filename string) error {
 		return err
 	}
 
-	if err := hg.SaveJSON(f); err != nil {
+	if strings.HasSuffix(filename, ".gz") {
+		zw := gzip.NewWriter(f)
+		err = errors.Join(write(zw), zw.Close())
+	} else {
+		err = write(f)
+	}
+	if err != nil {
 		_ = f.Close()
 		_ = os.Remove(tmpFile)
 		return err
@@ -40,3 +366,60 @@ func saveGraph(hg *hypergraph.Hypergraph[string], filename string) error {
 	// Atomic rename
 	return os.Rename(tmpFile, filename)
 }
+
+// saveGraph saves a hypergraph atomically, as JSON or, if the name ends in
+// .ndjson or .jsonl, as NDJSON, gzipped if it ends in .gz. If the vertex
+// type is int, vertices are written as numbers and must be integers.
+func (e *cmdEnv) saveGraph(hg *hypergraph.Hypergraph[string], filename string) error {
+	return e.saveGraphAs(hg, e.vertexType, filename)
+}
+
+// saveGraphAs saves a hypergraph like saveGraph with vertex type t rather
+// than the one of the files read, for hypergraphs such as duals whose
+// vertices are not the vertices of the input.
+func (e *cmdEnv) saveGraphAs(hg *hypergraph.Hypergraph[string], t, filename string) error {
+	if t != hypergraph.VertexTypeInt {
+		return writeGraph(e, hg, filename, keepVertex)
+	}
+	if filename == stdio {
+		// Standard output cannot be taken back, so check every vertex
+		// before writing any.
+		for _, v := range hg.Vertices() {
+			if _, err := parseIntVertex(v); err != nil {
+				return err
+			}
+		}
+	}
+	return writeGraph(e, hg, filename, parseIntVertex)
+}
+
+// writeGraph writes hg with every vertex converted by f as it is encoded.
+func writeGraph[W comparable](e *cmdEnv, hg *hypergraph.Hypergraph[string], filename string, f func(string) (W, error)) error {
+	return e.writeFileAtomic(filename, func(w io.Writer) error {
+		if isNDJSON(filename) {
+			return hypergraph.WriteNDJSONFunc(w, hg, hypergraph.Sorted, f)
+		}
+		return hypergraph.SaveJSONFunc(w, hg, f)
+	})
+}
+
+// saveSimpleGraph saves a simple graph to a JSON file atomically, like
+// saveGraph.
+func (e *cmdEnv) saveSimpleGraph(g *hypergraph.Graph[string], filename string) error {
+	return e.writeFileAtomic(filename, g.SaveJSON)
+}
+
+// loadPatch loads a hypergraph patch, as written by hg diff -o, from a file.
+func (e *cmdEnv) loadPatch(filename string) (*hypergraph.Patch[string], error) {
+	r, err := e.open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return hypergraph.LoadPatchJSON[string](r)
+}
+
+// savePatch saves a hypergraph patch to a file atomically, like saveGraph.
+func (e *cmdEnv) savePatch(p *hypergraph.Patch[string], filename string) error {
+	return e.writeFileAtomic(filename, p.SaveJSON)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/watchthelight/HypergraphGo/hypergraph"
@@ -29,7 +32,7 @@ func writeTestGraphFile(t *testing.T, dir string, name string) string {
 	t.Helper()
 	hg := createTestGraph(t)
 	path := filepath.Join(dir, name)
-	if err := saveGraph(hg, path); err != nil {
+	if err := osEnv.saveGraph(hg, path); err != nil {
 		t.Fatalf("failed to save test graph: %v", err)
 	}
 	return path
@@ -39,7 +42,7 @@ func TestLoadGraph_ValidFile(t *testing.T) {
 	dir := t.TempDir()
 	path := writeTestGraphFile(t, dir, "test.json")
 
-	hg, err := loadGraph(path)
+	hg, err := osEnv.loadGraph(path)
 	if err != nil {
 		t.Fatalf("loadGraph failed: %v", err)
 	}
@@ -53,7 +56,7 @@ func TestLoadGraph_ValidFile(t *testing.T) {
 }
 
 func TestLoadGraph_MissingFile(t *testing.T) {
-	_, err := loadGraph("/nonexistent/path/graph.json")
+	_, err := osEnv.loadGraph("/nonexistent/path/graph.json")
 	if err == nil {
 		t.Fatal("loadGraph should fail for missing file")
 	}
@@ -70,7 +73,7 @@ func TestLoadGraph_InvalidJSON(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err := loadGraph(path)
+	_, err := osEnv.loadGraph(path)
 	if err == nil {
 		t.Fatal("loadGraph should fail for invalid JSON")
 	}
@@ -84,7 +87,7 @@ func TestLoadGraph_EmptyFile(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err := loadGraph(path)
+	_, err := osEnv.loadGraph(path)
 	if err == nil {
 		t.Fatal("loadGraph should fail for empty file")
 	}
@@ -95,12 +98,12 @@ func TestSaveGraph_NewFile(t *testing.T) {
 	path := filepath.Join(dir, "output.json")
 
 	hg := createTestGraph(t)
-	if err := saveGraph(hg, path); err != nil {
+	if err := osEnv.saveGraph(hg, path); err != nil {
 		t.Fatalf("saveGraph failed: %v", err)
 	}
 
 	// Verify file was created and can be loaded
-	loaded, err := loadGraph(path)
+	loaded, err := osEnv.loadGraph(path)
 	if err != nil {
 		t.Fatalf("failed to load saved graph: %v", err)
 	}
@@ -120,18 +123,18 @@ func TestSaveGraph_Overwrite(t *testing.T) {
 	// Write initial graph
 	hg1 := hypergraph.NewHypergraph[string]()
 	hg1.AddVertex("x")
-	if err := saveGraph(hg1, path); err != nil {
+	if err := osEnv.saveGraph(hg1, path); err != nil {
 		t.Fatal(err)
 	}
 
 	// Overwrite with different graph
 	hg2 := createTestGraph(t)
-	if err := saveGraph(hg2, path); err != nil {
+	if err := osEnv.saveGraph(hg2, path); err != nil {
 		t.Fatalf("saveGraph overwrite failed: %v", err)
 	}
 
 	// Verify overwritten content
-	loaded, err := loadGraph(path)
+	loaded, err := osEnv.loadGraph(path)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -142,7 +145,7 @@ func TestSaveGraph_Overwrite(t *testing.T) {
 
 func TestSaveGraph_InvalidPath(t *testing.T) {
 	hg := hypergraph.NewHypergraph[string]()
-	err := saveGraph(hg, "/nonexistent/dir/file.json")
+	err := osEnv.saveGraph(hg, "/nonexistent/dir/file.json")
 	if err == nil {
 		t.Fatal("saveGraph should fail for invalid path")
 	}
@@ -169,11 +172,11 @@ func TestLoadSave_RoundTrip(t *testing.T) {
 
 	// Save and reload
 	path := filepath.Join(dir, "roundtrip.json")
-	if err := saveGraph(hg, path); err != nil {
+	if err := osEnv.saveGraph(hg, path); err != nil {
 		t.Fatal(err)
 	}
 
-	loaded, err := loadGraph(path)
+	loaded, err := osEnv.loadGraph(path)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -223,7 +226,7 @@ func TestLoadGraph_MalformedJSON(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			_, err := loadGraph(path)
+			_, err := osEnv.loadGraph(path)
 			if err == nil {
 				t.Errorf("loadGraph should fail for %s", tc.name)
 			}
@@ -236,11 +239,11 @@ func TestSaveGraph_EmptyGraph(t *testing.T) {
 	path := filepath.Join(dir, "empty.json")
 
 	hg := hypergraph.NewHypergraph[string]()
-	if err := saveGraph(hg, path); err != nil {
+	if err := osEnv.saveGraph(hg, path); err != nil {
 		t.Fatalf("saveGraph failed for empty graph: %v", err)
 	}
 
-	loaded, err := loadGraph(path)
+	loaded, err := osEnv.loadGraph(path)
 	if err != nil {
 		t.Fatalf("loadGraph failed for empty graph: %v", err)
 	}
@@ -252,3 +255,243 @@ func TestSaveGraph_EmptyGraph(t *testing.T) {
 		t.Errorf("empty graph should have 0 edges")
 	}
 }
+
+func TestLoadSave_Formats(t *testing.T) {
+	want := createTestGraph(t)
+	_ = want.SetEdgeWeight("e1", 2)
+
+	for _, name := range []string{"g.json.gz", "g.ndjson", "g.jsonl", "g.ndjson.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := osEnv.saveGraph(want, path); err != nil {
+				t.Fatal(err)
+			}
+			data, _ := os.ReadFile(path)
+			if gz := bytes.HasPrefix(data, gzipMagic); gz != strings.HasSuffix(name, ".gz") {
+				t.Errorf("gzipped = %v", gz)
+			}
+			got, err := osEnv.loadGraph(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !hypergraph.Diff(want, got).IsEmpty() {
+				t.Error("round trip changed the hypergraph")
+			}
+		})
+	}
+
+	t.Run("ndjson_lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "g.ndjson")
+		_ = osEnv.saveGraph(want, path)
+		data, _ := os.ReadFile(path)
+		if n := strings.Count(string(data), "\n"); n != 5 {
+			t.Errorf("wrote %d lines, want 5:\n%s", n, data)
+		}
+	})
+
+	t.Run("gzip_detected_by_content", func(t *testing.T) {
+		dir := t.TempDir()
+		gz := filepath.Join(dir, "g.json.gz")
+		_ = osEnv.saveGraph(want, gz)
+		plain := filepath.Join(dir, "renamed.json")
+		if err := os.Rename(gz, plain); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := osEnv.loadGraph(plain); err != nil {
+			t.Errorf("gzipped file without .gz: %v", err)
+		}
+	})
+}
+
+func TestLoadSave_Zstd(t *testing.T) {
+	dir := t.TempDir()
+	if err := osEnv.saveGraph(createTestGraph(t), filepath.Join(dir, "g.json.zst")); err == nil || !strings.Contains(err.Error(), "zstd") {
+		t.Errorf("saving .zst: err = %v", err)
+	}
+	path := filepath.Join(dir, "g.json")
+	_ = os.WriteFile(path, append(bytes.Clone(zstdMagic), 0, 0), 0644)
+	if _, err := osEnv.loadGraph(path); err == nil || !strings.Contains(err.Error(), "zstd") {
+		t.Errorf("loading zstd data: err = %v", err)
+	}
+}
+
+func TestLoadGraph_NDJSONError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.ndjson")
+	_ = os.WriteFile(path, []byte("{\"vertex\": \"a\"}\n{\"edge\": \"e\"}\n"), 0644)
+	_, err := osEnv.loadGraph(path)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLoadSave_Stdio(t *testing.T) {
+	data, _ := os.ReadFile(writeTestGraphFile(t, t.TempDir(), "g.json"))
+	var output string
+	withStdin(t, string(data), func() {
+		output = captureStdout(t, func() {
+			// Modifying "-" in place reads stdin and writes stdout.
+			if err := cmdAddVertex(osEnv, []string{"-f", "-", "-v", "z"}); err != nil {
+				t.Error(err)
+			}
+		})
+	})
+	hg, err := hypergraph.LoadJSON[string](strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("stdout is not a hypergraph: %v\n%s", err, output)
+	}
+	if !hg.HasVertex("z") || hg.NumEdges() != 2 {
+		t.Errorf("vertices %v, edges %v", hg.Vertices(), hg.Edges())
+	}
+}
+
+// withVertexType sets the vertex type for one test and resets it after,
+// so that the next file loaded chooses again.
+func withVertexType(t *testing.T, vt string) {
+	t.Helper()
+	osEnv.vertexType = vt
+	t.Cleanup(func() { osEnv.vertexType = "" })
+}
+
+func TestVertexType_FileRoundTrip(t *testing.T) {
+	withVertexType(t, "")
+	for _, name := range []string{"g.json", "g.ndjson"} {
+		path := filepath.Join(t.TempDir(), name)
+		ints := hypergraph.NewHypergraph[string]()
+		_ = ints.AddEdge("e1", []string{"10", "2"})
+		ints.AddVertex("1")
+		if err := writeGraph(osEnv, ints, path, parseIntVertex); err != nil {
+			t.Fatal(err)
+		}
+		osEnv.vertexType = ""
+
+		output := captureStdout(t, func() {
+			if err := cmdVertices(osEnv, []string{"-f", path}); err != nil {
+				t.Error(err)
+			}
+		})
+		if output != "1\n2\n10\n" {
+			t.Errorf("%s: vertices = %q, want numeric order", name, output)
+		}
+		if err := cmdAddVertex(osEnv, []string{"-f", path, "-v", "3"}); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(path)
+		if !strings.Contains(string(data), `"vertex_type":"int"`) || !strings.Contains(string(data), `3`) || strings.Contains(string(data), `"3"`) {
+			t.Errorf("%s: saved %s", name, data)
+		}
+	}
+}
+
+func TestVertexType_Flag(t *testing.T) {
+	withVertexType(t, hypergraph.VertexTypeInt)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "g.json")
+	if err := os.WriteFile(path, []byte(`{"vertices": ["10", "2"], "edges": {"e": ["2", "10"]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(dir, "out.json")
+	if err := cmdCopy(osEnv, []string{"-f", path, "-o", out}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	if !strings.Contains(string(data), `"vertex_type":"int","vertices":[2,10]`) {
+		t.Errorf("saved %s", data)
+	}
+
+	err := cmdAddVertex(osEnv, []string{"-f", path, "-v", "x"})
+	if err == nil || !strings.Contains(err.Error(), `vertex "x" is not an integer`) {
+		t.Errorf("add-vertex x error = %v", err)
+	}
+
+	if err := osEnv.setVertexType("float"); err == nil {
+		t.Error("setVertexType(float) should fail")
+	}
+	if err := osEnv.setVertexType(hypergraph.VertexTypeString); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmdCopy(osEnv, []string{"-f", out, "-o", out}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(out); !strings.Contains(string(data), `"vertices":["10","2"]`) {
+		t.Errorf("string copy saved %s", data)
+	}
+}
+
+// TestVertexType_Dual tests that outputs whose vertices are edge IDs are
+// written with string vertices from an int file.
+func TestVertexType_Dual(t *testing.T) {
+	withVertexType(t, "")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.json")
+	if err := os.WriteFile(path, []byte(`{"vertex_type": "int", "vertices": [1, 2, 3], "edges": {"e1": [1, 2], "e2": [2, 3]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loadStrings := func(name string) (*hypergraph.Hypergraph[string], error) {
+		data, _ := os.ReadFile(name)
+		return hypergraph.LoadJSON[string](bytes.NewReader(data))
+	}
+
+	out := filepath.Join(dir, "d.json")
+	if err := cmdDual(osEnv, []string{"-f", path, "-o", out}); err != nil {
+		t.Fatal(err)
+	}
+	d, err := loadStrings(out)
+	if err != nil || !d.HasVertex("e2") {
+		t.Fatalf("dual = %v, %v", d, err)
+	}
+
+	script := writeScript(t, dir, "dual\n")
+	out = filepath.Join(dir, "run.json")
+	if err := cmdRun(osEnv, []string{"-f", path, "-o", out, script}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadStrings(out); err != nil {
+		t.Errorf("hg run dual: %v", err)
+	}
+
+	state := &replState{hg: newGraph(""), out: io.Discard}
+	for _, line := range []string{":load " + path, "dual", ":save " + out} {
+		if err := executeReplCommand(state, line); err != nil {
+			t.Fatalf("%s: %v", line, err)
+		}
+	}
+}
+
+// TestVertexType_Temporal tests that temporal files keep their vertex type.
+func TestVertexType_Temporal(t *testing.T) {
+	withVertexType(t, "")
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ints.json")
+	data := `{"vertex_type": "int", "vertices": [10, 2], "edges": {"e": [2, 10]}, "times": {"e": [{"start": 1, "end": 2}]}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.json")
+	if err := cmdSlice(osEnv, []string{"-f", path, "-o", out, "-from", "0", "-to", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	if saved, _ := os.ReadFile(out); !strings.Contains(string(saved), `"vertex_type":"int","vertices":[2,10]`) {
+		t.Errorf("slice saved %s", saved)
+	}
+}
+
+func TestVertexType_Validate(t *testing.T) {
+	withVertexType(t, "")
+	path := filepath.Join(t.TempDir(), "g.json")
+	if err := os.WriteFile(path, []byte(`{"vertex_type": "int", "vertices": [2, 10, 2], "edges": {"e": [10]}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	output := captureStdout(t, func() {
+		if err := cmdValidate(osEnv, []string{"-f", path, "-fix"}); err != nil {
+			t.Error(err)
+		}
+	})
+	if !strings.Contains(output, "duplicate vertex 2") {
+		t.Errorf("output = %q", output)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"vertices":[2,10]`) {
+		t.Errorf("fixed file = %s", data)
+	}
+}
@@ -3,6 +3,8 @@
 // Usage:
 //
 //	hg --version           Print version info
+//	hg --vertex-type int <command> [flags]
+//	                       Run a subcommand on integer vertices
 //	hg <command> [flags]   Run a subcommand
 //	hg repl                Start interactive REPL
 //	hg help [command]      Show help
@@ -19,12 +21,19 @@ import (
 func main() {
 	// Global flags (before subcommand)
 	ver := flag.Bool("version", false, "print version and exit")
+	vtype := flag.String("vertex-type", "", "vertex type of hypergraph files: int or string")
 	flag.Parse()
 
 	if *ver {
 		fmt.Printf("hg %s (%s, %s)\n", version.Version, version.Commit, version.Date)
 		return
 	}
+	if *vtype != "" {
+		if err := osEnv.setVertexType(*vtype); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	args := flag.Args()
 	if len(args) == 0 {
@@ -39,67 +48,105 @@ func main() {
 	switch subcommand {
 	// Core operations
 	case "info":
-		err = cmdInfo(subArgs)
+		err = cmdInfo(osEnv, subArgs)
+	case "stats":
+		err = cmdStats(osEnv, subArgs)
 	case "add-vertex":
-		err = cmdAddVertex(subArgs)
+		err = cmdAddVertex(osEnv, subArgs)
 	case "remove-vertex":
-		err = cmdRemoveVertex(subArgs)
+		err = cmdRemoveVertex(osEnv, subArgs)
 	case "has-vertex":
-		err = cmdHasVertex(subArgs)
+		err = cmdHasVertex(osEnv, subArgs)
 	case "add-edge":
-		err = cmdAddEdge(subArgs)
+		err = cmdAddEdge(osEnv, subArgs)
 	case "remove-edge":
-		err = cmdRemoveEdge(subArgs)
+		err = cmdRemoveEdge(osEnv, subArgs)
 	case "has-edge":
-		err = cmdHasEdge(subArgs)
+		err = cmdHasEdge(osEnv, subArgs)
 	case "vertices":
-		err = cmdVertices(subArgs)
+		err = cmdVertices(osEnv, subArgs)
 	case "edges":
-		err = cmdEdges(subArgs)
+		err = cmdEdges(osEnv, subArgs)
 	case "degree":
-		err = cmdDegree(subArgs)
+		err = cmdDegree(osEnv, subArgs)
 	case "edge-size":
-		err = cmdEdgeSize(subArgs)
+		err = cmdEdgeSize(osEnv, subArgs)
 	case "copy":
-		err = cmdCopy(subArgs)
+		err = cmdCopy(osEnv, subArgs)
+	case "query":
+		err = cmdQuery(osEnv, subArgs)
 
 	// Transforms
 	case "dual":
-		err = cmdDual(subArgs)
+		err = cmdDual(osEnv, subArgs)
 	case "two-section":
-		err = cmdTwoSection(subArgs)
+		err = cmdTwoSection(osEnv, subArgs)
 	case "line-graph":
-		err = cmdLineGraph(subArgs)
+		err = cmdLineGraph(osEnv, subArgs)
+	case "star":
+		err = cmdStar(osEnv, subArgs)
+	case "from-graph":
+		err = cmdFromGraph(osEnv, subArgs)
+	case "slice":
+		err = cmdSlice(osEnv, subArgs)
 
 	// Traversal
 	case "bfs":
-		err = cmdBFS(subArgs)
+		err = cmdBFS(osEnv, subArgs)
 	case "dfs":
-		err = cmdDFS(subArgs)
+		err = cmdDFS(osEnv, subArgs)
 	case "components":
-		err = cmdComponents(subArgs)
+		err = cmdComponents(osEnv, subArgs)
 
 	// Algorithms
 	case "hitting-set":
-		err = cmdHittingSet(subArgs)
+		err = cmdHittingSet(osEnv, subArgs)
 	case "transversals":
-		err = cmdTransversals(subArgs)
+		err = cmdTransversals(osEnv, subArgs)
+	case "core":
+		err = cmdCore(osEnv, subArgs)
+	case "independent":
+		err = cmdIndependentSet(osEnv, subArgs)
 	case "coloring":
-		err = cmdColoring(subArgs)
+		err = cmdColoring(osEnv, subArgs)
+	case "homology":
+		err = cmdHomology(osEnv, subArgs)
+	case "mincut":
+		err = cmdMinCut(osEnv, subArgs)
+	case "edge-cover":
+		err = cmdEdgeCover(osEnv, subArgs)
+	case "match":
+		err = cmdMatch(osEnv, subArgs)
+	case "motifs":
+		err = cmdMotifs(osEnv, subArgs)
+	case "robustness":
+		err = cmdRobustness(osEnv, subArgs)
 
 	// I/O
 	case "new":
-		err = cmdNew(subArgs)
+		err = cmdNew(osEnv, subArgs)
 	case "incidence":
-		err = cmdIncidence(subArgs)
+		err = cmdIncidence(osEnv, subArgs)
 	case "validate":
-		err = cmdValidate(subArgs)
+		err = cmdValidate(osEnv, subArgs)
+	case "render":
+		err = cmdRender(osEnv, subArgs)
+	case "diff":
+		err = cmdDiff(osEnv, subArgs)
+	case "patch":
+		err = cmdPatch(osEnv, subArgs)
+	case "merge":
+		err = cmdMerge(osEnv, subArgs)
 
 	// Meta
 	case "help":
-		err = cmdHelp(subArgs)
+		err = cmdHelp(osEnv, subArgs)
 	case "repl":
-		err = cmdREPL(subArgs)
+		err = cmdREPL(osEnv, subArgs)
+	case "serve":
+		err = cmdServe(osEnv, subArgs)
+	case "run":
+		err = cmdRun(osEnv, subArgs)
 
 	default:
 		fmt.Fprintf(os.Stderr, "hg: unknown command '%s'\n", subcommand)
@@ -121,6 +168,7 @@ Usage: hg <command> [flags]
 Commands:
   Core:
     info          Display hypergraph info
+    stats         Degree, size and clustering statistics
     add-vertex    Add a vertex
     remove-vertex Remove a vertex
     has-vertex    Check vertex existence
@@ -132,11 +180,15 @@ Commands:
     degree        Get vertex degree
     edge-size     Get edge size
     copy          Copy hypergraph
+    query         Select edges or vertices by predicate
 
   Transforms:
     dual          Compute dual hypergraph
     two-section   Compute 2-section graph
     line-graph    Compute line graph
+    star          Compute star expansion (incidence graph)
+    from-graph    Rebuild a hypergraph from a graph
+    slice         Time-window slice of a temporal hypergraph
 
   Traversal:
     bfs           Breadth-first search
@@ -146,19 +198,48 @@ Commands:
   Algorithms:
     hitting-set   Greedy hitting set
     transversals  Minimal transversals
+    core          Vertex coreness and k-cores
+    independent   Strong or weak independent set
     coloring      Greedy coloring
+    homology      Betti numbers of the simplicial closure
+    mincut        Minimum hyperedge cut
+    edge-cover    Minimum-weight edge cover
+    match         Find embeddings of a pattern hypergraph
+    motifs        Count 3-edge motifs
+    robustness    Percolation curve under vertex or edge removal
 
   I/O:
     new           Create empty hypergraph
     incidence     Print incidence matrix
     validate      Validate JSON file
+    render        Draw hypergraph as SVG
+    diff          Structural diff of two files
+    patch         Apply a patch from diff
+    merge         Three-way merge of hypergraph files
 
   Meta:
     help          Show command help
     repl          Interactive mode
+    serve         Serve hypergraphs over HTTP
+    run           Run a script of REPL commands
+
+File Formats:
+    FILE.json     JSON document
+    FILE.ndjson   One vertex or edge per line (also .jsonl)
+    FILE.gz       Gzip-compressed (detected when reading)
+    -             Standard input for -f, standard output for -o (JSON)
+    -db DIR       Store of a snapshot and a write-ahead log, for the core
+                  commands; each change appends to the log. One command
+                  at a time can use a store
 
 Global Flags:
     --version     Print version and exit
+    --vertex-type int|string
+                  Vertex type of hypergraph files (default: the type the
+                  input file records, else string; hg serve keeps each
+                  file's own). Integer vertices sort
+                  numerically and are written as JSON numbers. Duals and
+                  from-graph output have string vertices
 
 Use "hg help <command>" for more information.`)
 }
@@ -50,6 +50,7 @@ func TestPrintUsage(t *testing.T) {
 		"Usage:",
 		"Core:",
 		"info",
+		"stats",
 		"add-vertex",
 		"remove-vertex",
 		"has-vertex",
@@ -61,10 +62,14 @@ func TestPrintUsage(t *testing.T) {
 		"degree",
 		"edge-size",
 		"copy",
+		"query",
 		"Transforms:",
 		"dual",
 		"two-section",
 		"line-graph",
+		"star",
+		"from-graph",
+		"slice",
 		"Traversal:",
 		"bfs",
 		"dfs",
@@ -72,15 +77,30 @@ func TestPrintUsage(t *testing.T) {
 		"Algorithms:",
 		"hitting-set",
 		"transversals",
+		"core",
+		"independent",
 		"coloring",
+		"homology",
+		"mincut",
+		"edge-cover",
+		"match",
+		"motifs",
+		"robustness",
 		"I/O:",
 		"new",
 		"incidence",
 		"validate",
+		"render",
+		"diff",
+		"patch",
+		"merge",
 		"Meta:",
 		"help",
 		"repl",
+		"serve",
+		"run",
 		"--version",
+		"--vertex-type",
 	}
 
 	for _, section := range expectedSections {
@@ -102,6 +122,7 @@ func TestSubcommandRouting(t *testing.T) {
 	}{
 		// Core operations
 		{"info", "Display hypergraph info"},
+		{"stats", "Degree, size and clustering statistics"},
 		{"add-vertex", "Add a vertex"},
 		{"remove-vertex", "Remove a vertex"},
 		{"has-vertex", "Check vertex existence"},
@@ -113,11 +134,15 @@ func TestSubcommandRouting(t *testing.T) {
 		{"degree", "Get vertex degree"},
 		{"edge-size", "Get edge size"},
 		{"copy", "Copy hypergraph"},
+		{"query", "Select edges or vertices by predicate"},
 
 		// Transforms
 		{"dual", "Compute dual hypergraph"},
 		{"two-section", "Compute 2-section graph"},
 		{"line-graph", "Compute line graph"},
+		{"star", "Compute star expansion (incidence graph)"},
+		{"from-graph", "Rebuild a hypergraph from a graph"},
+		{"slice", "Time-window slice of a temporal hypergraph"},
 
 		// Traversal
 		{"bfs", "Breadth-first search"},
@@ -127,16 +152,30 @@ func TestSubcommandRouting(t *testing.T) {
 		// Algorithms
 		{"hitting-set", "Greedy hitting set"},
 		{"transversals", "Minimal transversals"},
+		{"core", "Vertex coreness and k-cores"},
+		{"independent", "Strong or weak independent set"},
 		{"coloring", "Greedy coloring"},
+		{"homology", "Betti numbers of the simplicial closure"},
+		{"mincut", "Minimum hyperedge cut"},
+		{"edge-cover", "Minimum-weight edge cover"},
+		{"match", "Find embeddings of a pattern hypergraph"},
+		{"motifs", "Count 3-edge motifs"},
+		{"robustness", "Percolation curve under vertex or edge removal"},
 
 		// I/O
 		{"new", "Create empty hypergraph"},
 		{"incidence", "Print incidence matrix"},
 		{"validate", "Validate JSON file"},
+		{"render", "Draw hypergraph as SVG"},
+		{"diff", "Structural diff of two files"},
+		{"patch", "Apply a patch from diff"},
+		{"merge", "Three-way merge of hypergraph files"},
 
 		// Meta
 		{"help", "Show command help"},
 		{"repl", "Interactive mode"},
+		{"serve", "Serve hypergraphs over HTTP"},
+		{"run", "Run a script of REPL commands"},
 	}
 
 	stdout, _ := captureOutput(t, func() {
@@ -155,13 +194,13 @@ func TestSubcommandRouting(t *testing.T) {
 // TestCommandHelpEntries verifies all commands have help entries.
 func TestCommandHelpEntries(t *testing.T) {
 	expectedCommands := []string{
-		"info", "new", "validate", "add-vertex", "remove-vertex",
+		"info", "stats", "new", "validate", "add-vertex", "remove-vertex",
 		"has-vertex", "add-edge", "remove-edge", "has-edge",
-		"vertices", "edges", "degree", "edge-size", "copy",
-		"dual", "two-section", "line-graph",
+		"vertices", "edges", "degree", "edge-size", "copy", "query",
+		"dual", "two-section", "line-graph", "star", "from-graph", "slice",
 		"bfs", "dfs", "components",
-		"hitting-set", "transversals", "coloring", "incidence",
-		"repl",
+		"hitting-set", "transversals", "core", "independent", "coloring", "homology", "mincut", "edge-cover", "match", "motifs", "robustness", "incidence",
+		"render", "diff", "patch", "merge", "repl", "serve", "run",
 	}
 
 	for _, cmd := range expectedCommands {
@@ -199,7 +238,7 @@ func TestCommandHelpContent(t *testing.T) {
 // TestUnknownCommand tests that unknown commands produce appropriate error message.
 func TestUnknownCommand(t *testing.T) {
 	// Since main() calls os.Exit, we test the error path through cmdHelp
-	err := cmdHelp([]string{"nonexistent-command"})
+	err := cmdHelp(osEnv, []string{"nonexistent-command"})
 	if err == nil {
 		t.Fatal("expected error for unknown command")
 	}
@@ -218,14 +257,14 @@ func TestHelpCommandCategories(t *testing.T) {
 		name     string
 		commands []string
 	}{
-		{"Core:", []string{"info", "add-vertex", "remove-vertex", "has-vertex",
+		{"Core:", []string{"info", "stats", "add-vertex", "remove-vertex", "has-vertex",
 			"add-edge", "remove-edge", "has-edge", "vertices", "edges",
-			"degree", "edge-size", "copy"}},
-		{"Transforms:", []string{"dual", "two-section", "line-graph"}},
+			"degree", "edge-size", "copy", "query"}},
+		{"Transforms:", []string{"dual", "two-section", "line-graph", "star", "from-graph", "slice"}},
 		{"Traversal:", []string{"bfs", "dfs", "components"}},
-		{"Algorithms:", []string{"hitting-set", "transversals", "coloring"}},
-		{"I/O:", []string{"new", "incidence", "validate"}},
-		{"Meta:", []string{"help", "repl"}},
+		{"Algorithms:", []string{"hitting-set", "transversals", "core", "independent", "coloring", "homology", "mincut", "edge-cover", "match", "motifs", "robustness"}},
+		{"I/O:", []string{"new", "incidence", "validate", "render", "diff", "patch", "merge"}},
+		{"Meta:", []string{"help", "repl", "serve", "run"}},
 	}
 
 	for _, cat := range categories {
@@ -254,6 +293,9 @@ func TestGlobalFlags(t *testing.T) {
 	if !strings.Contains(stdout, "--version") {
 		t.Error("usage should document --version flag")
 	}
+	if !strings.Contains(stdout, "--vertex-type int|string") {
+		t.Error("usage should document --vertex-type flag")
+	}
 }
 
 // TestHelpFooter tests the help footer message.
@@ -274,7 +316,7 @@ func TestMultipleHelpRequests(t *testing.T) {
 	for _, cmd := range commands {
 		t.Run(cmd, func(t *testing.T) {
 			for i := 0; i < 3; i++ {
-				err := cmdHelp([]string{cmd})
+				err := cmdHelp(osEnv, []string{cmd})
 				if err != nil {
 					t.Errorf("iteration %d: cmdHelp failed: %v", i, err)
 				}
@@ -287,7 +329,7 @@ func TestMultipleHelpRequests(t *testing.T) {
 func TestHelpWithExtraArgs(t *testing.T) {
 	// help should use first arg and ignore others
 	stdout, _ := captureOutput(t, func() {
-		err := cmdHelp([]string{"info", "extra", "args"})
+		err := cmdHelp(osEnv, []string{"info", "extra", "args"})
 		if err != nil {
 			t.Fatalf("cmdHelp failed: %v", err)
 		}
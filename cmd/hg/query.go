@@ -0,0 +1,666 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+)
+
+// The query language selects edges or vertices by predicate:
+//
+//	query     := ("edges" | "vertices") ["where" expr]
+//	expr      := and ("or" and)*
+//	and       := unary ("and" unary)*
+//	unary     := "not" unary | "(" expr ")" | predicate
+//	predicate := field op NUMBER        (size, degree)
+//	           | "weight" op NUMBER     (edges; NUMBER may have a fraction)
+//	           | "id" op STRING
+//	           | "id" "matches" STRING  (regular expression)
+//	           | "contains" STRING      (edges: has member)
+//	           | "in" STRING            (vertices: member of edge)
+//	           | "within" NUMBER "of" STRING
+//	           | "role" STRING "is" STRING  (edges: role bound to vertex)
+//	           | "role" STRING "matches" STRING  (edges: role bound to a match)
+//	           | "plays" STRING            (vertices: plays role in some edge)
+//	op        := "=" | "==" | "!=" | "<" | "<=" | ">" | ">="
+
+type queryTarget int
+
+const (
+	queryEdges queryTarget = iota
+	queryVertices
+)
+
+func (t queryTarget) String() string {
+	if t == queryEdges {
+		return "edges"
+	}
+	return "vertices"
+}
+
+// hgQuery is a parsed query.
+type hgQuery struct {
+	target queryTarget
+	where  queryExpr // nil selects everything
+}
+
+// queryExpr is a boolean predicate over an edge ID or a vertex.
+type queryExpr interface {
+	eval(ctx *queryContext, item string) bool
+}
+
+// queryContext carries the graph and per-query caches during evaluation.
+type queryContext struct {
+	hg        *hypergraph.Hypergraph[string]
+	target    queryTarget
+	distances map[string]map[string]int
+}
+
+func (c *queryContext) distancesFrom(v string) map[string]int {
+	if d, ok := c.distances[v]; ok {
+		return d
+	}
+	d := c.hg.Distances(v)
+	c.distances[v] = d
+	return d
+}
+
+type queryAnd struct{ left, right queryExpr }
+type queryOr struct{ left, right queryExpr }
+type queryNot struct{ inner queryExpr }
+
+func (e queryAnd) eval(ctx *queryContext, item string) bool {
+	return e.left.eval(ctx, item) && e.right.eval(ctx, item)
+}
+
+func (e queryOr) eval(ctx *queryContext, item string) bool {
+	return e.left.eval(ctx, item) || e.right.eval(ctx, item)
+}
+
+func (e queryNot) eval(ctx *queryContext, item string) bool {
+	return !e.inner.eval(ctx, item)
+}
+
+// queryCompareInt compares the size of an edge or the degree of a vertex.
+type queryCompareInt struct {
+	op    string
+	value int
+}
+
+func (e queryCompareInt) eval(ctx *queryContext, item string) bool {
+	var n int
+	if ctx.target == queryEdges {
+		n, _ = ctx.hg.EdgeSize(item)
+	} else {
+		n = ctx.hg.VertexDegree(item)
+	}
+	return compareOp(e.op, cmp.Compare(n, e.value))
+}
+
+// queryCompareWeight compares the weight of an edge.
+type queryCompareWeight struct {
+	op    string
+	value float64
+}
+
+func (e queryCompareWeight) eval(ctx *queryContext, item string) bool {
+	return compareOp(e.op, cmp.Compare(ctx.hg.EdgeWeight(item), e.value))
+}
+
+// queryCompareID compares the edge ID or vertex name against a string:
+// edge IDs lexically, vertices in the hypergraph's vertex order, so that
+// integer vertices compare numerically.
+type queryCompareID struct {
+	op    string
+	value string
+}
+
+func (e queryCompareID) eval(ctx *queryContext, item string) bool {
+	if ctx.target == queryVertices {
+		return compareOp(e.op, ctx.hg.Compare(item, e.value))
+	}
+	return compareOp(e.op, strings.Compare(item, e.value))
+}
+
+type queryMatches struct{ re *regexp.Regexp }
+
+func (e queryMatches) eval(_ *queryContext, item string) bool {
+	return e.re.MatchString(item)
+}
+
+// queryMember is "contains V" for edges and "in E" for vertices.
+type queryMember struct{ other string }
+
+func (e queryMember) eval(ctx *queryContext, item string) bool {
+	edge, vertex := item, e.other
+	if ctx.target == queryVertices {
+		edge, vertex = e.other, item
+	}
+	return slices.Contains(ctx.hg.EdgeMembers(edge), vertex)
+}
+
+// queryWithin holds for a vertex at most hops away from center, or for an
+// edge with at least one such member.
+type queryWithin struct {
+	hops   int
+	center string
+}
+
+func (e queryWithin) eval(ctx *queryContext, item string) bool {
+	dist := ctx.distancesFrom(e.center)
+	near := func(v string) bool {
+		d, ok := dist[v]
+		return ok && d <= e.hops
+	}
+	if ctx.target == queryVertices {
+		return near(item)
+	}
+	return slices.ContainsFunc(ctx.hg.EdgeMembers(item), near)
+}
+
+// queryRole is "role R is V" for edges and "plays R" for vertices.
+type queryRole struct {
+	role, vertex string
+}
+
+func (e queryRole) eval(ctx *queryContext, item string) bool {
+	if ctx.target == queryEdges {
+		return slices.Contains(ctx.hg.VertexRoles(item, e.vertex), e.role)
+	}
+	for range ctx.hg.EdgesWithRole(item, e.role, hypergraph.Unordered) {
+		return true
+	}
+	return false
+}
+
+// queryRoleMatches is "role R matches REGEX" for edges: some vertex bound
+// to role R matches the pattern.
+type queryRoleMatches struct {
+	role string
+	re   *regexp.Regexp
+}
+
+func (e queryRoleMatches) eval(ctx *queryContext, item string) bool {
+	for _, b := range ctx.hg.EdgeRoles(item) {
+		if b.Role == e.role && e.re.MatchString(b.Vertex) {
+			return true
+		}
+	}
+	return false
+}
+
+func compareOp(op string, c int) bool {
+	switch op {
+	case "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+type queryTokenKind int
+
+const (
+	tokWord queryTokenKind = iota
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokEOF
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+// lexQuery splits a query into tokens. String literals are double-quoted
+// and use Go escape syntax. Words may use any Unicode letters; numbers are
+// ASCII digits with an optional fraction.
+func lexQuery(src string) ([]queryToken, error) {
+	var toks []queryToken
+	i := 0
+	for i < len(src) {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return nil, fmt.Errorf("query: invalid UTF-8 at offset %d", i)
+		case unicode.IsSpace(c):
+			i += size
+		case c == '(':
+			toks = append(toks, queryToken{tokLParen, "(", i})
+			i++
+		case c == ')':
+			toks = append(toks, queryToken{tokRParen, ")", i})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("query: unterminated string at offset %d", i)
+			}
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("query: bad string at offset %d: %w", i, err)
+			}
+			toks = append(toks, queryToken{tokString, s, i})
+			i = j + 1
+		case strings.ContainsRune("=!<>", c):
+			j := i + 1
+			if j < len(src) && src[j] == '=' {
+				j++
+			}
+			op := src[i:j]
+			if op == "!" {
+				return nil, fmt.Errorf("query: unexpected '!' at offset %d", i)
+			}
+			toks = append(toks, queryToken{tokOp, op, i})
+			i = j
+		case isASCIIDigit(c):
+			j := i
+			for j < len(src) && isASCIIDigit(rune(src[j])) {
+				j++
+			}
+			if j+1 < len(src) && src[j] == '.' && isASCIIDigit(rune(src[j+1])) {
+				j += 2
+				for j < len(src) && isASCIIDigit(rune(src[j])) {
+					j++
+				}
+			}
+			toks = append(toks, queryToken{tokNumber, src[i:j], i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(src) {
+				r, n := utf8.DecodeRuneInString(src[j:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				j += n
+			}
+			toks = append(toks, queryToken{tokWord, strings.ToLower(src[i:j]), i})
+			i = j
+		default:
+			return nil, fmt.Errorf("query: unexpected %q at offset %d", c, i)
+		}
+	}
+	return append(toks, queryToken{tokEOF, "", len(src)}), nil
+}
+
+func isASCIIDigit(c rune) bool { return '0' <= c && c <= '9' }
+
+type queryParser struct {
+	toks   []queryToken
+	pos    int
+	target queryTarget
+}
+
+func (p *queryParser) peek() queryToken { return p.toks[p.pos] }
+
+func (p *queryParser) next() queryToken {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *queryParser) errorf(t queryToken, format string, args ...any) error {
+	where := "end of query"
+	if t.kind != tokEOF {
+		where = fmt.Sprintf("%q at offset %d", t.text, t.pos)
+	}
+	return fmt.Errorf("query: %s (near %s)", fmt.Sprintf(format, args...), where)
+}
+
+func (p *queryParser) acceptWord(w string) bool {
+	if t := p.peek(); t.kind == tokWord && t.text == w {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// number parses a number token as an int. The lexer guarantees digits
+// with an optional fraction, but not that they form an int that fits.
+func (p *queryParser) number(t queryToken) (int, error) {
+	if strings.Contains(t.text, ".") {
+		return 0, p.errorf(t, "expected a whole number")
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, p.errorf(t, "number out of range")
+	}
+	return n, nil
+}
+
+// decimal parses a number token as a float64.
+func (p *queryParser) decimal(t queryToken) (float64, error) {
+	x, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return 0, p.errorf(t, "number out of range")
+	}
+	return x, nil
+}
+
+func (p *queryParser) expect(kind queryTokenKind, what string) (queryToken, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.errorf(t, "expected %s", what)
+	}
+	return t, nil
+}
+
+// parseQuery parses a query string.
+func parseQuery(src string) (*hgQuery, error) {
+	toks, err := lexQuery(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{toks: toks}
+	q := &hgQuery{}
+	switch {
+	case p.acceptWord("edges"):
+		q.target = queryEdges
+	case p.acceptWord("vertices"):
+		q.target = queryVertices
+	default:
+		return nil, p.errorf(p.peek(), "query must start with 'edges' or 'vertices'")
+	}
+	p.target = q.target
+	if p.acceptWord("where") {
+		if q.where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected token")
+	}
+	return q, nil
+}
+
+func (p *queryParser) parseOr() (queryExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryExpr, error) {
+	if p.acceptWord("not") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{inner}, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *queryParser) parsePredicate() (queryExpr, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, p.errorf(t, "expected predicate")
+	}
+	switch t.text {
+	case "size", "degree":
+		if (t.text == "size") != (p.target == queryEdges) {
+			return nil, p.errorf(t, "'%s' does not apply to %s", t.text, p.target)
+		}
+		op, err := p.expect(tokOp, "comparison operator")
+		if err != nil {
+			return nil, err
+		}
+		n, err := p.expect(tokNumber, "number")
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.number(n)
+		if err != nil {
+			return nil, err
+		}
+		return queryCompareInt{op: op.text, value: value}, nil
+
+	case "weight":
+		if p.target != queryEdges {
+			return nil, p.errorf(t, "'weight' does not apply to %s", p.target)
+		}
+		op, err := p.expect(tokOp, "comparison operator")
+		if err != nil {
+			return nil, err
+		}
+		n, err := p.expect(tokNumber, "number")
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.decimal(n)
+		if err != nil {
+			return nil, err
+		}
+		return queryCompareWeight{op: op.text, value: value}, nil
+
+	case "id":
+		if p.acceptWord("matches") {
+			s, err := p.expect(tokString, "pattern string")
+			if err != nil {
+				return nil, err
+			}
+			re, err := regexp.Compile(s.text)
+			if err != nil {
+				return nil, p.errorf(s, "bad pattern: %v", err)
+			}
+			return queryMatches{re}, nil
+		}
+		op, err := p.expect(tokOp, "comparison operator or 'matches'")
+		if err != nil {
+			return nil, err
+		}
+		s, err := p.expect(tokString, "string")
+		if err != nil {
+			return nil, err
+		}
+		return queryCompareID{op: op.text, value: s.text}, nil
+
+	case "contains", "in":
+		if (t.text == "contains") != (p.target == queryEdges) {
+			return nil, p.errorf(t, "'%s' does not apply to %s", t.text, p.target)
+		}
+		s, err := p.expect(tokString, "string")
+		if err != nil {
+			return nil, err
+		}
+		return queryMember{other: s.text}, nil
+
+	case "role", "plays":
+		if (t.text == "role") != (p.target == queryEdges) {
+			return nil, p.errorf(t, "'%s' does not apply to %s", t.text, p.target)
+		}
+		r, err := p.expect(tokString, "role")
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "plays" {
+			return queryRole{role: r.text}, nil
+		}
+		if p.acceptWord("matches") {
+			s, err := p.expect(tokString, "pattern string")
+			if err != nil {
+				return nil, err
+			}
+			re, err := regexp.Compile(s.text)
+			if err != nil {
+				return nil, p.errorf(s, "bad pattern: %v", err)
+			}
+			return queryRoleMatches{role: r.text, re: re}, nil
+		}
+		if !p.acceptWord("is") {
+			return nil, p.errorf(p.peek(), "expected 'is' or 'matches'")
+		}
+		s, err := p.expect(tokString, "vertex")
+		if err != nil {
+			return nil, err
+		}
+		return queryRole{role: r.text, vertex: s.text}, nil
+
+	case "within":
+		n, err := p.expect(tokNumber, "distance")
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptWord("of") {
+			return nil, p.errorf(p.peek(), "expected 'of'")
+		}
+		s, err := p.expect(tokString, "vertex")
+		if err != nil {
+			return nil, err
+		}
+		hops, err := p.number(n)
+		if err != nil {
+			return nil, err
+		}
+		return queryWithin{hops: hops, center: s.text}, nil
+	}
+	return nil, p.errorf(t, "unknown predicate")
+}
+
+// eval returns the sorted edge IDs or vertices selected by q.
+func (q *hgQuery) eval(hg *hypergraph.Hypergraph[string]) []string {
+	var items []string
+	if q.target == queryEdges {
+		items = hg.Edges()
+		slices.Sort(items)
+	} else {
+		items = hg.Vertices()
+		slices.SortFunc(items, hg.Compare)
+	}
+	if q.where == nil {
+		return items
+	}
+	ctx := &queryContext{hg: hg, target: q.target, distances: make(map[string]map[string]int)}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if q.where.eval(ctx, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// subgraph builds a hypergraph from a query result: the selected edges with
+// their members, or the subhypergraph induced by the selected vertices.
+func (q *hgQuery) subgraph(hg *hypergraph.Hypergraph[string], items []string) *hypergraph.Hypergraph[string] {
+	if q.target == queryVertices {
+		return hg.InducedSubhypergraph(items)
+	}
+	sub := hypergraph.NewHypergraphFunc(hg.Compare)
+	for _, id := range items {
+		_ = sub.AddEdge(id, hg.EdgeMembers(id))
+		_ = sub.SetEdgeRoles(id, hg.EdgeRoles(id))
+		if w := hg.EdgeWeight(id); w != 1 {
+			_ = sub.SetEdgeWeight(id, w)
+		}
+	}
+	return sub
+}
+
+// printQueryResult prints query results in the format of the edges and
+// vertices commands.
+func printQueryResult(w io.Writer, hg *hypergraph.Hypergraph[string], q *hgQuery, items []string) {
+	for _, item := range items {
+		if q.target == queryVertices {
+			fmt.Fprintln(w, item)
+			continue
+		}
+		members := hg.EdgeMembers(item)
+		slices.SortFunc(members, hg.Compare)
+		fmt.Fprintf(w, "%s: %s\n", item, strings.Join(members, ", "))
+	}
+}
+
+func cmdQuery(env *cmdEnv, args []string) error {
+	fs := env.flagSet("query")
+	file := fs.String("f", "", "input hypergraph JSON file")
+	output := fs.String("o", "", "write the result as a hypergraph to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || fs.NArg() == 0 {
+		return fmt.Errorf("missing required arguments: -f FILE QUERY")
+	}
+
+	q, err := parseQuery(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+
+	hg, err := env.loadGraph(*file)
+	if err != nil {
+		return err
+	}
+
+	items := q.eval(hg)
+	if *output != "" {
+		return env.saveGraph(q.subgraph(hg, items), *output)
+	}
+	printQueryResult(env.stdout(), hg, q, items)
+	return nil
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+)
+
+// createQueryTestGraph builds a graph with varied edge sizes and a path
+// A-B-C-D-E for distance queries.
+func createQueryTestGraph(t *testing.T) *hypergraph.Hypergraph[string] {
+	t.Helper()
+	hg := hypergraph.NewHypergraph[string]()
+	edges := map[string][]string{
+		"team-1": {"A", "B", "C"},
+		"team-2": {"C", "D"},
+		"pair-1": {"D", "E"},
+		"big":    {"A", "X", "Y", "Z"},
+	}
+	for id, members := range edges {
+		if err := hg.AddEdge(id, members); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hg.AddVertex("lonely")
+	return hg
+}
+
+// TestQueryEval tests query evaluation against a fixed graph.
+func TestQueryEval(t *testing.T) {
+	hg := createQueryTestGraph(t)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`edges`, []string{"big", "pair-1", "team-1", "team-2"}},
+		{`edges where size >= 3`, []string{"big", "team-1"}},
+		{`edges where size >= 3 and contains "A"`, []string{"big", "team-1"}},
+		{`edges where size = 2`, []string{"pair-1", "team-2"}},
+		{`edges where contains "C" or contains "E"`, []string{"pair-1", "team-1", "team-2"}},
+		{`edges where not contains "A"`, []string{"pair-1", "team-2"}},
+		{`edges where id matches "^team-"`, []string{"team-1", "team-2"}},
+		{`edges where id != "big" and (size > 2 or contains "E")`, []string{"pair-1", "team-1"}},
+		{`edges where within 0 of "E"`, []string{"pair-1"}},
+		{`vertices where degree >= 2`, []string{"A", "C", "D"}},
+		{`vertices where degree >= 1 and degree <= 1`, []string{"B", "E", "X", "Y", "Z"}},
+		{`vertices where degree = 0`, []string{"lonely"}},
+		{`vertices where in "team-2"`, []string{"C", "D"}},
+		{`vertices where within 1 of "C"`, []string{"A", "B", "C", "D"}},
+		{`vertices where within 2 of "C" and not id = "C"`, []string{"A", "B", "D", "E", "X", "Y", "Z"}},
+		{`vertices where within 3 of "missing"`, []string{}},
+		{`VERTICES WHERE DEGREE > 1 AND ID < "C"`, []string{"A"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := parseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseQuery failed: %v", err)
+			}
+			got := q.eval(hg)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestQueryEval_Roles tests the role predicates.
+func TestQueryEval_Roles(t *testing.T) {
+	hg := hypergraph.NewHypergraph[string]()
+	_ = hg.AddRoleEdge("f1", []hypergraph.RoleBinding[string]{{Role: "subject", Vertex: "alice"}, {Role: "object", Vertex: "bob"}})
+	_ = hg.AddRoleEdge("f2", []hypergraph.RoleBinding[string]{{Role: "subject", Vertex: "bob"}, {Role: "object", Vertex: "alice"}})
+	_ = hg.AddEdge("plain", []string{"alice", "carol"})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`edges where role "subject" is "alice"`, []string{"f1"}},
+		{`edges where role "object" is "alice" or contains "carol"`, []string{"f2", "plain"}},
+		{`edges where role "witness" is "alice"`, []string{}},
+		{`edges where role "object" matches "^b"`, []string{"f1"}},
+		{`edges where role "subject" matches "e$"`, []string{"f1"}},
+		{`edges where role "witness" matches ".*"`, []string{}},
+		{`vertices where plays "subject"`, []string{"alice", "bob"}},
+		{`vertices where not plays "object"`, []string{"carol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := parseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseQuery failed: %v", err)
+			}
+			if got := q.eval(hg); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	q, _ := parseQuery(`edges where role "subject" is "bob"`)
+	sub := q.subgraph(hg, q.eval(hg))
+	if got := sub.RoleMembers("f2", "object"); !slices.Equal(got, []string{"alice"}) {
+		t.Errorf("query result should keep roles, got %v", sub.EdgeRoles("f2"))
+	}
+}
+
+// TestQueryEval_WeightsAndOrder tests the weight predicate and that vertex
+// names compare in the hypergraph's vertex order.
+func TestQueryEval_WeightsAndOrder(t *testing.T) {
+	hg := newGraph(hypergraph.VertexTypeInt)
+	_ = hg.AddEdge("e1", []string{"1", "2"})
+	_ = hg.AddEdge("e2", []string{"2", "10"})
+	_ = hg.AddEdge("e10", []string{"9", "100"})
+	_ = hg.SetEdgeWeight("e2", 2.5)
+	_ = hg.SetEdgeWeight("e10", 0.25)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{`edges where weight = 1`, []string{"e1"}},
+		{`edges where weight > 1.5`, []string{"e2"}},
+		{`edges where weight < 0.5 or weight >= 2.5`, []string{"e10", "e2"}},
+		{`edges where id < "e2"`, []string{"e1", "e10"}},
+		{`vertices where id < "10"`, []string{"1", "2", "9"}},
+		{`vertices where id >= "10"`, []string{"10", "100"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := parseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseQuery failed: %v", err)
+			}
+			if got := q.eval(hg); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseQuery_Errors tests that malformed queries are rejected.
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query  string
+		errMsg string
+	}{
+		{``, "must start with"},
+		{`hyperedges`, "must start with"},
+		{`edges where`, "expected predicate"},
+		{`edges where degree > 1`, "does not apply to edges"},
+		{`vertices where size > 1`, "does not apply to vertices"},
+		{`vertices where contains "A"`, "does not apply to vertices"},
+		{`edges where in "E"`, "does not apply to edges"},
+		{`edges where size >`, "expected number"},
+		{`edges where size >= "3"`, "expected number"},
+		{`edges where id matches "("`, "bad pattern"},
+		{`edges where id = "unterminated`, "unterminated string"},
+		{`edges where within 2 "A"`, "expected 'of'"},
+		{`edges where (size > 1`, "expected ')'"},
+		{`edges where size > 1 size`, "unexpected token"},
+		{`edges where size ! 1`, "unexpected '!'"},
+		{`edges where bogus`, "unknown predicate"},
+		{`edges where size > 1 @`, "unexpected"},
+		{`vertices where role "r" is "A"`, "does not apply to vertices"},
+		{`edges where plays "r"`, "does not apply to edges"},
+		{`edges where role "r" "A"`, "expected 'is' or 'matches'"},
+		{`edges where role "r" matches "("`, "bad pattern"},
+		{`edges where size > 99999999999999999999`, "number out of range"},
+		{`edges where size > 1.5`, "expected a whole number"},
+		{`vertices where weight > 1`, "does not apply to vertices"},
+		{`edges where weight >= "2"`, "expected number"},
+		{`edges where weight > 1.`, "unexpected '.'"},
+		{`vertices where within 99999999999999999999 of "A"`, "number out of range"},
+		{`edges where größe > 1`, `unknown predicate (near "größe"`},
+		{`edges where size > ٣`, "unexpected '٣'"},
+		{"edges where id = \"a\" \xff", "invalid UTF-8 at offset 21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parseQuery(tt.query)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("error %q should contain %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestCmdQuery tests the query command.
+func TestCmdQuery(t *testing.T) {
+	t.Run("missing_args", func(t *testing.T) {
+		err := cmdQuery(osEnv, []string{"-f", "x.json"})
+		if err == nil || !strings.Contains(err.Error(), "missing required") {
+			t.Fatalf("expected missing argument error, got %v", err)
+		}
+	})
+
+	t.Run("parse_error", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+		if err := cmdQuery(osEnv, []string{"-f", path, "nodes"}); err == nil {
+			t.Fatal("expected parse error")
+		}
+	})
+
+	t.Run("print_edges", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+
+		output := captureStdout(t, func() {
+			if err := cmdQuery(osEnv, []string{"-f", path, `edges where contains "c"`}); err != nil {
+				t.Fatalf("cmdQuery failed: %v", err)
+			}
+		})
+
+		if strings.TrimSpace(output) != "e2: b, c" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("query_split_across_args", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "test.json")
+
+		output := captureStdout(t, func() {
+			if err := cmdQuery(osEnv, []string{"-f", path, "vertices", "where", "degree", ">", "1"}); err != nil {
+				t.Fatalf("cmdQuery failed: %v", err)
+			}
+		})
+
+		if strings.TrimSpace(output) != "b" {
+			t.Errorf("unexpected output: %q", output)
+		}
+	})
+
+	t.Run("write_edge_result", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "in.json")
+		out := filepath.Join(dir, "out.json")
+		if err := osEnv.saveGraph(createQueryTestGraph(t), path); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cmdQuery(osEnv, []string{"-f", path, "-o", out, `edges where size >= 3`}); err != nil {
+			t.Fatalf("cmdQuery failed: %v", err)
+		}
+
+		sub, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edges := sub.Edges()
+		slices.Sort(edges)
+		if !slices.Equal(edges, []string{"big", "team-1"}) {
+			t.Errorf("edges = %v, want [big team-1]", edges)
+		}
+		if sub.NumVertices() != 6 {
+			t.Errorf("NumVertices = %d, want 6", sub.NumVertices())
+		}
+	})
+
+	t.Run("write_induced_vertex_result", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "in.json")
+		out := filepath.Join(dir, "out.json")
+		if err := osEnv.saveGraph(createQueryTestGraph(t), path); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cmdQuery(osEnv, []string{"-f", path, "-o", out, `vertices where within 1 of "D"`}); err != nil {
+			t.Fatalf("cmdQuery failed: %v", err)
+		}
+
+		sub, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edges := sub.Edges()
+		slices.Sort(edges)
+		if !slices.Equal(edges, []string{"pair-1", "team-2"}) {
+			t.Errorf("edges = %v, want [pair-1 team-2]", edges)
+		}
+		if sub.NumVertices() != 3 {
+			t.Errorf("NumVertices = %d, want 3", sub.NumVertices())
+		}
+	})
+}
+
+// TestExecuteReplCommand_Query tests the query REPL command.
+func TestExecuteReplCommand_Query(t *testing.T) {
+	state := newTestReplState(t)
+
+	output := captureStdout(t, func() {
+		if err := executeReplCommand(state, `query edges where contains "a"`); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+	})
+	if strings.TrimSpace(output) != "e1: a, b" {
+		t.Errorf("unexpected output: %q", output)
+	}
+
+	if err := executeReplCommand(state, "query"); err == nil {
+		t.Error("expected error for empty query")
+	}
+}
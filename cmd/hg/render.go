@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+)
+
+func cmdRender(env *cmdEnv, args []string) error {
+	fs := env.flagSet("render")
+	file := fs.String("f", "", "input hypergraph JSON file")
+	output := fs.String("o", "", "output SVG file")
+	style := fs.String("style", "star", "edge style: star or hull")
+	seed := fs.Uint64("seed", 0, "layout seed")
+	width := fs.Int("width", 800, "image width in pixels")
+	height := fs.Int("height", 600, "image height in pixels")
+	iterations := fs.Int("iterations", 200, "layout iterations")
+	labels := fs.Bool("labels", true, "draw vertex and edge labels")
+	color := fs.String("color", "none", "color by: none, communities or coloring")
+	maxVertices := fs.Int("max-vertices", hypergraph.DefaultRenderMaxVertices, "refuse inputs with more vertices (-1: no limit)")
+	maxEdges := fs.Int("max-edges", hypergraph.DefaultRenderMaxEdges, "refuse inputs with more edges (-1: no limit)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || *output == "" {
+		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
+	}
+
+	if *iterations == 0 {
+		*iterations = -1 // the library reads zero as its default
+	}
+	opts := hypergraph.RenderOptions[string]{
+		Width:       *width,
+		Height:      *height,
+		Seed:        *seed,
+		Iterations:  *iterations,
+		Labels:      *labels,
+		MaxVertices: *maxVertices,
+		MaxEdges:    *maxEdges,
+	}
+	switch *style {
+	case "star":
+		opts.Style = hypergraph.StarStyle
+	case "hull":
+		opts.Style = hypergraph.HullStyle
+	default:
+		return fmt.Errorf("unknown style %q (want star or hull)", *style)
+	}
+
+	hg, err := env.loadGraph(*file)
+	if err != nil {
+		return err
+	}
+
+	// Coloring costs more than drawing would, so check the limits first.
+	if err := hg.CheckRenderSize(opts); err != nil {
+		return err
+	}
+	switch *color {
+	case "none":
+	case "communities":
+		opts.VertexClasses, _ = hg.Louvain(hypergraph.LouvainOptions{Seed: *seed})
+	case "coloring":
+		opts.VertexClasses = hg.GreedyColoring()
+	default:
+		return fmt.Errorf("unknown color mode %q (want none, communities or coloring)", *color)
+	}
+
+	var buf bytes.Buffer
+	if err := hg.RenderSVG(&buf, opts); err != nil {
+		return err
+	}
+	return env.writeFileAtomic(*output, func(w io.Writer) error {
+		_, err := w.Write(buf.Bytes())
+		return err
+	})
+}
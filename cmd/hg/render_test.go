@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCmdRender tests the render command.
+func TestCmdRender(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		err := cmdRender(osEnv, []string{"-f", "x.json"})
+		if err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("render_svg", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "input.json")
+		out := filepath.Join(dir, "out.svg")
+
+		if err := cmdRender(osEnv, []string{"-f", path, "-o", out, "-seed", "7", "-color", "coloring"}); err != nil {
+			t.Fatalf("cmdRender failed: %v", err)
+		}
+		first, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		svg := string(first)
+		if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, ">b</text>") {
+			t.Errorf("unexpected SVG:\n%s", svg)
+		}
+
+		if err := cmdRender(osEnv, []string{"-f", path, "-o", out, "-seed", "7", "-color", "coloring"}); err != nil {
+			t.Fatal(err)
+		}
+		second, _ := os.ReadFile(out)
+		if string(second) != svg {
+			t.Error("same seed produced a different image")
+		}
+	})
+
+	t.Run("hull_style_without_labels", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "input.json")
+		out := filepath.Join(dir, "out.svg")
+
+		if err := cmdRender(osEnv, []string{"-f", path, "-o", out, "-style", "hull", "-labels=false", "-color", "communities"}); err != nil {
+			t.Fatalf("cmdRender failed: %v", err)
+		}
+		data, _ := os.ReadFile(out)
+		if strings.Contains(string(data), "<text") || strings.Count(string(data), "<path") != 2 {
+			t.Errorf("unexpected SVG:\n%s", data)
+		}
+	})
+
+	t.Run("size_limit", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "input.json")
+		out := filepath.Join(dir, "out.svg")
+
+		err := cmdRender(osEnv, []string{"-f", path, "-o", out, "-max-edges", "1", "-color", "communities"})
+		if err == nil || !strings.Contains(err.Error(), "too large") {
+			t.Errorf("expected size error, got %v", err)
+		}
+		if _, statErr := os.Stat(out); !os.IsNotExist(statErr) {
+			t.Error("output written despite size limit")
+		}
+	})
+
+	t.Run("bad_style", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "input.json")
+		err := cmdRender(osEnv, []string{"-f", path, "-o", filepath.Join(dir, "out.svg"), "-style", "blobs"})
+		if err == nil || !strings.Contains(err.Error(), "unknown style") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -3,8 +3,8 @@ package main
 import (
 	"bufio"
 	"errors"
-	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"slices"
@@ -16,31 +16,43 @@ import (
 
 type replState struct {
 	hg            *hypergraph.Hypergraph[string]
+	vertexType    string // vertex type :save writes
 	file          string
 	modified      bool
 	quitConfirmed bool
 	newConfirmed  bool
+	out           io.Writer // command output; nil means os.Stdout
+}
+
+func (s *replState) stdout() io.Writer {
+	if s.out == nil {
+		return os.Stdout
+	}
+	return s.out
 }
 
 var errQuit = errors.New("quit")
 
-func cmdREPL(args []string) error {
-	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+func cmdREPL(env *cmdEnv, args []string) error {
+	fs := env.flagSet("repl")
 	file := fs.String("f", "", "initial file to load")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
 	state := &replState{
-		hg: hypergraph.NewHypergraph[string](),
+		hg:         newGraph(env.vertexType),
+		vertexType: env.vertexType,
 	}
 
 	if *file != "" {
-		hg, err := loadGraph(*file)
+		e := env.clone()
+		hg, err := e.loadGraph(*file)
 		if err != nil {
 			return err
 		}
 		state.hg = hg
+		state.vertexType = e.vertexType
 		state.file = *file
 		fmt.Printf("Loaded %s\n", *file)
 	}
@@ -102,6 +114,7 @@ func executeReplCommand(state *replState, line string) error {
 	}
 	cmd := parts[0]
 	args := parts[1:]
+	out := state.stdout()
 
 	// Reset confirmation flags for unrelated commands
 	if cmd != ":quit" && cmd != ":q" {
@@ -114,28 +127,31 @@ func executeReplCommand(state *replState, line string) error {
 	switch cmd {
 	case ":quit", ":q":
 		if state.modified && !state.quitConfirmed {
-			fmt.Println("Warning: unsaved changes. Use :save or :quit again to exit.")
+			fmt.Fprintln(out, "Warning: unsaved changes. Use :save or :quit again to exit.")
 			state.quitConfirmed = true
 			return nil
 		}
 		return errQuit
 
 	case ":help", ":h":
-		printReplHelp()
+		printReplHelp(out)
 		return nil
 
 	case ":load":
 		if len(args) < 1 {
 			return fmt.Errorf("usage: :load FILE")
 		}
-		hg, err := loadGraph(args[0])
+		// Each file keeps its own vertex type unless --vertex-type is set.
+		e := osEnv.clone()
+		hg, err := e.loadGraph(args[0])
 		if err != nil {
 			return err
 		}
 		state.hg = hg
+		state.vertexType = e.vertexType
 		state.file = args[0]
 		state.modified = false
-		fmt.Printf("Loaded %s\n", args[0])
+		fmt.Fprintf(out, "Loaded %s\n", args[0])
 		return nil
 
 	case ":save":
@@ -146,36 +162,37 @@ func executeReplCommand(state *replState, line string) error {
 		if file == "" {
 			return fmt.Errorf("no file specified (use :save FILE)")
 		}
-		if err := saveGraph(state.hg, file); err != nil {
+		if err := osEnv.saveGraphAs(state.hg, state.vertexType, file); err != nil {
 			return err
 		}
 		state.file = file
 		state.modified = false
-		fmt.Printf("Saved to %s\n", file)
+		fmt.Fprintf(out, "Saved to %s\n", file)
 		return nil
 
 	case ":new":
 		if state.modified && !state.newConfirmed {
-			fmt.Println("Warning: unsaved changes. Use :save first or :new again.")
+			fmt.Fprintln(out, "Warning: unsaved changes. Use :save first or :new again.")
 			state.newConfirmed = true
 			return nil
 		}
-		state.hg = hypergraph.NewHypergraph[string]()
+		state.hg = newGraph(osEnv.vertexType)
+		state.vertexType = osEnv.vertexType
 		state.file = ""
 		state.modified = false
 		state.newConfirmed = false
-		fmt.Println("Created empty hypergraph.")
+		fmt.Fprintln(out, "Created empty hypergraph.")
 		return nil
 
 	case ":info":
-		fmt.Printf("Vertices: %d\n", state.hg.NumVertices())
-		fmt.Printf("Edges:    %d\n", state.hg.NumEdges())
-		fmt.Printf("Empty:    %v\n", state.hg.IsEmpty())
+		fmt.Fprintf(out, "Vertices: %d\n", state.hg.NumVertices())
+		fmt.Fprintf(out, "Edges:    %d\n", state.hg.NumEdges())
+		fmt.Fprintf(out, "Empty:    %v\n", state.hg.IsEmpty())
 		if state.file != "" {
-			fmt.Printf("File:     %s\n", state.file)
+			fmt.Fprintf(out, "File:     %s\n", state.file)
 		}
 		if state.modified {
-			fmt.Println("(unsaved changes)")
+			fmt.Fprintln(out, "(unsaved changes)")
 		}
 		return nil
 
@@ -202,7 +219,7 @@ func executeReplCommand(state *replState, line string) error {
 		if len(args) < 1 {
 			return fmt.Errorf("usage: has-vertex VERTEX")
 		}
-		fmt.Println(state.hg.HasVertex(args[0]))
+		fmt.Fprintln(out, state.hg.HasVertex(args[0]))
 		return nil
 
 	case "add-edge":
@@ -234,14 +251,14 @@ func executeReplCommand(state *replState, line string) error {
 		if len(args) < 1 {
 			return fmt.Errorf("usage: has-edge ID")
 		}
-		fmt.Println(state.hg.HasEdge(args[0]))
+		fmt.Fprintln(out, state.hg.HasEdge(args[0]))
 		return nil
 
 	case "vertices":
 		verts := state.hg.Vertices()
-		slices.Sort(verts)
+		slices.SortFunc(verts, state.hg.Compare)
 		for _, v := range verts {
-			fmt.Println(v)
+			fmt.Fprintln(out, v)
 		}
 		return nil
 
@@ -250,8 +267,8 @@ func executeReplCommand(state *replState, line string) error {
 		slices.Sort(edges)
 		for _, id := range edges {
 			members := state.hg.EdgeMembers(id)
-			slices.Sort(members)
-			fmt.Printf("%s: %s\n", id, strings.Join(members, ", "))
+			slices.SortFunc(members, state.hg.Compare)
+			fmt.Fprintf(out, "%s: %s\n", id, strings.Join(members, ", "))
 		}
 		return nil
 
@@ -262,7 +279,7 @@ func executeReplCommand(state *replState, line string) error {
 		if !state.hg.HasVertex(args[0]) {
 			return fmt.Errorf("vertex not found: %s", args[0])
 		}
-		fmt.Println(state.hg.VertexDegree(args[0]))
+		fmt.Fprintln(out, state.hg.VertexDegree(args[0]))
 		return nil
 
 	case "edge-size":
@@ -273,7 +290,15 @@ func executeReplCommand(state *replState, line string) error {
 		if !ok {
 			return fmt.Errorf("edge not found: %s", args[0])
 		}
-		fmt.Println(size)
+		fmt.Fprintln(out, size)
+		return nil
+
+	case "query":
+		q, err := parseQuery(strings.TrimSpace(strings.TrimPrefix(line, cmd)))
+		if err != nil {
+			return err
+		}
+		printQueryResult(out, state.hg, q, q.eval(state.hg))
 		return nil
 
 	case "bfs":
@@ -284,7 +309,7 @@ func executeReplCommand(state *replState, line string) error {
 			return fmt.Errorf("vertex not found: %s", args[0])
 		}
 		result := state.hg.BFS(args[0])
-		fmt.Println(strings.Join(result, " "))
+		fmt.Fprintln(out, strings.Join(result, " "))
 		return nil
 
 	case "dfs":
@@ -295,37 +320,38 @@ func executeReplCommand(state *replState, line string) error {
 			return fmt.Errorf("vertex not found: %s", args[0])
 		}
 		result := state.hg.DFS(args[0])
-		fmt.Println(strings.Join(result, " "))
+		fmt.Fprintln(out, strings.Join(result, " "))
 		return nil
 
 	case "components":
 		components := state.hg.ConnectedComponents()
 		for i, comp := range components {
-			slices.Sort(comp)
-			fmt.Printf("Component %d: %s\n", i+1, strings.Join(comp, ", "))
+			slices.SortFunc(comp, state.hg.Compare)
+			fmt.Fprintf(out, "Component %d: %s\n", i+1, strings.Join(comp, ", "))
 		}
 		return nil
 
 	case "hitting-set":
 		result := state.hg.GreedyHittingSet()
-		slices.Sort(result)
-		fmt.Println(strings.Join(result, " "))
+		slices.SortFunc(result, state.hg.Compare)
+		fmt.Fprintln(out, strings.Join(result, " "))
 		return nil
 
 	case "coloring":
 		coloring := state.hg.GreedyColoring()
 		vertices := state.hg.Vertices()
-		slices.Sort(vertices)
+		slices.SortFunc(vertices, state.hg.Compare)
 		for _, v := range vertices {
-			fmt.Printf("%s: %d\n", v, coloring[v])
+			fmt.Fprintf(out, "%s: %d\n", v, coloring[v])
 		}
 		return nil
 
 	case "dual":
 		dual := state.hg.Dual()
 		state.hg = dual
+		state.vertexType = hypergraph.VertexTypeString // vertices are edge IDs
 		state.modified = true
-		fmt.Println("Computed dual (current hypergraph replaced).")
+		fmt.Fprintln(out, "Computed dual (current hypergraph replaced).")
 		return nil
 
 	default:
@@ -333,8 +359,8 @@ func executeReplCommand(state *replState, line string) error {
 	}
 }
 
-func printReplHelp() {
-	fmt.Println(`REPL Commands:
+func printReplHelp(w io.Writer) {
+	fmt.Fprintln(w, `REPL Commands:
   :load FILE    Load hypergraph from file
   :save [FILE]  Save to file
   :new          Create new empty hypergraph
@@ -353,6 +379,7 @@ Operations:
   edges                List all edges
   degree V             Get vertex degree
   edge-size ID         Get edge size
+  query QUERY          Select edges or vertices (see hg help query)
   bfs V                BFS from vertex
   dfs V                DFS from vertex
   components           Show connected components
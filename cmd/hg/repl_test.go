@@ -243,7 +243,7 @@ func TestExecuteReplCommand_Save(t *testing.T) {
 		}
 
 		// Verify file was created
-		loaded, err := loadGraph(path)
+		loaded, err := osEnv.loadGraph(path)
 		if err != nil {
 			t.Fatalf("failed to load saved graph: %v", err)
 		}
@@ -876,12 +876,12 @@ func TestAtomicSave(t *testing.T) {
 
 		// Check that temp file was cleaned up
 		tmpPath := path + ".tmp"
-		if _, err := loadGraph(tmpPath); err == nil {
+		if _, err := osEnv.loadGraph(tmpPath); err == nil {
 			t.Error("temp file should not exist after successful save")
 		}
 
 		// Verify the actual file exists and is valid
-		loaded, err := loadGraph(path)
+		loaded, err := osEnv.loadGraph(path)
 		if err != nil {
 			t.Fatalf("failed to load saved graph: %v", err)
 		}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// runScript executes a script of REPL commands, one per line, stopping at
+// the first error. Blank lines and lines starting with # are skipped.
+// Errors are prefixed with the script name and line number.
+func runScript(state *replState, name string, script []byte) (int, error) {
+	ran := 0
+	for i, line := range strings.Split(string(script), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if cmd := strings.Fields(line)[0]; sessionCommands[cmd] {
+			return ran, fmt.Errorf("%s:%d: command %s is not available in scripts", name, i+1, cmd)
+		}
+		if err := executeReplCommand(state, line); err != nil {
+			return ran, fmt.Errorf("%s:%d: %w", name, i+1, err)
+		}
+		ran++
+	}
+	return ran, nil
+}
+
+func cmdRun(env *cmdEnv, args []string) error {
+	fs := env.flagSet("run")
+	file := fs.String("f", "", "input hypergraph file, or - for stdin (default: empty hypergraph)")
+	output := fs.String("o", "", "output file, or - for stdout (default: modify in-place)")
+	dryRun := fs.Bool("dry-run", false, "run the script but save nothing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("missing required argument: SCRIPT")
+	}
+	scriptFile := fs.Arg(0)
+	if scriptFile == stdio && *file == stdio {
+		return fmt.Errorf("the script and the hypergraph cannot both be read from stdin")
+	}
+
+	script, err := env.readInput(scriptFile)
+	if err != nil {
+		return err
+	}
+	hg := newGraph(env.vertexType)
+	if *file != "" {
+		if hg, err = env.loadGraph(*file); err != nil {
+			return err
+		}
+	}
+
+	outFile := *output
+	if outFile == "" {
+		outFile = *file
+	}
+	// Keep standard output for the hypergraph when it is written there.
+	var out io.Writer = env.stdout()
+	if outFile == stdio {
+		out = env.stderr()
+	}
+
+	// The script runs against the hypergraph in memory, so a failing
+	// command leaves every file untouched.
+	state := &replState{hg: hg, vertexType: env.vertexType, file: *file, out: out}
+	name := scriptFile
+	if name == stdio {
+		name = "<stdin>"
+	}
+	ran, err := runScript(state, name, script)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *dryRun:
+		fmt.Fprintf(env.stderr(), "dry run: %d commands succeeded, nothing saved\n", ran)
+		return nil
+	case outFile == "" && state.modified:
+		return fmt.Errorf("the script changed the hypergraph but there is nowhere to save it: give -f FILE, -o OUTPUT or --dry-run")
+	case outFile == "":
+		return nil
+	case *output == "" && *file != stdio && !state.modified:
+		// Nothing to write back in place.
+		return nil
+	}
+	return env.saveGraphAs(state.hg, state.vertexType, outFile)
+}
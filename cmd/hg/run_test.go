@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withStdin runs f with os.Stdin reading data.
+func withStdin(t *testing.T, data string, f func()) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	in, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = in.Close() }()
+	old := os.Stdin
+	os.Stdin = in
+	defer func() { os.Stdin = old }()
+	f()
+}
+
+func writeScript(t *testing.T, dir, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, "script.hg")
+	if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestCmdRun tests the run command.
+func TestCmdRun(t *testing.T) {
+	t.Run("missing_script", func(t *testing.T) {
+		if err := cmdRun(osEnv, nil); err == nil || !strings.Contains(err.Error(), "missing required argument") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("in_place", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "g.json")
+		script := writeScript(t, dir, "# grow the graph\nadd-edge e3 c,d\n\nremove-vertex a\nedges\n")
+		var err error
+		output := captureStdout(t, func() { err = cmdRun(osEnv, []string{"-f", path, script}) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != "e1: b\ne2: b, c\ne3: c, d\n" {
+			t.Errorf("output = %q", output)
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if hg.HasVertex("a") || !hg.HasEdge("e3") {
+			t.Error("changes were not saved")
+		}
+	})
+
+	t.Run("stop_on_error", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "g.json")
+		script := writeScript(t, dir, "add-vertex z\nremove-edge nope\nadd-vertex y\n")
+		err := cmdRun(osEnv, []string{"-f", path, script})
+		if err == nil || !strings.HasSuffix(err.Error(), "script.hg:2: edge not found: nope") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if hg.HasVertex("z") {
+			t.Error("a failed script must not save its earlier changes")
+		}
+	})
+
+	t.Run("dry_run", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTestGraphFile(t, dir, "g.json")
+		script := writeScript(t, dir, "add-vertex z\n")
+		stdout, stderr := captureOutput(t, func() {
+			if err := cmdRun(osEnv, []string{"-f", path, "--dry-run", script}); err != nil {
+				t.Error(err)
+			}
+		})
+		if stdout != "" || !strings.Contains(stderr, "dry run: 1 commands succeeded") {
+			t.Errorf("stdout = %q, stderr = %q", stdout, stderr)
+		}
+		if hg, _ := osEnv.loadGraph(path); hg.HasVertex("z") {
+			t.Error("dry run saved the hypergraph")
+		}
+	})
+
+	t.Run("nowhere_to_save", func(t *testing.T) {
+		script := writeScript(t, t.TempDir(), "add-edge e1 a,b\nedges\n")
+		var err error
+		output := captureStdout(t, func() { err = cmdRun(osEnv, []string{script}) })
+		if err == nil || !strings.Contains(err.Error(), "nowhere to save it") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if output != "e1: a, b\n" {
+			t.Errorf("output = %q", output)
+		}
+
+		script = writeScript(t, t.TempDir(), "has-vertex a\n")
+		output = captureStdout(t, func() { err = cmdRun(osEnv, []string{script}) })
+		if err != nil || output != "false\n" {
+			t.Errorf("a read-only script needs no output: err = %v, output = %q", err, output)
+		}
+	})
+
+	t.Run("session_commands", func(t *testing.T) {
+		script := writeScript(t, t.TempDir(), "vertices\n:save other.json\n")
+		err := cmdRun(osEnv, []string{script})
+		if err == nil || !strings.Contains(err.Error(), "script.hg:2: command :save is not available") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		dir := t.TempDir()
+		data, _ := os.ReadFile(writeTestGraphFile(t, dir, "g.json"))
+		script := writeScript(t, dir, "add-vertex z\nhas-vertex z\n")
+		var stdout, stderr string
+		withStdin(t, string(data), func() {
+			stdout, stderr = captureOutput(t, func() {
+				if err := cmdRun(osEnv, []string{"-f", "-", "-o", "-", script}); err != nil {
+					t.Error(err)
+				}
+			})
+		})
+		if stderr != "true\n" {
+			t.Errorf("command output should go to stderr, got %q", stderr)
+		}
+		out := filepath.Join(dir, "out.json")
+		withStdin(t, stdout, func() {
+			if err := cmdCopy(osEnv, []string{"-f", "-", "-o", out}); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if hg, _ := osEnv.loadGraph(out); hg == nil || !hg.HasVertex("z") || hg.NumEdges() != 2 {
+			t.Errorf("hypergraph written to stdout: %q", stdout)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
+	"github.com/watchthelight/HypergraphGo/hypergraph/hgclient"
+)
+
+// maxRequestBody bounds the size of a request to hg serve.
+const maxRequestBody = 1 << 20
+
+// readOnlyCommands are the REPL commands that only read the hypergraph, so
+// any number of them may run at once. Every other command runs alone.
+var readOnlyCommands = map[string]bool{
+	":help": true, ":h": true, ":info": true,
+	"has-vertex": true, "has-edge": true, "vertices": true, "edges": true,
+	"degree": true, "edge-size": true, "query": true, "bfs": true,
+	"dfs": true, "components": true, "hitting-set": true, "coloring": true,
+}
+
+// sessionCommands manage a REPL session's file and have no meaning for a
+// server, which persists through its save endpoint instead.
+var sessionCommands = map[string]bool{
+	":load": true, ":save": true, ":new": true, ":quit": true, ":q": true,
+}
+
+// cliCommands are the hg commands that hg serve runs besides the REPL's,
+// with the same flags as on the command line. The served hypergraph is
+// their -f input, and any other input they name, such as the pattern of
+// match, is the served hypergraph of that name. They cannot change a
+// hypergraph or write files: what they would write goes to the output when
+// given -o -.
+var cliCommands = map[string]func(*cmdEnv, []string) error{
+	"info": cmdInfo, "stats": cmdStats, "copy": cmdCopy,
+	"two-section": cmdTwoSection, "line-graph": cmdLineGraph, "star": cmdStar,
+	"transversals": cmdTransversals, "core": cmdCore, "independent": cmdIndependentSet,
+	"homology": cmdHomology, "mincut": cmdMinCut, "edge-cover": cmdEdgeCover,
+	"match": cmdMatch, "motifs": cmdMotifs, "robustness": cmdRobustness,
+	"incidence": cmdIncidence, "render": cmdRender,
+}
+
+// serveGraph is a hypergraph held in memory by hg serve.
+type serveGraph struct {
+	mu         sync.RWMutex
+	hg         *hypergraph.Hypergraph[string]
+	vertexType string // of file, which save writes back
+	file       string
+	modified   bool
+}
+
+// exec runs one REPL command against the hypergraph and returns what it
+// printed.
+func (g *serveGraph) exec(line string) (string, error) {
+	fields := strings.Fields(line)
+	readOnly := readOnlyCommands[fields[0]]
+	if readOnly {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+	} else {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+	}
+	var out bytes.Buffer
+	state := &replState{hg: g.hg, vertexType: g.vertexType, file: g.file, modified: g.modified, out: &out}
+	err := executeReplCommand(state, line)
+	if !readOnly {
+		g.hg, g.vertexType, g.modified = state.hg, state.vertexType, state.modified
+	}
+	return out.String(), err
+}
+
+// save writes the hypergraph back to its file.
+func (g *serveGraph) save() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err := osEnv.saveGraphAs(g.hg, g.vertexType, g.file); err != nil {
+		return err
+	}
+	g.modified = false
+	return nil
+}
+
+// info describes the hypergraph for GET /graphs.
+func (g *serveGraph) info(name string) hgclient.GraphInfo {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return hgclient.GraphInfo{Name: name, File: g.file, Vertices: g.hg.NumVertices(), Edges: g.hg.NumEdges(), Modified: g.modified}
+}
+
+// hgServer serves a fixed set of named hypergraphs over HTTP and JSON:
+//
+//	GET  /graphs              list the hypergraphs
+//	POST /graphs/NAME/exec    run {"command": "..."} and return {"output": "..."}
+//	POST /graphs/NAME/save    write the hypergraph back to its file
+//
+// Commands use the REPL grammar, or are one of cliCommands. A failed
+// command returns status 422 with its message in "error". Package hgclient is a Go client for it.
+type hgServer struct {
+	graphs map[string]*serveGraph
+}
+
+// newServer loads each file under its name. Each keeps the vertex type
+// its file records unless --vertex-type is set.
+func newServer(files map[string]string) (*hgServer, error) {
+	s := &hgServer{graphs: make(map[string]*serveGraph, len(files))}
+	for name, file := range files {
+		env := osEnv.clone()
+		hg, err := env.loadGraph(file)
+		if err != nil {
+			return nil, err
+		}
+		s.graphs[name] = &serveGraph{hg: hg, vertexType: env.vertexType, file: file}
+	}
+	return s, nil
+}
+
+// exec runs a command against g and returns what it printed: a command
+// from cliCommands against a copy of g, anything else as a REPL command.
+func (s *hgServer) exec(g *serveGraph, line string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", errors.New("empty command")
+	}
+	if sessionCommands[fields[0]] {
+		return "", fmt.Errorf("command %s is not available in hg serve", fields[0])
+	}
+	run, ok := cliCommands[fields[0]]
+	if !ok {
+		return g.exec(line)
+	}
+
+	var out bytes.Buffer
+	g.mu.RLock()
+	vertexType := g.vertexType
+	g.mu.RUnlock()
+	env := &cmdEnv{out: &out, errOut: &out, vertexType: vertexType, graph: func(name string) (*hypergraph.Hypergraph[string], error) {
+		src := g
+		if name != stdio {
+			if src = s.graphs[name]; src == nil {
+				return nil, fmt.Errorf("no hypergraph named %q", name)
+			}
+		}
+		src.mu.RLock()
+		defer src.mu.RUnlock()
+		return src.hg.Copy(), nil
+	}}
+	err := run(env, append([]string{"-f", stdio}, fields[1:]...))
+	return out.String(), err
+}
+
+func (s *hgServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /graphs", s.handleList)
+	mux.HandleFunc("POST /graphs/{name}/exec", s.handleExec)
+	mux.HandleFunc("POST /graphs/{name}/save", s.handleSave)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *hgServer) lookup(w http.ResponseWriter, r *http.Request) *serveGraph {
+	name := r.PathValue("name")
+	g, ok := s.graphs[name]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, hgclient.ExecResponse{Error: fmt.Sprintf("no hypergraph named %q", name)})
+	}
+	return g
+}
+
+func (s *hgServer) handleList(w http.ResponseWriter, r *http.Request) {
+	infos := []hgclient.GraphInfo{}
+	for _, name := range slices.Sorted(maps.Keys(s.graphs)) {
+		infos = append(infos, s.graphs[name].info(name))
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *hgServer) handleExec(w http.ResponseWriter, r *http.Request) {
+	g := s.lookup(w, r)
+	if g == nil {
+		return
+	}
+	var req hgclient.ExecRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, hgclient.ExecResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	output, err := s.exec(g, req.Command)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, hgclient.ExecResponse{Output: output, Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, hgclient.ExecResponse{Output: output})
+}
+
+func (s *hgServer) handleSave(w http.ResponseWriter, r *http.Request) {
+	g := s.lookup(w, r)
+	if g == nil {
+		return
+	}
+	if err := g.save(); err != nil {
+		writeJSON(w, http.StatusInternalServerError, hgclient.ExecResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, hgclient.ExecResponse{})
+}
+
+// unsaved returns the names of the hypergraphs with unsaved changes.
+func (s *hgServer) unsaved() []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(s.graphs)) {
+		if s.graphs[name].info(name).Modified {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// graphName is the name hg serve gives a file: its base name without
+// extensions, so data/social.ndjson.gz is "social".
+func graphName(file string) string {
+	name := filepath.Base(file)
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func cmdServe(env *cmdEnv, args []string) error {
+	fs := env.flagSet("serve")
+	addr := fs.String("addr", "127.0.0.1:7070", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("missing required arguments: [NAME=]FILE...")
+	}
+
+	files := make(map[string]string)
+	for _, arg := range fs.Args() {
+		name, file, ok := strings.Cut(arg, "=")
+		if !ok {
+			name, file = graphName(arg), arg
+		}
+		if name == "" || file == "" {
+			return fmt.Errorf("invalid argument %q: want [NAME=]FILE", arg)
+		}
+		if _, dup := files[name]; dup {
+			return fmt.Errorf("duplicate hypergraph name %q", name)
+		}
+		files[name] = file
+	}
+
+	s, err := newServer(files)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Addr: *addr, Handler: s.handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving %s on http://%s\n", strings.Join(slices.Sorted(maps.Keys(files)), ", "), *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	if names := s.unsaved(); len(names) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: discarded unsaved changes to %s\n", strings.Join(names, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph/hgclient"
+)
+
+// startTestServer serves the test hypergraph as "g" from an in-process
+// server and returns a client for it and the file it was loaded from.
+func startTestServer(t *testing.T) (*hgclient.Client, string) {
+	t.Helper()
+	path := writeTestGraphFile(t, t.TempDir(), "g.json")
+	s, err := newServer(map[string]string{"g": path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.handler())
+	t.Cleanup(ts.Close)
+	return hgclient.New(ts.URL), path
+}
+
+// TestCmdServe tests hg serve through its client.
+func TestCmdServe(t *testing.T) {
+	t.Run("missing_args", func(t *testing.T) {
+		if err := cmdServe(osEnv, nil); err == nil || !strings.Contains(err.Error(), "missing required arguments") {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := cmdServe(osEnv, []string{"a=x.json", "a=y.json"}); err == nil || !strings.Contains(err.Error(), "duplicate hypergraph name") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c, _ := startTestServer(t)
+		if _, err := c.Exec("g", "add-edge e3 c,d"); err != nil {
+			t.Fatal(err)
+		}
+		out, err := c.Exec("g", "bfs a")
+		if err != nil || out != "a b c d\n" {
+			t.Errorf("bfs = %q, %v", out, err)
+		}
+		out, err = c.Exec("g", `query edges where size = 2 and contains "d"`)
+		if err != nil || out != "e3: c, d\n" {
+			t.Errorf("query = %q, %v", out, err)
+		}
+	})
+
+	t.Run("cli_commands", func(t *testing.T) {
+		c, path := startTestServer(t)
+		if _, err := c.Exec("g", "add-edge e3 c,d"); err != nil {
+			t.Fatal(err)
+		}
+		out, err := c.Exec("g", "homology")
+		if err != nil || !strings.Contains(out, "Betti (Z/2): 1 0") {
+			t.Errorf("homology = %q, %v", out, err)
+		}
+		out, err = c.Exec("g", "match -pattern g -count")
+		if err != nil || out != "2\n" {
+			t.Errorf("match = %q, %v", out, err)
+		}
+		out, err = c.Exec("g", "copy -o -")
+		if err != nil || !strings.Contains(out, `"e3":["c","d"]`) {
+			t.Errorf("copy = %q, %v", out, err)
+		}
+
+		// Commands cannot reach files, stores or the process.
+		if _, err := c.Exec("g", "copy -o "+path); err == nil || !strings.Contains(err.Error(), "cannot write") {
+			t.Errorf("copy to a file: %v", err)
+		}
+		if _, err := c.Exec("g", "info -f "+path); err == nil || !strings.Contains(err.Error(), "no hypergraph named") {
+			t.Errorf("info from a file: %v", err)
+		}
+		if _, err := c.Exec("g", "stats -db "+t.TempDir()); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+			t.Errorf("stats from a store: %v", err)
+		}
+		if out, err := c.Exec("g", "core -bogus"); err == nil || !strings.Contains(out, "Usage of core") {
+			t.Errorf("bad flag = %q, %v", out, err)
+		}
+		if infos, _ := c.Graphs(); infos[0].Edges != 3 {
+			t.Errorf("edges = %d, want 3", infos[0].Edges)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, _ := startTestServer(t)
+		if _, err := c.Exec("g", "remove-vertex zz"); err == nil || err.Error() != "vertex not found: zz" {
+			t.Errorf("remove-vertex: %v", err)
+		}
+		if _, err := c.Exec("g", ":load /etc/passwd"); err == nil || !strings.Contains(err.Error(), "not available") {
+			t.Errorf(":load: %v", err)
+		}
+		if _, err := c.Exec("nope", "vertices"); err == nil || !strings.Contains(err.Error(), `no hypergraph named "nope"`) {
+			t.Errorf("unknown graph: %v", err)
+		}
+	})
+
+	t.Run("save", func(t *testing.T) {
+		c, path := startTestServer(t)
+		_, _ = c.Exec("g", "add-vertex z")
+		infos, err := c.Graphs()
+		if err != nil || len(infos) != 1 || !infos[0].Modified || infos[0].Vertices != 4 {
+			t.Fatalf("graphs = %+v, %v", infos, err)
+		}
+		if err := c.Save("g"); err != nil {
+			t.Fatal(err)
+		}
+		hg, _ := osEnv.loadGraph(path)
+		if !hg.HasVertex("z") {
+			t.Error("save did not write the change")
+		}
+		if infos, _ := c.Graphs(); infos[0].Modified {
+			t.Error("saved hypergraph still marked modified")
+		}
+	})
+
+	t.Run("vertex_types", func(t *testing.T) {
+		withVertexType(t, "")
+		dir := t.TempDir()
+		ints := filepath.Join(dir, "ints.json")
+		if err := os.WriteFile(ints, []byte(`{"vertex_type":"int","vertices":[1,2,10],"edges":{"e1":[1,2]}}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		words := writeTestGraphFile(t, dir, "words.json")
+		// Whichever file loads first must not fix the vertex type of the
+		// other.
+		s, err := newServer(map[string]string{"i": ints, "w": words})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := httptest.NewServer(s.handler())
+		defer ts.Close()
+		c := hgclient.New(ts.URL)
+		if _, err := c.Exec("i", "add-edge e2 2,10"); err != nil {
+			t.Error(err)
+		}
+		if _, err := c.Exec("w", "add-vertex z"); err != nil {
+			t.Error(err)
+		}
+		if out, err := c.Exec("i", "copy -o -"); err != nil || !strings.Contains(out, `"vertex_type":"int"`) {
+			t.Errorf("copy = %q, %v", out, err)
+		}
+		if out, err := c.Exec("i", "vertices"); err != nil || out != "1\n2\n10\n" {
+			t.Errorf("vertices = %q, %v", out, err)
+		}
+		for _, name := range []string{"i", "w"} {
+			if err := c.Save(name); err != nil {
+				t.Errorf("save %s: %v", name, err)
+			}
+		}
+		if data, _ := os.ReadFile(ints); !strings.Contains(string(data), `"vertex_type":"int"`) {
+			t.Errorf("saved %s", data)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		c, _ := startTestServer(t)
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := c.Exec("g", fmt.Sprintf("add-edge x%d a,v%d", i, i)); err != nil {
+					t.Error(err)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if _, err := c.Exec("g", "components"); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+		if infos, _ := c.Graphs(); infos[0].Edges != 22 {
+			t.Errorf("edges = %d, want 22", infos[0].Edges)
+		}
+	})
+}
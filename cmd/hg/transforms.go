@@ -1,15 +1,14 @@
 package main
 
 import (
-	"encoding/json"
-	"flag"
 	"fmt"
-	"os"
-	"slices"
+	"math"
+
+	"github.com/watchthelight/HypergraphGo/hypergraph"
 )
 
-func cmdDual(args []string) error {
-	fs := flag.NewFlagSet("dual", flag.ExitOnError)
+func cmdDual(env *cmdEnv, args []string) error {
+	fs := env.flagSet("dual")
 	file := fs.String("f", "", "input hypergraph JSON file")
 	output := fs.String("o", "", "output file")
 	if err := fs.Parse(args); err != nil {
@@ -20,25 +19,41 @@ func cmdDual(args []string) error {
 		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
 
+	// The vertices of the dual are edge IDs, which are strings.
 	dual := hg.Dual()
-	return saveGraph(dual, *output)
+	return env.saveGraphAs(dual, hypergraph.VertexTypeString, *output)
 }
 
-// graphJSON represents a simple graph in JSON format.
-type graphJSON struct {
-	Vertices []string   `json:"vertices"`
-	Edges    [][]string `json:"edges"`
+func cmdTwoSection(env *cmdEnv, args []string) error {
+	fs := env.flagSet("two-section")
+	file := fs.String("f", "", "input hypergraph JSON file")
+	output := fs.String("o", "", "output file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || *output == "" {
+		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
+	}
+
+	hg, err := env.loadGraph(*file)
+	if err != nil {
+		return err
+	}
+
+	return env.saveSimpleGraph(hg.TwoSection(), *output)
 }
 
-func cmdTwoSection(args []string) error {
-	fs := flag.NewFlagSet("two-section", flag.ExitOnError)
+func cmdLineGraph(env *cmdEnv, args []string) error {
+	fs := env.flagSet("line-graph")
 	file := fs.String("f", "", "input hypergraph JSON file")
 	output := fs.String("o", "", "output file")
+	workers := fs.Int("workers", 0, "build the line graph on N goroutines")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -47,17 +62,19 @@ func cmdTwoSection(args []string) error {
 		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
 
-	g := hg.TwoSection()
-	return saveSimpleGraphString(g.Vertices(), g.Edges(), *output)
+	if *workers > 0 {
+		return env.saveSimpleGraph(hg.ParallelLineGraph(*workers), *output)
+	}
+	return env.saveSimpleGraph(hg.LineGraph(), *output)
 }
 
-func cmdLineGraph(args []string) error {
-	fs := flag.NewFlagSet("line-graph", flag.ExitOnError)
+func cmdStar(env *cmdEnv, args []string) error {
+	fs := env.flagSet("star")
 	file := fs.String("f", "", "input hypergraph JSON file")
 	output := fs.String("o", "", "output file")
 	if err := fs.Parse(args); err != nil {
@@ -68,58 +85,81 @@ func cmdLineGraph(args []string) error {
 		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
 
-	g := hg.LineGraph()
-	return saveSimpleGraphString(g.Vertices(), g.Edges(), *output)
+	return env.saveSimpleGraph(hg.StarExpansion(), *output)
 }
 
-// saveSimpleGraphString saves a simple graph (with string vertices) to JSON.
-func saveSimpleGraphString(vertices []string, edges []struct{ From, To string }, filename string) (err error) {
-	verts := make([]string, len(vertices))
-	copy(verts, vertices)
-	slices.Sort(verts)
-
-	edgeList := make([][]string, 0, len(edges))
-	for _, e := range edges {
-		pair := []string{e.From, e.To}
-		slices.Sort(pair)
-		edgeList = append(edgeList, pair)
-	}
-	slices.SortFunc(edgeList, func(a, b []string) int {
-		if a[0] < b[0] {
-			return -1
-		}
-		if a[0] > b[0] {
-			return 1
-		}
-		if a[1] < b[1] {
-			return -1
-		}
-		if a[1] > b[1] {
-			return 1
-		}
-		return 0
-	})
+func cmdFromGraph(env *cmdEnv, args []string) error {
+	fs := env.flagSet("from-graph")
+	file := fs.String("f", "", "input graph JSON file")
+	output := fs.String("o", "", "output file")
+	mode := fs.String("mode", "cliques", "reconstruction: cliques or star")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
-	data := graphJSON{
-		Vertices: verts,
-		Edges:    edgeList,
+	if *file == "" || *output == "" {
+		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
 	}
 
-	f, err := os.Create(filename)
+	g, err := env.loadSimpleGraph(*file)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if cerr := f.Close(); cerr != nil && err == nil {
-			err = cerr
+
+	var hg *hypergraph.Hypergraph[string]
+	switch *mode {
+	case "cliques":
+		hg = hypergraph.FromMaximalCliques(g)
+	case "star":
+		hg, err = hypergraph.FromStarExpansion(g)
+		if err != nil {
+			return err
 		}
-	}()
+	default:
+		return fmt.Errorf("unknown mode %q (want cliques or star)", *mode)
+	}
+	// Graph files have string vertices.
+	return env.saveGraphAs(hg, hypergraph.VertexTypeString, *output)
+}
+
+func cmdSlice(env *cmdEnv, args []string) error {
+	fs := env.flagSet("slice")
+	file := fs.String("f", "", "input temporal hypergraph JSON file")
+	output := fs.String("o", "", "output file")
+	from := fs.Int64("from", math.MinInt64, "window start time")
+	to := fs.Int64("to", math.MaxInt64, "window end time")
+	aggregate := fs.String("aggregate", "", "weight edges by activity: count or duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || *output == "" {
+		return fmt.Errorf("missing required flags: -f FILE -o OUTPUT")
+	}
+	if *to < *from {
+		return fmt.Errorf("window end %d is before start %d", *to, *from)
+	}
 
-	enc := json.NewEncoder(f)
-	return enc.Encode(data)
+	th, err := env.loadTemporalGraph(*file)
+	if err != nil {
+		return err
+	}
+
+	var sliced *hypergraph.Hypergraph[string]
+	switch *aggregate {
+	case "":
+		sliced = th.Window(*from, *to)
+	case "count":
+		sliced = th.Aggregate(*from, *to, hypergraph.ActivationCount)
+	case "duration":
+		sliced = th.Aggregate(*from, *to, hypergraph.ActiveDuration)
+	default:
+		return fmt.Errorf("unknown aggregate %q (want count or duration)", *aggregate)
+	}
+	return env.saveGraph(sliced, *output)
 }
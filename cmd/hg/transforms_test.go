@@ -4,16 +4,24 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/watchthelight/HypergraphGo/hypergraph"
 )
 
+// graphJSON is the layout of a simple graph file, for checking what the
+// commands write.
+type graphJSON struct {
+	Vertices []string   `json:"vertices"`
+	Edges    [][]string `json:"edges"`
+}
+
 // TestCmdDual tests the dual command.
 func TestCmdDual(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdDual([]string{})
+		err := cmdDual(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -26,7 +34,7 @@ func TestCmdDual(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "input.json")
 
-		err := cmdDual([]string{"-f", path})
+		err := cmdDual(osEnv, []string{"-f", path})
 		if err == nil {
 			t.Fatal("expected error for missing -o flag")
 		}
@@ -37,13 +45,13 @@ func TestCmdDual(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "dual.json")
 
-		err := cmdDual([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdDual(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdDual failed: %v", err)
 		}
 
 		// Load the dual graph
-		dual, err := loadGraph(outputPath)
+		dual, err := osEnv.loadGraph(outputPath)
 		if err != nil {
 			t.Fatalf("failed to load dual: %v", err)
 		}
@@ -62,12 +70,12 @@ func TestCmdDual(t *testing.T) {
 		doubleDualPath := filepath.Join(dir, "double_dual.json")
 
 		// Compute dual
-		cmdDual([]string{"-f", inputPath, "-o", dualPath})
+		cmdDual(osEnv, []string{"-f", inputPath, "-o", dualPath})
 		// Compute dual of dual
-		cmdDual([]string{"-f", dualPath, "-o", doubleDualPath})
+		cmdDual(osEnv, []string{"-f", dualPath, "-o", doubleDualPath})
 
-		original, _ := loadGraph(inputPath)
-		doubleDual, _ := loadGraph(doubleDualPath)
+		original, _ := osEnv.loadGraph(inputPath)
+		doubleDual, _ := osEnv.loadGraph(doubleDualPath)
 
 		// Dual of dual should have same vertex count as original
 		// (structure may differ but cardinalities should match for simple graphs)
@@ -81,7 +89,7 @@ func TestCmdDual(t *testing.T) {
 		dir := t.TempDir()
 		outputPath := filepath.Join(dir, "output.json")
 
-		err := cmdDual([]string{"-f", "/nonexistent/file.json", "-o", outputPath})
+		err := cmdDual(osEnv, []string{"-f", "/nonexistent/file.json", "-o", outputPath})
 		if err == nil {
 			t.Fatal("expected error for missing input file")
 		}
@@ -91,7 +99,7 @@ func TestCmdDual(t *testing.T) {
 // TestCmdTwoSection tests the two-section command.
 func TestCmdTwoSection(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdTwoSection([]string{})
+		err := cmdTwoSection(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -105,7 +113,7 @@ func TestCmdTwoSection(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "two_section.json")
 
-		err := cmdTwoSection([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdTwoSection(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdTwoSection failed: %v", err)
 		}
@@ -138,7 +146,7 @@ func TestCmdTwoSection(t *testing.T) {
 		dir := t.TempDir()
 		outputPath := filepath.Join(dir, "output.json")
 
-		err := cmdTwoSection([]string{"-f", "/nonexistent/file.json", "-o", outputPath})
+		err := cmdTwoSection(osEnv, []string{"-f", "/nonexistent/file.json", "-o", outputPath})
 		if err == nil {
 			t.Fatal("expected error for missing input file")
 		}
@@ -151,9 +159,9 @@ func TestCmdTwoSection(t *testing.T) {
 
 		// Create empty graph
 		hg := hypergraph.NewHypergraph[string]()
-		saveGraph(hg, inputPath)
+		osEnv.saveGraph(hg, inputPath)
 
-		err := cmdTwoSection([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdTwoSection(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdTwoSection failed on empty graph: %v", err)
 		}
@@ -171,7 +179,7 @@ func TestCmdTwoSection(t *testing.T) {
 // TestCmdLineGraph tests the line-graph command.
 func TestCmdLineGraph(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdLineGraph([]string{})
+		err := cmdLineGraph(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -185,7 +193,7 @@ func TestCmdLineGraph(t *testing.T) {
 		inputPath := writeTestGraphFile(t, dir, "input.json")
 		outputPath := filepath.Join(dir, "line_graph.json")
 
-		err := cmdLineGraph([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdLineGraph failed: %v", err)
 		}
@@ -215,7 +223,7 @@ func TestCmdLineGraph(t *testing.T) {
 		dir := t.TempDir()
 		outputPath := filepath.Join(dir, "output.json")
 
-		err := cmdLineGraph([]string{"-f", "/nonexistent/file.json", "-o", outputPath})
+		err := cmdLineGraph(osEnv, []string{"-f", "/nonexistent/file.json", "-o", outputPath})
 		if err == nil {
 			t.Fatal("expected error for missing input file")
 		}
@@ -234,9 +242,9 @@ func TestCmdLineGraph(t *testing.T) {
 		hg.AddVertex("d")
 		hg.AddEdge("e1", []string{"a", "b"})
 		hg.AddEdge("e2", []string{"c", "d"}) // Disjoint from e1
-		saveGraph(hg, inputPath)
+		osEnv.saveGraph(hg, inputPath)
 
-		err := cmdLineGraph([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdLineGraph failed: %v", err)
 		}
@@ -255,21 +263,28 @@ func TestCmdLineGraph(t *testing.T) {
 	})
 }
 
-// TestSaveSimpleGraphString tests the helper function for saving simple graphs.
-func TestSaveSimpleGraphString(t *testing.T) {
+// TestSaveSimpleGraph tests the helper function for saving simple graphs.
+func TestSaveSimpleGraph(t *testing.T) {
+	build := func(vertices []string, edges [][2]string) *hypergraph.Graph[string] {
+		g := hypergraph.NewGraph[string]()
+		for _, v := range vertices {
+			g.AddVertex(v)
+		}
+		for _, e := range edges {
+			if err := g.AddEdge(e[0], e[1]); err != nil {
+				t.Fatalf("AddEdge failed: %v", err)
+			}
+		}
+		return g
+	}
+
 	t.Run("basic_graph", func(t *testing.T) {
 		dir := t.TempDir()
 		path := filepath.Join(dir, "simple.json")
 
-		vertices := []string{"a", "b", "c"}
-		edges := []struct{ From, To string }{
-			{"a", "b"},
-			{"b", "c"},
-		}
-
-		err := saveSimpleGraphString(vertices, edges, path)
-		if err != nil {
-			t.Fatalf("saveSimpleGraphString failed: %v", err)
+		g := build([]string{"a", "b", "c"}, [][2]string{{"a", "b"}, {"b", "c"}})
+		if err := osEnv.saveSimpleGraph(g, path); err != nil {
+			t.Fatalf("saveSimpleGraph failed: %v", err)
 		}
 
 		data, _ := os.ReadFile(path)
@@ -289,14 +304,11 @@ func TestSaveSimpleGraphString(t *testing.T) {
 		path := filepath.Join(dir, "sorted.json")
 
 		// Provide unsorted input
-		vertices := []string{"c", "a", "b"}
-		edges := []struct{ From, To string }{
-			{"c", "a"},
-			{"b", "a"},
+		g := build([]string{"c", "a", "b"}, [][2]string{{"c", "a"}, {"b", "a"}})
+		if err := osEnv.saveSimpleGraph(g, path); err != nil {
+			t.Fatalf("saveSimpleGraph failed: %v", err)
 		}
 
-		saveSimpleGraphString(vertices, edges, path)
-
 		data, _ := os.ReadFile(path)
 		var result graphJSON
 		json.Unmarshal(data, &result)
@@ -318,9 +330,8 @@ func TestSaveSimpleGraphString(t *testing.T) {
 		dir := t.TempDir()
 		path := filepath.Join(dir, "empty.json")
 
-		err := saveSimpleGraphString(nil, nil, path)
-		if err != nil {
-			t.Fatalf("saveSimpleGraphString failed for empty: %v", err)
+		if err := osEnv.saveSimpleGraph(build(nil, nil), path); err != nil {
+			t.Fatalf("saveSimpleGraph failed for empty: %v", err)
 		}
 
 		data, _ := os.ReadFile(path)
@@ -336,7 +347,7 @@ func TestSaveSimpleGraphString(t *testing.T) {
 	})
 
 	t.Run("invalid_path", func(t *testing.T) {
-		err := saveSimpleGraphString(nil, nil, "/nonexistent/dir/file.json")
+		err := osEnv.saveSimpleGraph(build(nil, nil), "/nonexistent/dir/file.json")
 		if err == nil {
 			t.Fatal("expected error for invalid path")
 		}
@@ -358,16 +369,16 @@ func TestTransforms_LargerGraph(t *testing.T) {
 	hg.AddEdge("e2", []string{"v2", "v3", "v4"})
 	hg.AddEdge("e3", []string{"v4", "v5"})
 	hg.AddEdge("e4", []string{"v1", "v5"})
-	saveGraph(hg, inputPath)
+	osEnv.saveGraph(hg, inputPath)
 
 	t.Run("dual", func(t *testing.T) {
 		outputPath := filepath.Join(dir, "large_dual.json")
-		err := cmdDual([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdDual(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdDual failed: %v", err)
 		}
 
-		dual, _ := loadGraph(outputPath)
+		dual, _ := osEnv.loadGraph(outputPath)
 		if dual.NumVertices() != 4 { // 4 original edges
 			t.Errorf("dual should have 4 vertices, got %d", dual.NumVertices())
 		}
@@ -375,7 +386,7 @@ func TestTransforms_LargerGraph(t *testing.T) {
 
 	t.Run("two_section", func(t *testing.T) {
 		outputPath := filepath.Join(dir, "large_two_section.json")
-		err := cmdTwoSection([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdTwoSection(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdTwoSection failed: %v", err)
 		}
@@ -395,7 +406,7 @@ func TestTransforms_LargerGraph(t *testing.T) {
 
 	t.Run("line_graph", func(t *testing.T) {
 		outputPath := filepath.Join(dir, "large_line_graph.json")
-		err := cmdLineGraph([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdLineGraph failed: %v", err)
 		}
@@ -420,11 +431,11 @@ func TestTransforms_SingletonEdges(t *testing.T) {
 	hg.AddVertex("b")
 	hg.AddEdge("e1", []string{"a"}) // Singleton edge
 	hg.AddEdge("e2", []string{"b"}) // Another singleton
-	saveGraph(hg, inputPath)
+	osEnv.saveGraph(hg, inputPath)
 
 	t.Run("two_section_singleton", func(t *testing.T) {
 		outputPath := filepath.Join(dir, "singleton_two_section.json")
-		err := cmdTwoSection([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdTwoSection(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdTwoSection failed: %v", err)
 		}
@@ -441,7 +452,7 @@ func TestTransforms_SingletonEdges(t *testing.T) {
 
 	t.Run("line_graph_singleton", func(t *testing.T) {
 		outputPath := filepath.Join(dir, "singleton_line_graph.json")
-		err := cmdLineGraph([]string{"-f", inputPath, "-o", outputPath})
+		err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", outputPath})
 		if err != nil {
 			t.Fatalf("cmdLineGraph failed: %v", err)
 		}
@@ -456,3 +467,222 @@ func TestTransforms_SingletonEdges(t *testing.T) {
 		}
 	})
 }
+
+// TestCmdSlice tests the slice command.
+func TestCmdSlice(t *testing.T) {
+	writeTemporal := func(t *testing.T, dir string) string {
+		t.Helper()
+		th := hypergraph.NewTemporalHypergraph[string]()
+		_ = th.AddEdge("early", []string{"a", "b"}, hypergraph.Interval{Start: 1, End: 3})
+		_ = th.AddEdge("late", []string{"b", "c"}, hypergraph.Interval{Start: 10, End: 12}, hypergraph.Interval{Start: 20, End: 20})
+		path := filepath.Join(dir, "temporal.json")
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := th.SaveJSON(f); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("missing_flags", func(t *testing.T) {
+		err := cmdSlice(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Fatalf("expected missing flags error, got %v", err)
+		}
+	})
+
+	t.Run("reversed_window", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTemporal(t, dir)
+		err := cmdSlice(osEnv, []string{"-f", path, "-o", filepath.Join(dir, "out.json"), "-from", "5", "-to", "1"})
+		if err == nil {
+			t.Fatal("expected error for reversed window")
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTemporal(t, dir)
+		out := filepath.Join(dir, "out.json")
+		if err := cmdSlice(osEnv, []string{"-f", path, "-o", out, "-from", "2", "-to", "5"}); err != nil {
+			t.Fatalf("cmdSlice failed: %v", err)
+		}
+		hg, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hg.HasEdge("early") || hg.HasEdge("late") || hg.NumVertices() != 3 {
+			t.Errorf("slice has edges %v and %d vertices", hg.Edges(), hg.NumVertices())
+		}
+	})
+
+	t.Run("aggregate_count", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTemporal(t, dir)
+		out := filepath.Join(dir, "out.json")
+		if err := cmdSlice(osEnv, []string{"-f", path, "-o", out, "-aggregate", "count"}); err != nil {
+			t.Fatalf("cmdSlice failed: %v", err)
+		}
+		hg, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hg.EdgeWeight("late") != 2 || hg.EdgeWeight("early") != 1 {
+			t.Errorf("weights: late=%v early=%v, want 2 and 1", hg.EdgeWeight("late"), hg.EdgeWeight("early"))
+		}
+	})
+
+	t.Run("unknown_aggregate", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writeTemporal(t, dir)
+		err := cmdSlice(osEnv, []string{"-f", path, "-o", filepath.Join(dir, "out.json"), "-aggregate", "max"})
+		if err == nil {
+			t.Fatal("expected error for unknown aggregate")
+		}
+	})
+}
+
+func TestCmdStar(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		err := cmdStar(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Fatalf("expected missing flags error, got %v", err)
+		}
+	})
+
+	t.Run("round_trip", func(t *testing.T) {
+		dir := t.TempDir()
+		inputPath := writeTestGraphFile(t, dir, "input.json")
+		starPath := filepath.Join(dir, "star.json")
+		if err := cmdStar(osEnv, []string{"-f", inputPath, "-o", starPath}); err != nil {
+			t.Fatalf("cmdStar failed: %v", err)
+		}
+
+		data, err := os.ReadFile(starPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result graphJSON
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("failed to parse output JSON: %v", err)
+		}
+		// 3 vertex nodes + 2 edge nodes; e1={a,b}, e2={b,c}.
+		if len(result.Vertices) != 5 || len(result.Edges) != 4 {
+			t.Errorf("star expansion = %v", result)
+		}
+
+		backPath := filepath.Join(dir, "back.json")
+		if err := cmdFromGraph(osEnv, []string{"-f", starPath, "-o", backPath, "-mode", "star"}); err != nil {
+			t.Fatalf("cmdFromGraph failed: %v", err)
+		}
+		hg, err := osEnv.loadGraph(backPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hg.NumVertices() != 3 || hg.NumEdges() != 2 || !hg.HasEdge("e1") {
+			t.Errorf("reconstructed edges %v", hg.Edges())
+		}
+	})
+}
+
+func TestCmdFromGraph(t *testing.T) {
+	t.Run("missing_flags", func(t *testing.T) {
+		err := cmdFromGraph(osEnv, []string{})
+		if err == nil || !strings.Contains(err.Error(), "missing required flags") {
+			t.Fatalf("expected missing flags error, got %v", err)
+		}
+	})
+
+	t.Run("cliques", func(t *testing.T) {
+		dir := t.TempDir()
+		inputPath := filepath.Join(dir, "graph.json")
+		graph := `{"vertices":["a","b","c","d","e"],"edges":[["a","b"],["a","c"],["b","c"],["c","d"]]}`
+		if err := os.WriteFile(inputPath, []byte(graph), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		out := filepath.Join(dir, "out.json")
+		if err := cmdFromGraph(osEnv, []string{"-f", inputPath, "-o", out}); err != nil {
+			t.Fatalf("cmdFromGraph failed: %v", err)
+		}
+		hg, err := osEnv.loadGraph(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[string][]string{"c0": {"a", "b", "c"}, "c1": {"c", "d"}, "c2": {"e"}}
+		if hg.NumEdges() != len(want) {
+			t.Fatalf("got edges %v", hg.Edges())
+		}
+		for id, members := range want {
+			got := hg.EdgeMembers(id)
+			slices.Sort(got)
+			if !slices.Equal(got, members) {
+				t.Errorf("%s = %v, want %v", id, got, members)
+			}
+		}
+	})
+
+	t.Run("star_without_prefixes", func(t *testing.T) {
+		dir := t.TempDir()
+		inputPath := filepath.Join(dir, "graph.json")
+		if err := os.WriteFile(inputPath, []byte(`{"vertices":["a","b"],"edges":[["a","b"]]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		err := cmdFromGraph(osEnv, []string{"-f", inputPath, "-o", filepath.Join(dir, "out.json"), "-mode", "star"})
+		if err == nil {
+			t.Fatal("expected error for unprefixed nodes")
+		}
+	})
+
+	t.Run("unknown_mode", func(t *testing.T) {
+		dir := t.TempDir()
+		inputPath := filepath.Join(dir, "graph.json")
+		_ = os.WriteFile(inputPath, []byte(`{"vertices":[],"edges":[]}`), 0o644)
+		err := cmdFromGraph(osEnv, []string{"-f", inputPath, "-o", filepath.Join(dir, "out.json"), "-mode", "bogus"})
+		if err == nil || !strings.Contains(err.Error(), "unknown mode") {
+			t.Fatalf("expected unknown mode error, got %v", err)
+		}
+	})
+}
+
+func TestCmdTwoSection_Annotations(t *testing.T) {
+	dir := t.TempDir()
+	hg := hypergraph.NewHypergraph[string]()
+	_ = hg.AddOrderedEdge("f", []string{"x", "y"})
+	inputPath := filepath.Join(dir, "input.json")
+	if err := osEnv.saveGraph(hg, inputPath); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.json")
+	if err := cmdTwoSection(osEnv, []string{"-f", inputPath, "-o", out}); err != nil {
+		t.Fatalf("cmdTwoSection failed: %v", err)
+	}
+	g, err := osEnv.loadSimpleGraph(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []hypergraph.EdgeAnnotation{{Edge: "f", FromRole: "0", ToRole: "1"}}
+	if got := g.Annotations("y", "x"); !slices.Equal(got, want) {
+		t.Errorf("annotations = %v, want %v", got, want)
+	}
+}
+
+func TestCmdLineGraph_Workers(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := writeTestGraphFile(t, dir, "input.json")
+	seqPath := filepath.Join(dir, "seq.json")
+	parPath := filepath.Join(dir, "par.json")
+	if err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", seqPath}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmdLineGraph(osEnv, []string{"-f", inputPath, "-o", parPath, "-workers", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	seq, _ := os.ReadFile(seqPath)
+	par, _ := os.ReadFile(parPath)
+	if string(seq) != string(par) {
+		t.Errorf("parallel line graph %s differs from %s", par, seq)
+	}
+}
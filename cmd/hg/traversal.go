@@ -1,16 +1,16 @@
 package main
 
 import (
-	"flag"
 	"fmt"
 	"slices"
 	"strings"
 )
 
-func cmdBFS(args []string) error {
-	fs := flag.NewFlagSet("bfs", flag.ExitOnError)
+func cmdBFS(env *cmdEnv, args []string) error {
+	fs := env.flagSet("bfs")
 	file := fs.String("f", "", "input hypergraph JSON file")
 	start := fs.String("start", "", "starting vertex")
+	workers := fs.Int("workers", 0, "run level-synchronous BFS on N goroutines")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -19,7 +19,7 @@ func cmdBFS(args []string) error {
 		return fmt.Errorf("missing required flags: -f FILE -start VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
@@ -28,13 +28,18 @@ func cmdBFS(args []string) error {
 		return fmt.Errorf("vertex not found: %s", *start)
 	}
 
-	result := hg.BFS(*start)
-	fmt.Println(strings.Join(result, " "))
+	var result []string
+	if *workers > 0 {
+		result = hg.ParallelBFS(*start, *workers)
+	} else {
+		result = hg.BFS(*start)
+	}
+	fmt.Fprintln(env.stdout(), strings.Join(result, " "))
 	return nil
 }
 
-func cmdDFS(args []string) error {
-	fs := flag.NewFlagSet("dfs", flag.ExitOnError)
+func cmdDFS(env *cmdEnv, args []string) error {
+	fs := env.flagSet("dfs")
 	file := fs.String("f", "", "input hypergraph JSON file")
 	start := fs.String("start", "", "starting vertex")
 	if err := fs.Parse(args); err != nil {
@@ -45,7 +50,7 @@ func cmdDFS(args []string) error {
 		return fmt.Errorf("missing required flags: -f FILE -start VERTEX")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
@@ -55,13 +60,14 @@ func cmdDFS(args []string) error {
 	}
 
 	result := hg.DFS(*start)
-	fmt.Println(strings.Join(result, " "))
+	fmt.Fprintln(env.stdout(), strings.Join(result, " "))
 	return nil
 }
 
-func cmdComponents(args []string) error {
-	fs := flag.NewFlagSet("components", flag.ExitOnError)
+func cmdComponents(env *cmdEnv, args []string) error {
+	fs := env.flagSet("components")
 	file := fs.String("f", "", "input hypergraph JSON file")
+	workers := fs.Int("workers", 0, "use parallel union-find on N goroutines")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -70,15 +76,20 @@ func cmdComponents(args []string) error {
 		return fmt.Errorf("missing required flag: -f FILE")
 	}
 
-	hg, err := loadGraph(*file)
+	hg, err := env.loadGraph(*file)
 	if err != nil {
 		return err
 	}
 
-	components := hg.ConnectedComponents()
+	var components [][]string
+	if *workers > 0 {
+		components = hg.ParallelConnectedComponents(*workers)
+	} else {
+		components = hg.ConnectedComponents()
+	}
 	for i, comp := range components {
-		slices.Sort(comp)
-		fmt.Printf("Component %d: %s\n", i+1, strings.Join(comp, ", "))
+		slices.SortFunc(comp, hg.Compare)
+		fmt.Fprintf(env.stdout(), "Component %d: %s\n", i+1, strings.Join(comp, ", "))
 	}
 	return nil
 }
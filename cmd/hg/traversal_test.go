@@ -11,7 +11,7 @@ import (
 // TestCmdBFS tests the bfs command.
 func TestCmdBFS(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdBFS([]string{})
+		err := cmdBFS(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -24,7 +24,7 @@ func TestCmdBFS(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "test.json")
 
-		err := cmdBFS([]string{"-f", path})
+		err := cmdBFS(osEnv, []string{"-f", path})
 		if err == nil {
 			t.Fatal("expected error for missing -start flag")
 		}
@@ -35,7 +35,7 @@ func TestCmdBFS(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdBFS([]string{"-f", path, "-start", "a"})
+			err := cmdBFS(osEnv, []string{"-f", path, "-start", "a"})
 			if err != nil {
 				t.Fatalf("cmdBFS failed: %v", err)
 			}
@@ -61,7 +61,7 @@ func TestCmdBFS(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "test.json")
 
-		err := cmdBFS([]string{"-f", path, "-start", "nonexistent"})
+		err := cmdBFS(osEnv, []string{"-f", path, "-start", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent vertex")
 		}
@@ -71,7 +71,7 @@ func TestCmdBFS(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdBFS([]string{"-f", "/nonexistent/file.json", "-start", "a"})
+		err := cmdBFS(osEnv, []string{"-f", "/nonexistent/file.json", "-start", "a"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -81,7 +81,7 @@ func TestCmdBFS(t *testing.T) {
 // TestCmdDFS tests the dfs command.
 func TestCmdDFS(t *testing.T) {
 	t.Run("missing_flags", func(t *testing.T) {
-		err := cmdDFS([]string{})
+		err := cmdDFS(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -94,7 +94,7 @@ func TestCmdDFS(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "test.json")
 
-		err := cmdDFS([]string{"-f", path})
+		err := cmdDFS(osEnv, []string{"-f", path})
 		if err == nil {
 			t.Fatal("expected error for missing -start flag")
 		}
@@ -105,7 +105,7 @@ func TestCmdDFS(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdDFS([]string{"-f", path, "-start", "a"})
+			err := cmdDFS(osEnv, []string{"-f", path, "-start", "a"})
 			if err != nil {
 				t.Fatalf("cmdDFS failed: %v", err)
 			}
@@ -130,7 +130,7 @@ func TestCmdDFS(t *testing.T) {
 		dir := t.TempDir()
 		path := writeTestGraphFile(t, dir, "test.json")
 
-		err := cmdDFS([]string{"-f", path, "-start", "nonexistent"})
+		err := cmdDFS(osEnv, []string{"-f", path, "-start", "nonexistent"})
 		if err == nil {
 			t.Fatal("expected error for nonexistent vertex")
 		}
@@ -140,7 +140,7 @@ func TestCmdDFS(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdDFS([]string{"-f", "/nonexistent/file.json", "-start", "a"})
+		err := cmdDFS(osEnv, []string{"-f", "/nonexistent/file.json", "-start", "a"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -150,7 +150,7 @@ func TestCmdDFS(t *testing.T) {
 // TestCmdComponents tests the components command.
 func TestCmdComponents(t *testing.T) {
 	t.Run("missing_file_flag", func(t *testing.T) {
-		err := cmdComponents([]string{})
+		err := cmdComponents(osEnv, []string{})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -164,7 +164,7 @@ func TestCmdComponents(t *testing.T) {
 		path := writeTestGraphFile(t, dir, "test.json")
 
 		output := captureStdout(t, func() {
-			err := cmdComponents([]string{"-f", path})
+			err := cmdComponents(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdComponents failed: %v", err)
 			}
@@ -197,10 +197,10 @@ func TestCmdComponents(t *testing.T) {
 		hg.AddVertex("d")
 		hg.AddEdge("e1", []string{"a", "b"}) // Component 1
 		hg.AddEdge("e2", []string{"c", "d"}) // Component 2
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdComponents([]string{"-f", path})
+			err := cmdComponents(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdComponents failed: %v", err)
 			}
@@ -220,7 +220,7 @@ func TestCmdComponents(t *testing.T) {
 	})
 
 	t.Run("missing_input_file", func(t *testing.T) {
-		err := cmdComponents([]string{"-f", "/nonexistent/file.json"})
+		err := cmdComponents(osEnv, []string{"-f", "/nonexistent/file.json"})
 		if err == nil {
 			t.Fatal("expected error for missing file")
 		}
@@ -231,10 +231,10 @@ func TestCmdComponents(t *testing.T) {
 		path := filepath.Join(dir, "empty.json")
 
 		hg := hypergraph.NewHypergraph[string]()
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdComponents([]string{"-f", path})
+			err := cmdComponents(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdComponents failed: %v", err)
 			}
@@ -255,10 +255,10 @@ func TestCmdComponents(t *testing.T) {
 		hg.AddVertex("b")
 		hg.AddVertex("c")
 		// No edges - each vertex is its own component
-		saveGraph(hg, path)
+		osEnv.saveGraph(hg, path)
 
 		output := captureStdout(t, func() {
-			err := cmdComponents([]string{"-f", path})
+			err := cmdComponents(osEnv, []string{"-f", path})
 			if err != nil {
 				t.Fatalf("cmdComponents failed: %v", err)
 			}
@@ -285,11 +285,11 @@ func TestTraversal_LargerGraph(t *testing.T) {
 	hg.AddEdge("e1", []string{"v1", "v2", "v3"})
 	hg.AddEdge("e2", []string{"v3", "v4"})
 	hg.AddEdge("e3", []string{"v4", "v5"})
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	t.Run("bfs_visits_all", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdBFS([]string{"-f", path, "-start", "v1"})
+			cmdBFS(osEnv, []string{"-f", path, "-start", "v1"})
 		})
 
 		parts := strings.Fields(output)
@@ -300,7 +300,7 @@ func TestTraversal_LargerGraph(t *testing.T) {
 
 	t.Run("dfs_visits_all", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdDFS([]string{"-f", path, "-start", "v1"})
+			cmdDFS(osEnv, []string{"-f", path, "-start", "v1"})
 		})
 
 		parts := strings.Fields(output)
@@ -311,7 +311,7 @@ func TestTraversal_LargerGraph(t *testing.T) {
 
 	t.Run("components_single", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdComponents([]string{"-f", path})
+			cmdComponents(osEnv, []string{"-f", path})
 		})
 
 		lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -340,11 +340,11 @@ func TestTraversal_ComplexComponents(t *testing.T) {
 	hg.AddEdge("e1", []string{"a", "b"})
 	hg.AddEdge("e2", []string{"c", "d"})
 	hg.AddEdge("e3", []string{"d", "e"})
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	t.Run("three_components", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdComponents([]string{"-f", path})
+			cmdComponents(osEnv, []string{"-f", path})
 		})
 
 		lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -355,7 +355,7 @@ func TestTraversal_ComplexComponents(t *testing.T) {
 
 	t.Run("bfs_from_isolated", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdBFS([]string{"-f", path, "-start", "f"})
+			cmdBFS(osEnv, []string{"-f", path, "-start", "f"})
 		})
 
 		parts := strings.Fields(output)
@@ -369,7 +369,7 @@ func TestTraversal_ComplexComponents(t *testing.T) {
 
 	t.Run("dfs_from_component", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdDFS([]string{"-f", path, "-start", "c"})
+			cmdDFS(osEnv, []string{"-f", path, "-start", "c"})
 		})
 
 		parts := strings.Fields(output)
@@ -391,12 +391,12 @@ func TestTraversal_HyperedgeConnectivity(t *testing.T) {
 	hg.AddVertex("c")
 	hg.AddVertex("d")
 	hg.AddEdge("big_edge", []string{"a", "b", "c", "d"})
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	for _, start := range []string{"a", "b", "c", "d"} {
 		t.Run("bfs_from_"+start, func(t *testing.T) {
 			output := captureStdout(t, func() {
-				cmdBFS([]string{"-f", path, "-start", start})
+				cmdBFS(osEnv, []string{"-f", path, "-start", start})
 			})
 
 			parts := strings.Fields(output)
@@ -410,7 +410,7 @@ func TestTraversal_HyperedgeConnectivity(t *testing.T) {
 
 		t.Run("dfs_from_"+start, func(t *testing.T) {
 			output := captureStdout(t, func() {
-				cmdDFS([]string{"-f", path, "-start", start})
+				cmdDFS(osEnv, []string{"-f", path, "-start", start})
 			})
 
 			parts := strings.Fields(output)
@@ -431,7 +431,7 @@ func TestTraversal_StartVertexConsistency(t *testing.T) {
 	for _, v := range vertices {
 		t.Run("bfs_starts_with_"+v, func(t *testing.T) {
 			output := captureStdout(t, func() {
-				cmdBFS([]string{"-f", path, "-start", v})
+				cmdBFS(osEnv, []string{"-f", path, "-start", v})
 			})
 
 			parts := strings.Fields(output)
@@ -442,7 +442,7 @@ func TestTraversal_StartVertexConsistency(t *testing.T) {
 
 		t.Run("dfs_starts_with_"+v, func(t *testing.T) {
 			output := captureStdout(t, func() {
-				cmdDFS([]string{"-f", path, "-start", v})
+				cmdDFS(osEnv, []string{"-f", path, "-start", v})
 			})
 
 			parts := strings.Fields(output)
@@ -469,11 +469,11 @@ func TestTraversal_ChainGraph(t *testing.T) {
 	hg.AddEdge("bc", []string{"b", "c"})
 	hg.AddEdge("cd", []string{"c", "d"})
 	hg.AddEdge("de", []string{"d", "e"})
-	saveGraph(hg, path)
+	osEnv.saveGraph(hg, path)
 
 	t.Run("bfs_from_end", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdBFS([]string{"-f", path, "-start", "a"})
+			cmdBFS(osEnv, []string{"-f", path, "-start", "a"})
 		})
 
 		parts := strings.Fields(output)
@@ -484,7 +484,7 @@ func TestTraversal_ChainGraph(t *testing.T) {
 
 	t.Run("dfs_from_middle", func(t *testing.T) {
 		output := captureStdout(t, func() {
-			cmdDFS([]string{"-f", path, "-start", "c"})
+			cmdDFS(osEnv, []string{"-f", path, "-start", "c"})
 		})
 
 		parts := strings.Fields(output)
@@ -496,3 +496,34 @@ func TestTraversal_ChainGraph(t *testing.T) {
 		}
 	})
 }
+
+func TestCmdTraversal_Workers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "graph.json")
+	hg := hypergraph.NewHypergraph[string]()
+	_ = hg.AddEdge("e1", []string{"a", "c"})
+	_ = hg.AddEdge("e2", []string{"a", "b"})
+	_ = hg.AddEdge("e3", []string{"x", "y"})
+	if err := osEnv.saveGraph(hg, path); err != nil {
+		t.Fatal(err)
+	}
+
+	output := captureStdout(t, func() {
+		if err := cmdBFS(osEnv, []string{"-f", path, "-start", "a", "-workers", "4"}); err != nil {
+			t.Fatalf("cmdBFS failed: %v", err)
+		}
+	})
+	if got := strings.TrimSpace(output); got != "a b c" {
+		t.Errorf("parallel bfs = %q, want %q", got, "a b c")
+	}
+
+	output = captureStdout(t, func() {
+		if err := cmdComponents(osEnv, []string{"-f", path, "-workers", "4"}); err != nil {
+			t.Fatalf("cmdComponents failed: %v", err)
+		}
+	})
+	want := "Component 1: a, b, c\nComponent 2: x, y"
+	if got := strings.TrimSpace(output); got != want {
+		t.Errorf("parallel components = %q, want %q", got, want)
+	}
+}
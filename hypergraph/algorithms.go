@@ -1,7 +1,6 @@
 package hypergraph
 
 import (
-	"slices"
 	"time"
 )
 
@@ -21,7 +20,7 @@ func (h *Hypergraph[V]) GreedyHittingSet() []V {
 	}
 	// Sort vertices for deterministic iteration order
 	vertices := h.Vertices()
-	slices.Sort(vertices)
+	h.sortVertices(vertices)
 
 	for len(remainingEdges) > 0 {
 		// Find vertex with max degree in remaining
@@ -29,7 +28,7 @@ func (h *Hypergraph[V]) GreedyHittingSet() []V {
 		maxDeg := -1
 		for _, v := range vertices {
 			deg := 0
-			for e := range h.vertexToEdges[v] {
+			for e := range h.IncidentEdges(v, Unordered) {
 				if _, exists := remainingEdges[e]; exists {
 					deg++
 				}
@@ -43,7 +42,7 @@ func (h *Hypergraph[V]) GreedyHittingSet() []V {
 			break // no vertex found with positive degree
 		}
 		hittingSet = append(hittingSet, bestV)
-		for e := range h.vertexToEdges[bestV] {
+		for e := range h.IncidentEdges(bestV, Unordered) {
 			delete(remainingEdges, e)
 		}
 	}
@@ -66,7 +65,7 @@ func (h *Hypergraph[V]) EnumerateMinimalTransversals(maxSolutions int, maxTime t
 	start := time.Now()
 	var transversals [][]V
 	vertices := h.Vertices()
-	slices.Sort(vertices)
+	h.sortVertices(vertices)
 	var backtrack func(int, []V)
 	backtrack = func(index int, current []V) {
 		if time.Since(start) > maxTime {
@@ -78,7 +77,7 @@ func (h *Hypergraph[V]) EnumerateMinimalTransversals(maxSolutions int, maxTime t
 		// Check if current covers all edges
 		covered := make(map[string]struct{})
 		for _, v := range current {
-			for e := range h.vertexToEdges[v] {
+			for e := range h.IncidentEdges(v, Unordered) {
 				covered[e] = struct{}{}
 			}
 		}
@@ -91,7 +90,7 @@ func (h *Hypergraph[V]) EnumerateMinimalTransversals(maxSolutions int, maxTime t
 				sub = append(sub, current[i+1:]...)
 				subCovered := make(map[string]struct{})
 				for _, u := range sub {
-					for e := range h.vertexToEdges[u] {
+					for e := range h.IncidentEdges(u, Unordered) {
 						subCovered[e] = struct{}{}
 					}
 				}
@@ -127,11 +126,12 @@ func (h *Hypergraph[V]) EnumerateMinimalTransversals(maxSolutions int, maxTime t
 func (h *Hypergraph[V]) GreedyColoring() map[V]int {
 	coloring := make(map[V]int)
 	vertices := h.Vertices()
-	slices.Sort(vertices)
+	h.sortVertices(vertices)
+	used := make(map[int]struct{})
 	for _, v := range vertices {
-		used := make(map[int]struct{})
-		for e := range h.vertexToEdges[v] {
-			for u := range h.edges[e].Set {
+		clear(used)
+		for e := range h.IncidentEdges(v, Unordered) {
+			for u := range h.Members(e, Unordered) {
 				if c, exists := coloring[u]; exists {
 					used[c] = struct{}{}
 				}
@@ -0,0 +1,55 @@
+package hypergraph
+
+// Betweenness returns the betweenness centrality of every vertex: the sum,
+// over unordered pairs of other vertices, of the fraction of shortest paths
+// between them that pass through the vertex. Paths step between vertices
+// sharing an edge, as in the 2-section, and the result is not normalized.
+//
+// It runs Brandes' algorithm from every vertex.
+// Time complexity: O(|V| · (|V| + M)) where M is the number of pairs of
+// vertices sharing an edge.
+func (h *Hypergraph[V]) Betweenness() map[V]float64 {
+	vertices, adj := h.neighborLists()
+	n := len(vertices)
+	score := make([]float64, n)
+	sigma := make([]float64, n)
+	dist := make([]int, n)
+	delta := make([]float64, n)
+	stack := make([]int, 0, n)
+	for s := range n {
+		for i := range n {
+			sigma[i], dist[i], delta[i] = 0, -1, 0
+		}
+		sigma[s], dist[s] = 1, 0
+		stack = append(stack[:0], s)
+		// The stack doubles as the BFS queue: vertices are appended in
+		// order of distance and popped in reverse below.
+		for head := 0; head < len(stack); head++ {
+			v := stack[head]
+			for _, u := range adj[v] {
+				if dist[u] < 0 {
+					dist[u] = dist[v] + 1
+					stack = append(stack, u)
+				}
+				if dist[u] == dist[v]+1 {
+					sigma[u] += sigma[v]
+				}
+			}
+		}
+		for i := len(stack) - 1; i > 0; i-- {
+			w := stack[i]
+			for _, v := range adj[w] {
+				if dist[v] == dist[w]-1 {
+					delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+				}
+			}
+			score[w] += delta[w]
+		}
+	}
+
+	result := make(map[V]float64, n)
+	for i, v := range vertices {
+		result[v] = score[i] / 2 // each pair was counted from both ends
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package hypergraph
+
+import (
+	"maps"
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// Betweenness Tests
+// ============================================================================
+
+func TestBetweenness(t *testing.T) {
+	t.Parallel()
+	// A 3-edge {a,b,c} joined at c to the path c-d-e.
+	h := fromEdges(t, []string{"a", "b", "c"}, []string{"c", "d"}, []string{"d", "e"})
+	h.AddVertex("z")
+	got := h.Betweenness()
+	// c lies on every path from {a,b} to {d,e}: 4 pairs. d lies on the
+	// paths from {a,b,c} to e: 3 pairs.
+	want := map[string]float64{"a": 0, "b": 0, "c": 4, "d": 3, "e": 0, "z": 0}
+	if !maps.EqualFunc(got, want, func(x, y float64) bool { return math.Abs(x-y) < 1e-12 }) {
+		t.Errorf("Betweenness = %v, want %v", got, want)
+	}
+}
+
+func TestBetweenness_SplitPaths(t *testing.T) {
+	t.Parallel()
+	// A 4-cycle a-b-c-d: b and d each carry half of the a-c paths.
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"c", "d"}, []string{"d", "a"})
+	for v, b := range h.Betweenness() {
+		if b != 0.5 {
+			t.Errorf("Betweenness(%s) = %v, want 0.5", v, b)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package hypergraph
+
+import (
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// ModularityVariant selects when a hyperedge counts as inside a community.
+type ModularityVariant int
+
+const (
+	// StrictModularity counts an edge only if all of its members lie in one
+	// community (the "all-or-nothing" affinity of Chodrow et al., the strict
+	// modularity of Kamiński et al.).
+	StrictModularity ModularityVariant = iota
+	// MajorityModularity counts an edge if more than half of its members
+	// lie in one community.
+	MajorityModularity
+)
+
+// Modularity returns the hypergraph modularity of a partition under the
+// degree-corrected null model: vertex v is placed in community C with
+// probability vol(C)/vol(V), where volumes sum weighted vertex degrees.
+// For each edge size d the expected weight of satisfied edges is compared
+// with the observed weight, and the total is normalized by the total edge
+// weight. Vertices missing from partition are treated as singletons.
+//
+// Edge weights come from [Hypergraph.EdgeWeight]. The result is 0 for a
+// hypergraph with no edge weight.
+func (h *Hypergraph[V]) Modularity(partition map[V]int, variant ModularityVariant) float64 {
+	s := newCommunityState(h, variant)
+	labels := make(map[int]int)
+	for i, v := range s.vertices {
+		c, ok := partition[v]
+		if !ok {
+			s.comm[i] = len(s.vertices) + i // beyond any label assigned below
+			continue
+		}
+		if _, seen := labels[c]; !seen {
+			labels[c] = len(labels)
+		}
+		s.comm[i] = labels[c]
+	}
+	s.recount()
+	return s.modularity()
+}
+
+// LouvainOptions configures [Hypergraph.Louvain].
+type LouvainOptions struct {
+	// Seed makes the vertex visiting order, and hence the result,
+	// reproducible.
+	Seed uint64
+	// Variant is the modularity being optimized.
+	Variant ModularityVariant
+	// MaxPasses bounds the number of local-moving passes per level.
+	// Zero means no limit.
+	MaxPasses int
+}
+
+// Louvain greedily maximizes hypergraph modularity and returns the
+// partition (community labels 0, 1, ... numbered in order of each
+// community's smallest vertex) with its modularity.
+//
+// It alternates two phases in the style of Louvain: moving single vertices
+// to the neighboring community with the best modularity gain, then moving
+// whole communities into neighboring communities, until neither phase
+// improves the score. Edge weights come from [Hypergraph.EdgeWeight].
+func (h *Hypergraph[V]) Louvain(opts LouvainOptions) (map[V]int, float64) {
+	s := newCommunityState(h, opts.Variant)
+	for i := range s.comm {
+		s.comm[i] = i
+	}
+	s.recount()
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+
+	for {
+		movedVertex := s.localMoving(rng, opts.MaxPasses)
+		movedCommunity := s.mergeCommunities(rng)
+		if !movedVertex && !movedCommunity {
+			break
+		}
+	}
+
+	// Relabel in order of smallest member for deterministic output.
+	relabel := make(map[int]int)
+	partition := make(map[V]int, len(s.vertices))
+	for i, v := range s.vertices {
+		c, ok := relabel[s.comm[i]]
+		if !ok {
+			c = len(relabel)
+			relabel[s.comm[i]] = c
+		}
+		partition[v] = c
+	}
+	return partition, s.modularity()
+}
+
+// communityState holds an index-based view of the hypergraph together with
+// the per-community and per-edge counts needed for incremental modularity.
+type communityState[V comparable] struct {
+	variant   ModularityVariant
+	vertices  []V
+	incident  [][]int // vertex -> edge indices
+	members   [][]int // edge -> vertex indices
+	weight    []float64
+	degree    []float64 // weighted vertex degree
+	volTotal  float64
+	wTotal    float64
+	sizes     []int     // distinct edge sizes, ascending
+	sizeWt    []float64 // total weight of edges of each size in sizes
+	comm      []int
+	vol       map[int]float64
+	edgeCount []map[int]int // edge -> community -> members in it
+}
+
+func newCommunityState[V comparable](h *Hypergraph[V], variant ModularityVariant) *communityState[V] {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	edges := slices.Sorted(maps.Keys(h.edges))
+	s := &communityState[V]{
+		variant:  variant,
+		vertices: vertices,
+		incident: make([][]int, len(vertices)),
+		members:  make([][]int, len(edges)),
+		weight:   make([]float64, len(edges)),
+		degree:   make([]float64, len(vertices)),
+		comm:     make([]int, len(vertices)),
+	}
+	for e, id := range edges {
+		w := h.EdgeWeight(id)
+		s.weight[e] = w
+		s.wTotal += w
+		for v := range h.Members(id, Sorted) {
+			i := index[v]
+			s.members[e] = append(s.members[e], i)
+			s.incident[i] = append(s.incident[i], e)
+			s.degree[i] += w
+			s.volTotal += w
+		}
+	}
+	for e := range s.members {
+		d := len(s.members[e])
+		i, found := slices.BinarySearch(s.sizes, d)
+		if !found {
+			s.sizes = slices.Insert(s.sizes, i, d)
+			s.sizeWt = slices.Insert(s.sizeWt, i, 0)
+		}
+		s.sizeWt[i] += s.weight[e]
+	}
+	return s
+}
+
+// recount rebuilds community volumes and per-edge counts from comm.
+func (s *communityState[V]) recount() {
+	s.vol = make(map[int]float64)
+	for i, c := range s.comm {
+		s.vol[c] += s.degree[i]
+	}
+	s.edgeCount = make([]map[int]int, len(s.members))
+	for e, ms := range s.members {
+		s.edgeCount[e] = make(map[int]int)
+		for _, i := range ms {
+			s.edgeCount[e][s.comm[i]]++
+		}
+	}
+}
+
+// satisfied reports whether count members of an edge of the given size in
+// one community make the edge count as internal.
+func (s *communityState[V]) satisfied(count, size int) bool {
+	if s.variant == MajorityModularity {
+		return 2*count > size
+	}
+	return count == size
+}
+
+// expected returns the probability that an edge of size d is satisfied by a
+// community holding fraction p of the total volume.
+func (s *communityState[V]) expected(p float64, d int) float64 {
+	if s.variant != MajorityModularity {
+		return math.Pow(p, float64(d))
+	}
+	total := 0.0
+	for c := d/2 + 1; c <= d; c++ {
+		total += binomial(d, c) * math.Pow(p, float64(c)) * math.Pow(1-p, float64(d-c))
+	}
+	return total
+}
+
+// expectedTerm returns the null-model weight of satisfied edges contributed
+// by a community with the given volume.
+func (s *communityState[V]) expectedTerm(vol float64) float64 {
+	if s.volTotal == 0 || vol == 0 {
+		return 0
+	}
+	p := vol / s.volTotal
+	total := 0.0
+	for i, d := range s.sizes {
+		total += s.sizeWt[i] * s.expected(p, d)
+	}
+	return total
+}
+
+func (s *communityState[V]) edgeSatisfied(e int) bool {
+	for _, n := range s.edgeCount[e] {
+		if s.satisfied(n, len(s.members[e])) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *communityState[V]) modularity() float64 {
+	if s.wTotal == 0 {
+		return 0
+	}
+	observed := 0.0
+	for e := range s.members {
+		if s.edgeSatisfied(e) {
+			observed += s.weight[e]
+		}
+	}
+	expected := 0.0
+	for _, c := range slices.Sorted(maps.Keys(s.vol)) {
+		expected += s.expectedTerm(s.vol[c])
+	}
+	return (observed - expected) / s.wTotal
+}
+
+// edgeHit records that count members of a moving vertex set lie in edge.
+type edgeHit struct{ edge, count int }
+
+// edgeHits lists the edges met by a vertex set in ascending edge order, so
+// that floating-point sums over them do not depend on map iteration order.
+func (s *communityState[V]) edgeHits(set []int) []edgeHit {
+	counts := make(map[int]int)
+	for _, i := range set {
+		for _, e := range s.incident[i] {
+			counts[e]++
+		}
+	}
+	hits := make([]edgeHit, 0, len(counts))
+	for _, e := range slices.Sorted(maps.Keys(counts)) {
+		hits = append(hits, edgeHit{e, counts[e]})
+	}
+	return hits
+}
+
+// moveDelta returns the modularity change from moving the vertices in set,
+// all currently in community from, into community to. touched lists the
+// edges meeting set.
+func (s *communityState[V]) moveDelta(setVol float64, touched []edgeHit, from, to int) float64 {
+	if from == to || s.wTotal == 0 {
+		return 0
+	}
+	observed := 0.0
+	for _, hit := range touched {
+		e, k := hit.edge, hit.count
+		size := len(s.members[e])
+		before := s.edgeSatisfied(e)
+		after := false
+		for c, n := range s.edgeCount[e] {
+			switch c {
+			case from:
+				n -= k
+			case to:
+				n += k
+			}
+			if s.satisfied(n, size) {
+				after = true
+				break
+			}
+		}
+		if _, ok := s.edgeCount[e][to]; !ok && s.satisfied(k, size) {
+			after = true
+		}
+		if before != after {
+			if after {
+				observed += s.weight[e]
+			} else {
+				observed -= s.weight[e]
+			}
+		}
+	}
+	expected := s.expectedTerm(s.vol[from]-setVol) + s.expectedTerm(s.vol[to]+setVol) -
+		s.expectedTerm(s.vol[from]) - s.expectedTerm(s.vol[to])
+	return (observed - expected) / s.wTotal
+}
+
+// move reassigns the vertices in set from community from to community to.
+func (s *communityState[V]) move(set []int, setVol float64, touched []edgeHit, from, to int) {
+	for _, i := range set {
+		s.comm[i] = to
+	}
+	s.vol[from] -= setVol
+	s.vol[to] += setVol
+	if s.vol[from] == 0 {
+		delete(s.vol, from)
+	}
+	for _, hit := range touched {
+		e, k := hit.edge, hit.count
+		s.edgeCount[e][from] -= k
+		if s.edgeCount[e][from] == 0 {
+			delete(s.edgeCount[e], from)
+		}
+		s.edgeCount[e][to] += k
+	}
+}
+
+// bestTarget returns the neighboring community with the largest positive
+// modularity gain for moving set out of from, breaking ties by label.
+func (s *communityState[V]) bestTarget(setVol float64, touched []edgeHit, from int) (int, bool) {
+	var candidates []int
+	for _, hit := range touched {
+		for c := range s.edgeCount[hit.edge] {
+			if c != from {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	slices.Sort(candidates)
+	candidates = slices.Compact(candidates)
+	best, bestGain := from, 1e-12
+	for _, c := range candidates {
+		if gain := s.moveDelta(setVol, touched, from, c); gain > bestGain {
+			best, bestGain = c, gain
+		}
+	}
+	return best, best != from
+}
+
+// localMoving moves single vertices until a pass makes no move or
+// maxPasses passes have run. It reports whether any vertex moved.
+func (s *communityState[V]) localMoving(rng *rand.Rand, maxPasses int) bool {
+	moved := false
+	order := rng.Perm(len(s.vertices))
+	for pass := 0; maxPasses <= 0 || pass < maxPasses; pass++ {
+		improved := false
+		for _, i := range order {
+			touched := s.edgeHits([]int{i})
+			from := s.comm[i]
+			if to, ok := s.bestTarget(s.degree[i], touched, from); ok {
+				s.move([]int{i}, s.degree[i], touched, from, to)
+				improved, moved = true, true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return moved
+}
+
+// mergeCommunities moves whole communities into neighboring ones while
+// that improves modularity. It reports whether any community moved.
+func (s *communityState[V]) mergeCommunities(rng *rand.Rand) bool {
+	groups := make(map[int][]int)
+	for i, c := range s.comm {
+		groups[c] = append(groups[c], i)
+	}
+	labels := slices.Sorted(maps.Keys(groups))
+	rng.Shuffle(len(labels), func(a, b int) { labels[a], labels[b] = labels[b], labels[a] })
+
+	moved := false
+	for _, from := range labels {
+		set := groups[from]
+		if len(set) == 0 {
+			continue
+		}
+		touched := s.edgeHits(set)
+		setVol := 0.0
+		for _, i := range set {
+			setVol += s.degree[i]
+		}
+		if to, ok := s.bestTarget(setVol, touched, from); ok {
+			s.move(set, setVol, touched, from, to)
+			groups[to] = append(groups[to], set...)
+			groups[from] = nil
+			moved = true
+		}
+	}
+	return moved
+}
+
+// binomial returns n choose k as a float64.
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
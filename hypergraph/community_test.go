@@ -0,0 +1,163 @@
+package hypergraph
+
+import (
+	"maps"
+	"math"
+	"testing"
+)
+
+// twoCommunities returns two dense groups {A, B, C} and {D, E, F} of
+// pairwise and triple edges joined by a single bridge edge {C, D}.
+func twoCommunities() *Hypergraph[string] {
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("ab", []string{"A", "B"})
+	_ = h.AddEdge("bc", []string{"B", "C"})
+	_ = h.AddEdge("ac", []string{"A", "C"})
+	_ = h.AddEdge("abc", []string{"A", "B", "C"})
+	_ = h.AddEdge("de", []string{"D", "E"})
+	_ = h.AddEdge("ef", []string{"E", "F"})
+	_ = h.AddEdge("df", []string{"D", "F"})
+	_ = h.AddEdge("def", []string{"D", "E", "F"})
+	_ = h.AddEdge("bridge", []string{"C", "D"})
+	return h
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// ============================================================================
+// Modularity Tests
+// ============================================================================
+
+func TestModularity_DisjointTriangles(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"D", "E", "F"})
+
+	split := map[string]int{"A": 0, "B": 0, "C": 0, "D": 1, "E": 1, "F": 1}
+	// Observed 2; expected 2 * (0.5^3 + 0.5^3) = 0.5; normalized by 2.
+	if got := h.Modularity(split, StrictModularity); !approxEqual(got, 0.75) {
+		t.Errorf("strict modularity = %v, want 0.75", got)
+	}
+
+	// Singletons: nothing observed; expected 2 * 6 * (1/6)^3.
+	if got := h.Modularity(nil, StrictModularity); !approxEqual(got, -1.0/36) {
+		t.Errorf("singleton modularity = %v, want %v", got, -1.0/36)
+	}
+
+	all := map[string]int{"A": 7, "B": 7, "C": 7, "D": 7, "E": 7, "F": 7}
+	for _, variant := range []ModularityVariant{StrictModularity, MajorityModularity} {
+		if got := h.Modularity(all, variant); !approxEqual(got, 0) {
+			t.Errorf("variant %d: single community modularity = %v, want 0", variant, got)
+		}
+	}
+}
+
+func TestModularity_MajorityCountsPartialEdges(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	partition := map[string]int{"A": 0, "B": 0, "C": 1}
+
+	// Strict: not satisfied. Expected: (2/3)^3 + (1/3)^3 = 1/3.
+	if got := h.Modularity(partition, StrictModularity); !approxEqual(got, -1.0/3) {
+		t.Errorf("strict = %v, want %v", got, -1.0/3)
+	}
+	// Majority: satisfied. Expected: P(Bin(3, 2/3) >= 2) + P(Bin(3, 1/3) >= 2) = 20/27 + 7/27 = 1.
+	if got := h.Modularity(partition, MajorityModularity); !approxEqual(got, 0) {
+		t.Errorf("majority = %v, want 0", got)
+	}
+}
+
+func TestModularity_Weighted(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+	partition := map[string]int{"A": 0, "B": 0, "C": 1, "D": 1}
+
+	unweighted := h.Modularity(partition, StrictModularity)
+	_ = h.SetEdgeWeight("E1", 3)
+	// Volumes 6 and 2 of 8: expected 4 * ((3/4)^2 + (1/4)^2) = 2.5; observed 4.
+	if got := h.Modularity(partition, StrictModularity); !approxEqual(got, 1.5/4) {
+		t.Errorf("weighted modularity = %v, want %v", got, 1.5/4)
+	}
+	if approxEqual(unweighted, h.Modularity(partition, StrictModularity)) {
+		t.Error("edge weight should change modularity")
+	}
+}
+
+func TestModularity_Empty(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	h.AddVertex(1)
+	if got := h.Modularity(map[int]int{1: 0}, StrictModularity); got != 0 {
+		t.Errorf("modularity without edges = %v, want 0", got)
+	}
+}
+
+// ============================================================================
+// Louvain Tests
+// ============================================================================
+
+func TestLouvain_TwoCommunities(t *testing.T) {
+	t.Parallel()
+	h := twoCommunities()
+	for _, variant := range []ModularityVariant{StrictModularity, MajorityModularity} {
+		partition, q := h.Louvain(LouvainOptions{Seed: 1, Variant: variant})
+		if partition["A"] != partition["B"] || partition["B"] != partition["C"] {
+			t.Errorf("variant %d: A, B, C should share a community: %v", variant, partition)
+		}
+		if partition["D"] != partition["E"] || partition["E"] != partition["F"] {
+			t.Errorf("variant %d: D, E, F should share a community: %v", variant, partition)
+		}
+		if partition["A"] == partition["D"] {
+			t.Errorf("variant %d: groups should be separate: %v", variant, partition)
+		}
+		if !approxEqual(q, h.Modularity(partition, variant)) {
+			t.Errorf("variant %d: reported modularity %v differs from Modularity %v", variant, q, h.Modularity(partition, variant))
+		}
+		if q <= 0 {
+			t.Errorf("variant %d: modularity = %v, want positive", variant, q)
+		}
+	}
+}
+
+func TestLouvain_LabelsAndDeterminism(t *testing.T) {
+	t.Parallel()
+	h := twoCommunities()
+	first, q1 := h.Louvain(LouvainOptions{Seed: 42})
+	for range 5 {
+		again, q2 := h.Louvain(LouvainOptions{Seed: 42})
+		if !maps.Equal(first, again) || q1 != q2 {
+			t.Fatalf("same seed gave %v (%v) then %v (%v)", first, q1, again, q2)
+		}
+	}
+	// Labels are numbered by smallest member: A's community is 0.
+	if first["A"] != 0 || first["D"] != 1 {
+		t.Errorf("labels = %v, want A in 0 and D in 1", first)
+	}
+}
+
+func TestLouvain_HeavyBridge(t *testing.T) {
+	t.Parallel()
+	h := twoCommunities()
+	_ = h.SetEdgeWeight("bridge", 50)
+	partition, _ := h.Louvain(LouvainOptions{Seed: 3})
+	if partition["C"] != partition["D"] {
+		t.Errorf("a heavy bridge should pull C and D together: %v", partition)
+	}
+}
+
+func TestLouvain_NoEdges(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	h.AddVertex(1)
+	h.AddVertex(2)
+	partition, q := h.Louvain(LouvainOptions{})
+	if len(partition) != 2 || partition[1] == partition[2] || q != 0 {
+		t.Errorf("isolated vertices should stay singletons with Q=0, got %v, %v", partition, q)
+	}
+}
@@ -0,0 +1,540 @@
+package hypergraph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// ContagionState is the state of a vertex in a spreading process.
+type ContagionState uint8
+
+const (
+	// StateSusceptible vertices can be infected.
+	StateSusceptible ContagionState = iota
+	// StateInfected vertices spread the process. In the threshold and
+	// cascade models they are the active vertices.
+	StateInfected
+	// StateRecovered vertices are immune (SIR only).
+	StateRecovered
+)
+
+// String returns the name of the state.
+func (s ContagionState) String() string {
+	switch s {
+	case StateSusceptible:
+		return "susceptible"
+	case StateInfected:
+		return "infected"
+	case StateRecovered:
+		return "recovered"
+	}
+	return fmt.Sprintf("ContagionState(%d)", uint8(s))
+}
+
+// ContagionModel selects what becomes of an infected vertex that recovers.
+type ContagionModel int
+
+const (
+	// ModelSIS returns recovered vertices to susceptible.
+	ModelSIS ContagionModel = iota
+	// ModelSIR makes recovered vertices permanently immune.
+	ModelSIR
+)
+
+// StateCounts tallies the vertices in each state.
+type StateCounts struct {
+	Susceptible, Infected, Recovered int
+}
+
+// Trajectory records one simulation run step by step.
+type Trajectory[V comparable] struct {
+	// Counts[t] tallies the states after step t; Counts[0] is the initial
+	// state.
+	Counts []StateCounts
+	// Infections[t] lists the vertices infected at step t, ascending;
+	// Infections[0] lists the initially infected vertices.
+	Infections [][]V
+	// Final is the state of every vertex after the last step.
+	Final map[V]ContagionState
+}
+
+// ContagionOptions configures [Hypergraph.SimulateContagion].
+type ContagionOptions[V comparable] struct {
+	// Model is ModelSIS or ModelSIR.
+	Model ContagionModel
+	// Beta is the infection rate: a susceptible vertex under infection
+	// pressure p is infected in a step with probability 1 - exp(-Beta·p).
+	// It must not be negative.
+	Beta float64
+	// Mu is the probability, in [0, 1], that an infected vertex recovers
+	// in a step.
+	Mu float64
+	// EdgeThreshold returns how many infected members edge id, which has
+	// size members, needs before it transmits. Nil means 1, so that any
+	// edge with an infected member transmits.
+	EdgeThreshold func(id string, size int) int
+	// Infectivity returns the pressure that an edge of the given size with
+	// infected members at or above its threshold puts on each of its
+	// susceptible members, before weighting by the edge weight. Nil means
+	// linear: infected. A power such as infected² gives nonlinear
+	// contagion, and a constant gives a pure threshold response.
+	Infectivity func(infected, size int) float64
+	// Initial lists the initially infected vertices.
+	Initial []V
+	// InitialFraction, when Initial is empty, infects this fraction of the
+	// vertices, chosen at random, at the start. It must be in [0, 1].
+	InitialFraction float64
+	// Steps bounds the number of steps. The run also ends once no vertex
+	// is infected. Zero means no bound, which requires the SIR model with
+	// a positive Mu.
+	Steps int
+	// Seed makes the run reproducible.
+	Seed uint64
+}
+
+// SimulateContagion runs a discrete-time SIS or SIR process in which
+// groups, not pairs, transmit: an edge puts pressure on its susceptible
+// members only while at least its threshold of members is infected. In
+// each step every susceptible vertex is infected with probability
+// 1 - exp(-Beta·p), where p sums weight × infectivity over its
+// transmitting edges, and every vertex infected at the start of the step
+// recovers with probability Mu. All updates in a step are simultaneous.
+//
+// Returns an error if the model is unknown, a rate, probability or the
+// step count is out of range, an initial vertex is not in h, or Steps is
+// zero for a process that need not die out: SIS, or SIR without recovery.
+// Time complexity: O(steps · Σ|e|).
+func (h *Hypergraph[V]) SimulateContagion(opts ContagionOptions[V]) (Trajectory[V], error) {
+	switch {
+	case opts.Model != ModelSIS && opts.Model != ModelSIR:
+		return Trajectory[V]{}, fmt.Errorf("contagion: unknown model %d", opts.Model)
+	case !(opts.Beta >= 0):
+		return Trajectory[V]{}, fmt.Errorf("contagion: negative infection rate %g", opts.Beta)
+	case !isProbability(opts.Mu):
+		return Trajectory[V]{}, fmt.Errorf("contagion: recovery probability %g is outside [0, 1]", opts.Mu)
+	}
+	if err := checkRun(opts.Steps, opts.InitialFraction); err != nil {
+		return Trajectory[V]{}, err
+	}
+	if opts.Steps == 0 && (opts.Model == ModelSIS || opts.Mu == 0) {
+		return Trajectory[V]{}, errors.New("contagion: a process that need not die out needs a positive step bound")
+	}
+	s := newSpreadIndex(h)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x636f6e746167696f))
+	if err := s.infectInitial(opts.Initial, opts.InitialFraction, rng); err != nil {
+		return Trajectory[V]{}, err
+	}
+	threshold := s.thresholds(opts.EdgeThreshold)
+	infectivity := opts.Infectivity
+	if infectivity == nil {
+		infectivity = func(infected, _ int) float64 { return float64(infected) }
+	}
+
+	pressure := make([]float64, len(s.vertices))
+	var infected, recovered []int
+	for step := 1; (opts.Steps == 0 || step <= opts.Steps) && s.counts.Infected > 0; step++ {
+		clear(pressure)
+		for e, members := range s.members {
+			if s.active[e] < threshold[e] || s.active[e] == 0 {
+				continue
+			}
+			p := s.weight[e] * infectivity(s.active[e], len(members))
+			for _, v := range members {
+				pressure[v] += p
+			}
+		}
+		infected, recovered = infected[:0], recovered[:0]
+		for v, state := range s.state {
+			switch {
+			case state == StateSusceptible && pressure[v] > 0:
+				if rng.Float64() < -math.Expm1(-opts.Beta*pressure[v]) {
+					infected = append(infected, v)
+				}
+			case state == StateInfected && opts.Mu > 0:
+				if rng.Float64() < opts.Mu {
+					recovered = append(recovered, v)
+				}
+			}
+		}
+		next := StateSusceptible
+		if opts.Model == ModelSIR {
+			next = StateRecovered
+		}
+		for _, v := range recovered {
+			s.set(v, next)
+		}
+		for _, v := range infected {
+			s.set(v, StateInfected)
+		}
+		s.record(infected)
+	}
+	return s.trajectory(), nil
+}
+
+// ThresholdOptions configures [Hypergraph.SimulateThreshold].
+type ThresholdOptions[V comparable] struct {
+	// VertexThreshold returns the activation threshold of vertex v, in
+	// [0, 1]. Nil draws each threshold uniformly at random.
+	VertexThreshold func(v V) float64
+	// EdgeThreshold returns how many active members edge id, which has
+	// size members, needs before it influences the others. Nil means 1.
+	EdgeThreshold func(id string, size int) int
+	// Initial lists the initially active vertices.
+	Initial []V
+	// InitialFraction, when Initial is empty, activates this fraction of
+	// the vertices, chosen at random, at the start. It must be in [0, 1].
+	InitialFraction float64
+	// Steps bounds the number of steps. Zero means until no vertex
+	// activates.
+	Steps int
+	// Seed makes the random thresholds and initial vertices reproducible.
+	Seed uint64
+}
+
+// SimulateThreshold runs the linear threshold model on h. Edge e at or
+// above its threshold influences each inactive member by w(e)·a/(|e|-1),
+// where a is the number of active members; a vertex activates once the
+// influence of its edges, divided by its weighted degree, reaches its
+// threshold. Active vertices stay active, so the run ends when a step
+// activates nobody. Active vertices are reported as [StateInfected].
+//
+// Returns an error if Steps is negative, InitialFraction is outside
+// [0, 1] or an initial vertex is not in h.
+// Time complexity: O(steps · Σ|e|).
+func (h *Hypergraph[V]) SimulateThreshold(opts ThresholdOptions[V]) (Trajectory[V], error) {
+	if err := checkRun(opts.Steps, opts.InitialFraction); err != nil {
+		return Trajectory[V]{}, err
+	}
+	s := newSpreadIndex(h)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x7468726573686f6c))
+	theta := make([]float64, len(s.vertices))
+	for v, x := range s.vertices {
+		if opts.VertexThreshold != nil {
+			theta[v] = opts.VertexThreshold(x)
+		} else {
+			theta[v] = rng.Float64()
+		}
+	}
+	if err := s.infectInitial(opts.Initial, opts.InitialFraction, rng); err != nil {
+		return Trajectory[V]{}, err
+	}
+	threshold := s.thresholds(opts.EdgeThreshold)
+	degree := make([]float64, len(s.vertices))
+	for e, members := range s.members {
+		for _, v := range members {
+			degree[v] += s.weight[e]
+		}
+	}
+
+	influence := make([]float64, len(s.vertices))
+	var activated []int
+	for step := 1; opts.Steps == 0 || step <= opts.Steps; step++ {
+		clear(influence)
+		for e, members := range s.members {
+			if s.active[e] < threshold[e] || s.active[e] == 0 || len(members) < 2 {
+				continue
+			}
+			x := s.weight[e] * float64(s.active[e]) / float64(len(members)-1)
+			for _, v := range members {
+				influence[v] += x
+			}
+		}
+		activated = activated[:0]
+		for v, state := range s.state {
+			if state == StateSusceptible && degree[v] > 0 && influence[v] > 0 && influence[v]/degree[v] >= theta[v] {
+				activated = append(activated, v)
+			}
+		}
+		if len(activated) == 0 {
+			break
+		}
+		for _, v := range activated {
+			s.set(v, StateInfected)
+		}
+		s.record(activated)
+	}
+	return s.trajectory(), nil
+}
+
+// CascadeOptions configures [Hypergraph.SimulateCascade].
+type CascadeOptions[V comparable] struct {
+	// Probability is the chance, in [0, 1], that an edge fires for each
+	// newly active member.
+	Probability float64
+	// EdgeProbability, if set, overrides Probability for edge id, which
+	// has size members. It must return a value in [0, 1].
+	EdgeProbability func(id string, size int) float64
+	// EdgeThreshold returns how many active members edge id needs before
+	// it can fire. Nil means 1.
+	EdgeThreshold func(id string, size int) int
+	// Initial lists the initially active vertices.
+	Initial []V
+	// InitialFraction, when Initial is empty, activates this fraction of
+	// the vertices, chosen at random, at the start. It must be in [0, 1].
+	InitialFraction float64
+	// Steps bounds the number of steps. Zero means until no vertex
+	// activates.
+	Steps int
+	// Seed makes the run reproducible.
+	Seed uint64
+}
+
+// SimulateCascade runs an independent cascade in which edges, not pairs,
+// transmit: each vertex activated in a step gives every edge it belongs to
+// one chance, in the next step, to fire with the edge's probability, and
+// a firing edge activates all of its inactive members. An edge below its
+// threshold of active members does not fire. Active vertices are reported
+// as [StateInfected].
+//
+// Returns an error if a probability, the step count or InitialFraction is
+// out of range, or if an initial vertex is not in h.
+// Time complexity: O(Σ|e|) per run, plus O(|V|) per step.
+func (h *Hypergraph[V]) SimulateCascade(opts CascadeOptions[V]) (Trajectory[V], error) {
+	if opts.EdgeProbability == nil && !isProbability(opts.Probability) {
+		return Trajectory[V]{}, fmt.Errorf("contagion: probability %g is outside [0, 1]", opts.Probability)
+	}
+	if err := checkRun(opts.Steps, opts.InitialFraction); err != nil {
+		return Trajectory[V]{}, err
+	}
+	s := newSpreadIndex(h)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x63617363616465))
+	if err := s.infectInitial(opts.Initial, opts.InitialFraction, rng); err != nil {
+		return Trajectory[V]{}, err
+	}
+	threshold := s.thresholds(opts.EdgeThreshold)
+	prob := make([]float64, len(s.edges))
+	for e, id := range s.edges {
+		prob[e] = opts.Probability
+		if opts.EdgeProbability != nil {
+			prob[e] = opts.EdgeProbability(id, len(s.members[e]))
+			if !isProbability(prob[e]) {
+				return Trajectory[V]{}, fmt.Errorf("contagion: probability %g of edge %s is outside [0, 1]", prob[e], id)
+			}
+		}
+	}
+
+	fresh := make([]int, len(s.edges)) // newly active members per edge
+	newly := slices.Clone(s.infections[0])
+	var fired, activated []int
+	for step := 1; (opts.Steps == 0 || step <= opts.Steps) && len(newly) > 0; step++ {
+		for _, v := range newly {
+			for _, e := range s.incident[v] {
+				fresh[e]++
+			}
+		}
+		// Decide every edge against the active counts at the start of the
+		// step, visiting edges in index order so the draws do not depend on
+		// the order of newly.
+		fired = fired[:0]
+		for e, k := range fresh {
+			if k == 0 {
+				continue
+			}
+			fresh[e] = 0
+			if s.active[e] >= threshold[e] && rng.Float64() < -math.Expm1(float64(k)*math.Log1p(-prob[e])) {
+				fired = append(fired, e)
+			}
+		}
+		activated = activated[:0]
+		for _, e := range fired {
+			for _, v := range s.members[e] {
+				if s.state[v] == StateSusceptible {
+					s.set(v, StateInfected)
+					activated = append(activated, v)
+				}
+			}
+		}
+		if len(activated) == 0 {
+			break
+		}
+		slices.Sort(activated)
+		s.record(activated)
+		newly = append(newly[:0], activated...)
+	}
+	return s.trajectory(), nil
+}
+
+// isProbability reports whether p is in [0, 1]. NaN is not.
+func isProbability(p float64) bool { return p >= 0 && p <= 1 }
+
+// checkRun checks the options shared by every simulation.
+func checkRun(steps int, fraction float64) error {
+	if steps < 0 {
+		return fmt.Errorf("contagion: negative step count %d", steps)
+	}
+	if !isProbability(fraction) {
+		return fmt.Errorf("contagion: initial fraction %g is outside [0, 1]", fraction)
+	}
+	return nil
+}
+
+// MonteCarlo calls run once per seed seed, seed+1, ..., seed+runs-1 on up
+// to workers goroutines (workers <= 0 means runtime.GOMAXPROCS(0)) and
+// returns the results in seed order, so that they do not depend on the
+// worker count. run must be safe to call concurrently; the simulations
+// above are, as long as nothing modifies the hypergraph. If any run fails,
+// the error of the first failing seed is returned.
+func MonteCarlo[T any](runs, workers int, seed uint64, run func(seed uint64) (T, error)) ([]T, error) {
+	results := make([]T, runs)
+	errs := make([]error, runs)
+	parallelChunks(runs, workers, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			results[i], errs[i] = run(seed + uint64(i))
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// spreadIndex is an index-based view of a hypergraph together with the
+// vertex states of a spreading process and its recorded trajectory.
+type spreadIndex[V comparable] struct {
+	vertices []V
+	index    map[V]int
+	edges    []string
+	incident [][]int // vertex -> edge indices
+	members  [][]int // edge -> vertex indices
+	weight   []float64
+
+	state      []ContagionState
+	active     []int // infected members per edge
+	counts     StateCounts
+	history    []StateCounts
+	infections [][]int
+}
+
+func newSpreadIndex[V comparable](h *Hypergraph[V]) *spreadIndex[V] {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	edges := slices.Sorted(maps.Keys(h.edges))
+	s := &spreadIndex[V]{
+		vertices: vertices,
+		index:    index,
+		edges:    edges,
+		incident: make([][]int, len(vertices)),
+		members:  make([][]int, len(edges)),
+		weight:   make([]float64, len(edges)),
+		state:    make([]ContagionState, len(vertices)),
+		active:   make([]int, len(edges)),
+		counts:   StateCounts{Susceptible: len(vertices)},
+	}
+	for e, id := range edges {
+		s.weight[e] = h.EdgeWeight(id)
+		for v := range h.Members(id, Unordered) {
+			s.members[e] = append(s.members[e], index[v])
+			s.incident[index[v]] = append(s.incident[index[v]], e)
+		}
+		slices.Sort(s.members[e])
+	}
+	for v := range s.incident {
+		slices.Sort(s.incident[v])
+	}
+	return s
+}
+
+// thresholds evaluates an edge threshold function for every edge, with nil
+// meaning 1.
+func (s *spreadIndex[V]) thresholds(f func(id string, size int) int) []int {
+	threshold := make([]int, len(s.edges))
+	for e, id := range s.edges {
+		threshold[e] = 1
+		if f != nil {
+			threshold[e] = f(id, len(s.members[e]))
+		}
+	}
+	return threshold
+}
+
+// infectInitial infects the initial vertices, or a random fraction of all
+// vertices if there are none, and records step 0.
+func (s *spreadIndex[V]) infectInitial(initial []V, fraction float64, rng *rand.Rand) error {
+	var seeds []int
+	for _, v := range initial {
+		i, ok := s.index[v]
+		if !ok {
+			return fmt.Errorf("contagion: vertex not found: %v", v)
+		}
+		seeds = append(seeds, i)
+	}
+	if len(initial) == 0 && fraction > 0 {
+		n := int(math.Round(fraction * float64(len(s.vertices))))
+		seeds = rng.Perm(len(s.vertices))[:n]
+	}
+	var infected []int
+	for _, v := range seeds {
+		if s.state[v] != StateInfected {
+			s.set(v, StateInfected)
+			infected = append(infected, v)
+		}
+	}
+	slices.Sort(infected)
+	s.record(infected)
+	return nil
+}
+
+// set moves vertex v to state, keeping the counts current.
+func (s *spreadIndex[V]) set(v int, state ContagionState) {
+	old := s.state[v]
+	if old == state {
+		return
+	}
+	s.state[v] = state
+	*s.count(old)--
+	*s.count(state)++
+	if old == StateInfected {
+		for _, e := range s.incident[v] {
+			s.active[e]--
+		}
+	}
+	if state == StateInfected {
+		for _, e := range s.incident[v] {
+			s.active[e]++
+		}
+	}
+}
+
+func (s *spreadIndex[V]) count(state ContagionState) *int {
+	switch state {
+	case StateInfected:
+		return &s.counts.Infected
+	case StateRecovered:
+		return &s.counts.Recovered
+	}
+	return &s.counts.Susceptible
+}
+
+// record ends a step in which the given vertices, ascending, were
+// infected.
+func (s *spreadIndex[V]) record(infected []int) {
+	s.history = append(s.history, s.counts)
+	s.infections = append(s.infections, slices.Clone(infected))
+}
+
+func (s *spreadIndex[V]) trajectory() Trajectory[V] {
+	t := Trajectory[V]{
+		Counts:     s.history,
+		Infections: make([][]V, len(s.infections)),
+		Final:      make(map[V]ContagionState, len(s.vertices)),
+	}
+	for step, infected := range s.infections {
+		t.Infections[step] = make([]V, len(infected))
+		for i, v := range infected {
+			t.Infections[step][i] = s.vertices[v]
+		}
+	}
+	for v, x := range s.vertices {
+		t.Final[x] = s.state[v]
+	}
+	return t
+}
@@ -0,0 +1,207 @@
+package hypergraph
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// ============================================================================
+// SimulateContagion Tests
+// ============================================================================
+
+func TestSimulateContagion_Threshold(t *testing.T) {
+	t.Parallel()
+	// With certain transmission and no recovery, only edges with two
+	// infected members spread: {a,b,c} does, but {c,d,e} never reaches two.
+	h := fromEdges(t, []string{"a", "b", "c"}, []string{"c", "d", "e"}, []string{"a", "x"})
+	got, err := h.SimulateContagion(ContagionOptions[string]{
+		Model:         ModelSIS,
+		Beta:          100,
+		EdgeThreshold: func(_ string, _ int) int { return 2 },
+		Initial:       []string{"a", "b"},
+		Steps:         5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"a", "b"}, {"c"}, nil, nil, nil, nil}
+	if len(got.Infections) != len(want) {
+		t.Fatalf("Infections = %v", got.Infections)
+	}
+	for i := range want {
+		if !slices.Equal(got.Infections[i], want[i]) {
+			t.Errorf("step %d infections = %v, want %v", i, got.Infections[i], want[i])
+		}
+	}
+	if got.Final["d"] != StateSusceptible || got.Counts[5] != (StateCounts{Susceptible: 3, Infected: 3}) {
+		t.Errorf("final = %v, counts = %v", got.Final, got.Counts[5])
+	}
+}
+
+func TestSimulateContagion_SIR(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(1, 2))
+	h := randomWeightedHypergraph(rng, 60, 120)
+	opts := ContagionOptions[int]{
+		Model:       ModelSIR,
+		Beta:        0.3,
+		Mu:          0.2,
+		Infectivity: func(infected, _ int) float64 { return float64(infected * infected) },
+		Initial:     []int{0, 1},
+		Seed:        9,
+	}
+	a, err := h.SimulateContagion(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := h.SimulateContagion(opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("runs with the same seed differ")
+	}
+	last := a.Counts[len(a.Counts)-1]
+	if last.Infected != 0 || last.Recovered < 2 {
+		t.Errorf("SIR should die out with recovered vertices, got %v", last)
+	}
+	for step, c := range a.Counts {
+		if c.Susceptible+c.Infected+c.Recovered != 60 {
+			t.Errorf("step %d counts %v do not sum to 60", step, c)
+		}
+		if step > 0 && c.Susceptible > a.Counts[step-1].Susceptible {
+			t.Errorf("SIR susceptible count grew at step %d", step)
+		}
+	}
+}
+
+func TestSimulateContagion_Errors(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"a", "b"})
+	if _, err := h.SimulateContagion(ContagionOptions[string]{Model: ModelSIS, Initial: []string{"a"}}); err == nil {
+		t.Error("expected error for unbounded SIS")
+	}
+	if _, err := h.SimulateContagion(ContagionOptions[string]{Model: ModelSIR, Mu: 0.5, Initial: []string{"z"}}); err == nil {
+		t.Error("expected error for unknown initial vertex")
+	}
+
+	bad := map[string]ContagionOptions[string]{
+		"unknown model":        {Model: 7, Steps: 1},
+		"negative Beta":        {Model: ModelSIR, Beta: -1, Mu: 0.5},
+		"NaN Beta":             {Model: ModelSIR, Beta: math.NaN(), Mu: 0.5},
+		"Mu above 1":           {Model: ModelSIR, Beta: 1, Mu: 1.5},
+		"negative Mu":          {Model: ModelSIS, Beta: 1, Mu: -0.1, Steps: 1},
+		"negative Steps":       {Model: ModelSIS, Beta: 1, Mu: 0.5, Steps: -1},
+		"InitialFraction of 2": {Model: ModelSIR, Beta: 1, Mu: 0.5, InitialFraction: 2},
+	}
+	for name, opts := range bad {
+		if _, err := h.SimulateContagion(opts); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+	if _, err := h.SimulateThreshold(ThresholdOptions[string]{Steps: -1}); err == nil {
+		t.Error("expected error for negative threshold Steps")
+	}
+	for _, p := range []float64{-0.5, 1.5, math.NaN()} {
+		if _, err := h.SimulateCascade(CascadeOptions[string]{Probability: p, Initial: []string{"a"}}); err == nil {
+			t.Errorf("expected error for cascade probability %g", p)
+		}
+		edgeP := func(string, int) float64 { return p }
+		if _, err := h.SimulateCascade(CascadeOptions[string]{EdgeProbability: edgeP, Initial: []string{"a"}}); err == nil {
+			t.Errorf("expected error for edge probability %g", p)
+		}
+	}
+}
+
+// ============================================================================
+// SimulateThreshold and SimulateCascade Tests
+// ============================================================================
+
+func TestSimulateThreshold(t *testing.T) {
+	t.Parallel()
+	// b and c get half their influence, and d all of it, from the edge to
+	// their predecessor, so activation moves one step along the path.
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"c", "d"})
+	thresholds := map[string]float64{"b": 0.5, "c": 0.5, "d": 0.9}
+	got, err := h.SimulateThreshold(ThresholdOptions[string]{
+		VertexThreshold: func(v string) float64 { return thresholds[v] },
+		Initial:         []string{"a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"a"}, {"b"}, {"c"}, {"d"}}
+	if !slices.EqualFunc(got.Infections, want, slices.Equal) {
+		t.Errorf("Infections = %v, want %v", got.Infections, want)
+	}
+
+	thresholds["d"] = 1.5
+	got, _ = h.SimulateThreshold(ThresholdOptions[string]{
+		VertexThreshold: func(v string) float64 { return thresholds[v] },
+		Initial:         []string{"a"},
+	})
+	if got.Final["d"] != StateSusceptible || len(got.Counts) != 3 {
+		t.Errorf("d should stay inactive after two steps, got %v", got.Infections)
+	}
+}
+
+func TestSimulateCascade(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"a", "b", "c"}, []string{"c", "d"}, []string{"d", "e", "f"})
+	got, err := h.SimulateCascade(CascadeOptions[string]{
+		EdgeProbability: func(id string, _ int) float64 {
+			if id == "e2" {
+				return 0
+			}
+			return 1
+		},
+		Initial: []string{"a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// e0 and e1 always fire, e2 never does.
+	want := [][]string{{"a"}, {"b", "c"}, {"d"}}
+	if !slices.EqualFunc(got.Infections, want, slices.Equal) {
+		t.Errorf("Infections = %v, want %v", got.Infections, want)
+	}
+
+	gated, _ := h.SimulateCascade(CascadeOptions[string]{
+		Probability:   1,
+		EdgeThreshold: func(_ string, size int) int { return size - 1 },
+		Initial:       []string{"a"},
+	})
+	if gated.Counts[len(gated.Counts)-1].Infected != 1 {
+		t.Errorf("threshold should block {a,b,c}: %v", gated.Infections)
+	}
+}
+
+func TestMonteCarlo(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(3, 3))
+	h := randomWeightedHypergraph(rng, 40, 60)
+	run := func(seed uint64) (Trajectory[int], error) {
+		return h.SimulateCascade(CascadeOptions[int]{Probability: 0.3, InitialFraction: 0.05, Seed: seed})
+	}
+	serial, err := MonteCarlo(16, 1, 100, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parallel, _ := MonteCarlo(16, 4, 100, run)
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Error("results depend on the worker count")
+	}
+	if first, _ := run(100); !reflect.DeepEqual(first, serial[0]) {
+		t.Error("run 0 should use the base seed")
+	}
+	if len(serial[0].Infections[0]) != 2 {
+		t.Errorf("InitialFraction 0.05 of 40 should infect 2, got %v", serial[0].Infections[0])
+	}
+
+	_, err = MonteCarlo(4, 2, 0, func(seed uint64) (Trajectory[int], error) {
+		return h.SimulateCascade(CascadeOptions[int]{Initial: []int{-int(seed)}})
+	})
+	if err == nil {
+		t.Error("expected the error of a failing run")
+	}
+}
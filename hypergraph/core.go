@@ -0,0 +1,106 @@
+package hypergraph
+
+import (
+	"maps"
+)
+
+// Coreness returns the core number of every vertex: the largest k such that
+// the vertex belongs to the k-core (see [Hypergraph.KCore]).
+//
+// It peels vertices in order of their current degree with a bucket queue.
+// Removing a vertex removes every edge containing it, which lowers the
+// degree of the edge's other members.
+// Time complexity: O(|V| + P) where P is the number of edge memberships.
+func (h *Hypergraph[V]) Coreness() map[V]int {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	degree := make([]int, len(vertices))
+	maxDeg := 0
+	for i, v := range vertices {
+		degree[i] = h.VertexDegree(v)
+		maxDeg = max(maxDeg, degree[i])
+	}
+	// Buckets may hold stale entries; an entry is live when the vertex is
+	// unremoved and its degree still matches the bucket.
+	buckets := make([][]int, maxDeg+1)
+	for i := len(vertices) - 1; i >= 0; i-- {
+		buckets[degree[i]] = append(buckets[degree[i]], i)
+	}
+	removed := make([]bool, len(vertices))
+	deadEdge := make(map[string]bool)
+	core := make(map[V]int, len(vertices))
+	k := 0
+	for d := 0; d <= maxDeg; {
+		if len(buckets[d]) == 0 {
+			d++
+			continue
+		}
+		i := buckets[d][len(buckets[d])-1]
+		buckets[d] = buckets[d][:len(buckets[d])-1]
+		if removed[i] || degree[i] != d {
+			continue
+		}
+		k = max(k, d)
+		core[vertices[i]] = k
+		removed[i] = true
+		for e := range h.IncidentEdges(vertices[i], Unordered) {
+			if deadEdge[e] {
+				continue
+			}
+			deadEdge[e] = true
+			for u := range h.Members(e, Unordered) {
+				j := index[u]
+				if removed[j] {
+					continue
+				}
+				degree[j]--
+				buckets[degree[j]] = append(buckets[degree[j]], j)
+				d = min(d, degree[j])
+			}
+		}
+	}
+	return core
+}
+
+// Degeneracy returns the largest core number of any vertex, or 0 for an
+// empty hypergraph.
+func (h *Hypergraph[V]) Degeneracy() int {
+	k := 0
+	for _, c := range h.Coreness() {
+		k = max(k, c)
+	}
+	return k
+}
+
+// KCore returns the k-core of h: the largest induced subhypergraph in which
+// every vertex belongs to at least k edges. Edges are kept only when all
+// of their members are in the core, so the result is
+// h.InducedSubhypergraph of the vertices with coreness at least k.
+func (h *Hypergraph[V]) KCore(k int) *Hypergraph[V] {
+	var keep []V
+	for v, c := range h.Coreness() {
+		if c >= k {
+			keep = append(keep, v)
+		}
+	}
+	return h.InducedSubhypergraph(keep)
+}
+
+// KLCore returns the (k,l)-core of h: the k-core (see [Hypergraph.KCore])
+// of h without its edges of fewer than l members. Every vertex of the
+// result belongs to at least k of its edges and every edge has at least l
+// members. Edges are kept whole, as in the k-core, so the (k,1)-core is
+// the k-core. Edge IDs, weights and roles are preserved.
+// Time complexity: O(|V| + |E| + P) where P is the number of edge memberships.
+func (h *Hypergraph[V]) KLCore(k, l int) *Hypergraph[V] {
+	g := h.Copy()
+	for id := range h.edges {
+		if size, _ := h.EdgeSize(id); size < l {
+			g.RemoveEdge(id)
+		}
+	}
+	return g.KCore(k)
+}
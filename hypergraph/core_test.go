@@ -0,0 +1,153 @@
+package hypergraph
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// naiveKCore repeatedly deletes a vertex of degree below k from the induced
+// subhypergraph until none is left.
+func naiveKCore(h *Hypergraph[int], k int) []int {
+	keep := h.Vertices()
+	for {
+		sub := h.InducedSubhypergraph(keep)
+		next := slices.DeleteFunc(slices.Clone(keep), func(v int) bool { return sub.VertexDegree(v) < k })
+		if len(next) == len(keep) {
+			slices.Sort(keep)
+			return keep
+		}
+		keep = next
+	}
+}
+
+// ============================================================================
+// Coreness Tests
+// ============================================================================
+
+func TestCoreness_Simple(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	// A, B, C each lie in three edges among themselves; D hangs off one
+	// edge; E is isolated.
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"A", "B"})
+	_ = h.AddEdge("E3", []string{"B", "C"})
+	_ = h.AddEdge("E4", []string{"A", "C"})
+	_ = h.AddEdge("E5", []string{"C", "D"})
+	h.AddVertex("E")
+
+	want := map[string]int{"A": 3, "B": 3, "C": 3, "D": 1, "E": 0}
+	if got := h.Coreness(); !maps.Equal(got, want) {
+		t.Errorf("Coreness = %v, want %v", got, want)
+	}
+	if got := h.Degeneracy(); got != 3 {
+		t.Errorf("Degeneracy = %d, want 3", got)
+	}
+
+	core := h.KCore(2)
+	if got := core.Vertices(); len(got) != 3 || core.HasVertex("D") {
+		t.Errorf("2-core vertices = %v, want A B C", got)
+	}
+	if core.HasEdge("E5") || core.NumEdges() != 4 {
+		t.Errorf("2-core edges = %v, want E1..E4", core.Edges())
+	}
+	if h.KCore(4).NumVertices() != 0 {
+		t.Error("4-core should be empty")
+	}
+}
+
+func TestCoreness_Empty(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	if len(h.Coreness()) != 0 || h.Degeneracy() != 0 {
+		t.Error("empty hypergraph should have no coreness and degeneracy 0")
+	}
+}
+
+func TestCoreness_MatchesNaive(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(5, 3))
+	for trial := range 30 {
+		h := randomWeightedHypergraph(rng, 12, 5+rng.IntN(25))
+		core := h.Coreness()
+		for k := 0; k <= h.Degeneracy()+1; k++ {
+			var want []int
+			for v, c := range core {
+				if c >= k {
+					want = append(want, v)
+				}
+			}
+			slices.Sort(want)
+			if got := naiveKCore(h, k); !slices.Equal(got, want) {
+				t.Fatalf("trial %d k=%d: naive core %v, coreness gives %v", trial, k, got, want)
+			}
+		}
+	}
+}
+
+// ============================================================================
+// KLCore Tests
+// ============================================================================
+
+func TestKLCore(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	_ = h.AddEdge("E2", []string{"A", "B", "C"})
+	_ = h.AddEdge("E3", []string{"A", "C"})
+	_ = h.AddEdge("E4", []string{"B", "C"})
+	_ = h.AddEdge("E5", []string{"D"})
+	_ = h.AddEdge("E6", []string{"D", "A"})
+	_ = h.SetEdgeWeight("E2", 2)
+
+	// In the 2-core D keeps degree 2. At l = 2 the singleton E5 goes, so
+	// D has degree 1 and leaves with E6.
+	core := h.KLCore(2, 2)
+	if got := core.Vertices(); !slices.Equal(sortedCopy(got), []string{"A", "B", "C"}) {
+		t.Errorf("(2,2)-core vertices = %v, want [A B C]", got)
+	}
+	if !slices.Equal(sortedCopy(core.Edges()), []string{"E1", "E2", "E3", "E4"}) {
+		t.Errorf("(2,2)-core edges = %v, want [E1 E2 E3 E4]", core.Edges())
+	}
+	if core.EdgeWeight("E2") != 2 {
+		t.Errorf("weight of E2 = %v, want 2", core.EdgeWeight("E2"))
+	}
+	if d := Diff(h.KCore(2), h.KLCore(2, 1)); !d.IsEmpty() || h.KCore(2).NumVertices() != 4 {
+		t.Errorf("(2,1)-core differs from the 2-core: %+v", d)
+	}
+	// Only E2 has three members, and alone it gives no vertex degree 2.
+	if got := h.KLCore(2, 3); got.NumVertices() != 0 || got.NumEdges() != 0 {
+		t.Errorf("(2,3)-core should be empty, got %v", got.Vertices())
+	}
+}
+
+func TestKLCore_Invariants(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(8, 13))
+	for trial := range 30 {
+		h := randomWeightedHypergraph(rng, 12, 5+rng.IntN(25))
+		k, l := 1+rng.IntN(3), 1+rng.IntN(3)
+		core := h.KLCore(k, l)
+		for _, v := range core.Vertices() {
+			if core.VertexDegree(v) < k {
+				t.Fatalf("trial %d: vertex %d has degree %d < %d", trial, v, core.VertexDegree(v), k)
+			}
+		}
+		for _, id := range core.Edges() {
+			if size, _ := core.EdgeSize(id); size < l || size != len(h.EdgeMembers(id)) {
+				t.Fatalf("trial %d: edge %s has size %d, want at least %d and whole", trial, id, size, l)
+			}
+		}
+		if l == 1 && !Diff(h.KCore(k), core).IsEmpty() {
+			t.Fatalf("trial %d: (%d,1)-core differs from the %d-core", trial, k, k)
+		}
+	}
+}
+
+func sortedCopy(s []string) []string {
+	out := slices.Clone(s)
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,388 @@
+package hypergraph
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+)
+
+// EdgeCover is a set of hyperedges that together contain every vertex.
+type EdgeCover struct {
+	// Edges are the IDs of the chosen edges, sorted.
+	Edges []string
+	// Weight is the total weight of the chosen edges.
+	Weight float64
+}
+
+// coverInstance is an index-based weighted set cover instance: every
+// element must be covered by at least one chosen set. For edge covers the
+// sets are hyperedges and the elements are vertices; for transversals it
+// is the other way round. Sets and elements are numbered in sorted order.
+type coverInstance struct {
+	ids    []string  // edge IDs when the sets are hyperedges
+	weight []float64 // set -> weight
+	sets   [][]int   // set -> elements
+	covers [][]int   // element -> sets containing it
+}
+
+func newCoverInstance[V comparable](h *Hypergraph[V]) (*coverInstance, error) {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	c := &coverInstance{
+		ids:    slices.Sorted(maps.Keys(h.edges)),
+		covers: make([][]int, len(vertices)),
+	}
+	c.weight = make([]float64, len(c.ids))
+	c.sets = make([][]int, len(c.ids))
+	for e, id := range c.ids {
+		c.weight[e] = h.EdgeWeight(id)
+		for v := range h.Members(id, Unordered) {
+			c.sets[e] = append(c.sets[e], index[v])
+			c.covers[index[v]] = append(c.covers[index[v]], e)
+		}
+	}
+	for i, v := range vertices {
+		if len(c.covers[i]) == 0 {
+			return nil, fmt.Errorf("%w: vertex %v is in no edge", ErrInfeasible, v)
+		}
+	}
+	return c, nil
+}
+
+// newTransversalInstance returns the unit-weight instance whose sets are
+// the sorted vertices of h and whose elements are its edges, so that a
+// cover is a transversal. It also returns the vertex order.
+func newTransversalInstance[V comparable](h *Hypergraph[V]) (*coverInstance, []V) {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	edges := slices.Sorted(maps.Keys(h.edges))
+	c := &coverInstance{
+		weight: make([]float64, len(vertices)),
+		sets:   make([][]int, len(vertices)),
+		covers: make([][]int, len(edges)),
+	}
+	for i := range vertices {
+		c.weight[i] = 1
+	}
+	for e, id := range edges {
+		for v := range h.Members(id, Sorted) {
+			c.sets[index[v]] = append(c.sets[index[v]], e)
+			c.covers[e] = append(c.covers[e], index[v])
+		}
+	}
+	return c, vertices
+}
+
+func (c *coverInstance) result(chosen []int) EdgeCover {
+	var cover EdgeCover
+	for _, e := range chosen {
+		cover.Edges = append(cover.Edges, c.ids[e])
+		cover.Weight += c.weight[e]
+	}
+	slices.Sort(cover.Edges)
+	return cover
+}
+
+// prune drops chosen sets, heaviest first, whose elements are all covered
+// by the remaining sets.
+func (c *coverInstance) prune(chosen []int) []int {
+	count := make([]int, len(c.covers))
+	for _, e := range chosen {
+		for _, v := range c.sets[e] {
+			count[v]++
+		}
+	}
+	order := slices.Clone(chosen)
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(c.weight[b], c.weight[a]) })
+	drop := make(map[int]bool)
+	for _, e := range order {
+		redundant := true
+		for _, v := range c.sets[e] {
+			if count[v] < 2 {
+				redundant = false
+				break
+			}
+		}
+		if redundant {
+			drop[e] = true
+			for _, v := range c.sets[e] {
+				count[v]--
+			}
+		}
+	}
+	return slices.DeleteFunc(chosen, func(e int) bool { return drop[e] })
+}
+
+// greedy repeatedly picks the set with the lowest weight per newly covered
+// element, breaking ties by index.
+func (c *coverInstance) greedy() []int {
+	covered := make([]bool, len(c.covers))
+	remaining := len(c.covers)
+	var chosen []int
+	for remaining > 0 {
+		best, bestRatio := -1, math.Inf(1)
+		for e := range c.sets {
+			gain := 0
+			for _, v := range c.sets[e] {
+				if !covered[v] {
+					gain++
+				}
+			}
+			if gain == 0 {
+				continue
+			}
+			if ratio := c.weight[e] / float64(gain); ratio < bestRatio {
+				best, bestRatio = e, ratio
+			}
+		}
+		chosen = append(chosen, best)
+		for _, v := range c.sets[best] {
+			if !covered[v] {
+				covered[v] = true
+				remaining--
+			}
+		}
+	}
+	return chosen
+}
+
+// GreedyEdgeCover computes a weighted edge cover with the greedy set cover
+// algorithm: it repeatedly picks the edge with the lowest weight per newly
+// covered vertex, breaking ties by edge ID.
+//
+// The result is within a factor H(k) <= 1 + ln k of optimal, where k is the
+// largest edge size. Returns an error wrapping [ErrInfeasible] if some
+// vertex belongs to no edge.
+// Time complexity: O(|E| * P) where P is the number of edge memberships.
+func (h *Hypergraph[V]) GreedyEdgeCover() (EdgeCover, error) {
+	c, err := newCoverInstance(h)
+	if err != nil {
+		return EdgeCover{}, err
+	}
+	return c.result(c.greedy()), nil
+}
+
+// LPRoundingEdgeCover computes a weighted edge cover by solving the linear
+// programming relaxation and rounding: every edge whose fractional value
+// is at least 1/f is chosen, where f is the largest vertex degree.
+// Redundant edges are then dropped, heaviest first.
+//
+// The result is within a factor f of optimal. The relaxation is solved
+// through its dual with a dense simplex method, which needs
+// O(|V| * |E|) memory. Returns an error wrapping [ErrInfeasible] if some
+// vertex belongs to no edge.
+func (h *Hypergraph[V]) LPRoundingEdgeCover() (EdgeCover, error) {
+	c, err := newCoverInstance(h)
+	if err != nil {
+		return EdgeCover{}, err
+	}
+	f := 0
+	for _, es := range c.covers {
+		f = max(f, len(es))
+	}
+	x := c.solveRelaxation()
+	var chosen []int
+	for e, xe := range x {
+		if xe >= 1/float64(f)-lpEpsilon {
+			chosen = append(chosen, e)
+		}
+	}
+	return c.result(c.prune(chosen)), nil
+}
+
+// lpEpsilon is the tolerance used by the simplex solver.
+const lpEpsilon = 1e-9
+
+// solveRelaxation returns an optimal solution x of
+//
+//	min w·x  subject to  Σ_{e ∋ v} x_e >= 1 for every vertex v,  x >= 0.
+//
+// It solves the dual packing problem
+//
+//	max Σ y_v  subject to  Σ_{v ∈ e} y_v <= w_e for every edge e,  y >= 0
+//
+// with the tableau simplex method and Bland's rule. The origin is feasible
+// because weights are non-negative, so no first phase is needed, and x is
+// read from the reduced costs of the slack variables.
+func (c *coverInstance) solveRelaxation() []float64 {
+	rows, cols := len(c.ids), len(c.covers)
+	// Tableau columns: y_0..y_{n-1}, slack_0..slack_{m-1}, rhs.
+	width := cols + rows + 1
+	t := make([][]float64, rows+1)
+	for r := range t {
+		t[r] = make([]float64, width)
+	}
+	basis := make([]int, rows)
+	for e := range rows {
+		for _, v := range c.sets[e] {
+			t[e][v] = 1
+		}
+		t[e][cols+e] = 1
+		t[e][width-1] = c.weight[e]
+		basis[e] = cols + e
+	}
+	obj := t[rows]
+	for v := range cols {
+		obj[v] = -1
+	}
+
+	for {
+		// Bland's rule: the lowest-index column with negative reduced cost.
+		enter := -1
+		for j := range width - 1 {
+			if obj[j] < -lpEpsilon {
+				enter = j
+				break
+			}
+		}
+		if enter < 0 {
+			break
+		}
+		leave := -1
+		bestRatio := math.Inf(1)
+		for r := range rows {
+			if t[r][enter] > lpEpsilon {
+				ratio := t[r][width-1] / t[r][enter]
+				if ratio < bestRatio-lpEpsilon || (ratio < bestRatio+lpEpsilon && leave >= 0 && basis[r] < basis[leave]) {
+					leave, bestRatio = r, ratio
+				}
+			}
+		}
+		if leave < 0 {
+			break // unbounded; cannot happen since every y_v appears in some row
+		}
+		pivot := t[leave][enter]
+		for j := range t[leave] {
+			t[leave][j] /= pivot
+		}
+		for r := range t {
+			if r == leave || t[r][enter] == 0 {
+				continue
+			}
+			factor := t[r][enter]
+			for j := range t[r] {
+				t[r][j] -= factor * t[leave][j]
+			}
+		}
+		basis[leave] = enter
+	}
+
+	x := make([]float64, rows)
+	for e := range rows {
+		x[e] = max(0, obj[cols+e])
+	}
+	return x
+}
+
+// ExactEdgeCover computes a minimum-weight edge cover by branch and bound,
+// seeded with the greedy cover.
+//
+// If maxTime elapses first, the best cover found so far is returned with
+// [ErrCutoff]. Returns an error wrapping [ErrInfeasible] if some vertex
+// belongs to no edge. Time complexity: exponential in the worst case
+// (the problem is NP-hard).
+func (h *Hypergraph[V]) ExactEdgeCover(maxTime time.Duration) (EdgeCover, error) {
+	c, err := newCoverInstance(h)
+	if err != nil {
+		return EdgeCover{}, err
+	}
+	best, cutoff := c.branchAndBound(c.greedy(), maxTime)
+	result := c.result(best)
+	if cutoff {
+		return result, ErrCutoff
+	}
+	return result, nil
+}
+
+// branchAndBound returns a minimum-weight cover, starting from the
+// incumbent seed, and whether maxTime elapsed before the search finished.
+//
+// It branches on the uncovered element contained in the fewest sets,
+// trying its sets cheapest first. A node is pruned when its weight plus a
+// lower bound for the uncovered elements cannot beat the incumbent. The
+// bound packs uncovered elements whose sets are pairwise disjoint, since
+// each of them needs its own set, and is at least the cheapest cover of
+// any single uncovered element.
+func (c *coverInstance) branchAndBound(seed []int, maxTime time.Duration) ([]int, bool) {
+	best := slices.Clone(seed)
+	bestWeight := 0.0
+	for _, e := range best {
+		bestWeight += c.weight[e]
+	}
+
+	// Sets covering each element, cheapest first.
+	cheapest := make([]float64, len(c.covers))
+	for v := range c.covers {
+		slices.SortStableFunc(c.covers[v], func(a, b int) int { return cmp.Compare(c.weight[a], c.weight[b]) })
+		cheapest[v] = c.weight[c.covers[v][0]]
+	}
+
+	start := time.Now()
+	cutoff := false
+	count := make([]int, len(c.covers)) // how many chosen sets cover v
+	used := make([]bool, len(c.sets))   // scratch for the packing bound
+	var uncovered, chosen []int
+	var search func(weight float64)
+	search = func(weight float64) {
+		if cutoff || time.Since(start) > maxTime {
+			cutoff = true
+			return
+		}
+		uncovered = uncovered[:0]
+		for v := range c.covers {
+			if count[v] == 0 {
+				uncovered = append(uncovered, v)
+			}
+		}
+		if len(uncovered) == 0 {
+			if weight < bestWeight-lpEpsilon {
+				best, bestWeight = slices.Clone(chosen), weight
+			}
+			return
+		}
+		slices.SortStableFunc(uncovered, func(a, b int) int { return cmp.Compare(len(c.covers[a]), len(c.covers[b])) })
+		branch := uncovered[0]
+
+		packing, single := 0.0, 0.0
+		for _, v := range uncovered {
+			single = max(single, cheapest[v])
+			if !slices.ContainsFunc(c.covers[v], func(e int) bool { return used[e] }) {
+				packing += cheapest[v]
+				for _, e := range c.covers[v] {
+					used[e] = true
+				}
+			}
+		}
+		for _, v := range uncovered {
+			for _, e := range c.covers[v] {
+				used[e] = false
+			}
+		}
+		if weight+max(packing, single) >= bestWeight-lpEpsilon {
+			return
+		}
+
+		for _, e := range c.covers[branch] {
+			chosen = append(chosen, e)
+			for _, v := range c.sets[e] {
+				count[v]++
+			}
+			search(weight + c.weight[e])
+			for _, v := range c.sets[e] {
+				count[v]--
+			}
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	search(0)
+	return best, cutoff
+}
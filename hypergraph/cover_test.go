@@ -0,0 +1,179 @@
+package hypergraph
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+// isEdgeCover reports whether the edges contain every vertex.
+func isEdgeCover(h *Hypergraph[int], edges []string) bool {
+	covered := make(map[int]bool)
+	for _, id := range edges {
+		for _, v := range h.EdgeMembers(id) {
+			covered[v] = true
+		}
+	}
+	return len(covered) == h.NumVertices()
+}
+
+// bruteForceEdgeCover returns the minimum cover weight by enumeration.
+func bruteForceEdgeCover(h *Hypergraph[int]) float64 {
+	edges := h.Edges()
+	slices.Sort(edges)
+	best := math.Inf(1)
+	for mask := range 1 << len(edges) {
+		var chosen []string
+		weight := 0.0
+		for i, id := range edges {
+			if mask&(1<<i) != 0 {
+				chosen = append(chosen, id)
+				weight += h.EdgeWeight(id)
+			}
+		}
+		if weight < best && isEdgeCover(h, chosen) {
+			best = weight
+		}
+	}
+	return best
+}
+
+// ============================================================================
+// Edge Cover Tests
+// ============================================================================
+
+func TestEdgeCover_Solvers(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("all", []string{"A", "B", "C", "D"})
+	_ = h.AddEdge("ab", []string{"A", "B"})
+	_ = h.AddEdge("cd", []string{"C", "D"})
+	_ = h.SetEdgeWeight("all", 3)
+
+	for name, solve := range map[string]func() (EdgeCover, error){
+		"greedy": h.GreedyEdgeCover,
+		"lp":     h.LPRoundingEdgeCover,
+		"exact":  func() (EdgeCover, error) { return h.ExactEdgeCover(time.Second) },
+	} {
+		cover, err := solve()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if cover.Weight != 2 || !slices.Equal(cover.Edges, []string{"ab", "cd"}) {
+			t.Errorf("%s: cover = %+v, want [ab cd] with weight 2", name, cover)
+		}
+	}
+}
+
+func TestEdgeCover_Infeasible(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	h.AddVertex("lonely")
+
+	if _, err := h.GreedyEdgeCover(); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("greedy: err = %v, want ErrInfeasible", err)
+	}
+	if _, err := h.LPRoundingEdgeCover(); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("lp: err = %v, want ErrInfeasible", err)
+	}
+	if _, err := h.ExactEdgeCover(time.Second); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("exact: err = %v, want ErrInfeasible", err)
+	}
+}
+
+func TestEdgeCover_Empty(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	cover, err := h.LPRoundingEdgeCover()
+	if err != nil || len(cover.Edges) != 0 {
+		t.Errorf("empty graph: cover = %+v, err = %v", cover, err)
+	}
+}
+
+func TestEdgeCover_AgainstBruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(1, 2))
+	for trial := range 30 {
+		h := randomWeightedHypergraph(rng, 7, 9)
+		if _, err := h.GreedyEdgeCover(); errors.Is(err, ErrInfeasible) {
+			continue
+		}
+		opt := bruteForceEdgeCover(h)
+
+		exact, err := h.ExactEdgeCover(time.Second)
+		if err != nil {
+			t.Fatalf("trial %d: exact: %v", trial, err)
+		}
+		if exact.Weight != opt {
+			t.Fatalf("trial %d: exact weight %v, optimum %v", trial, exact.Weight, opt)
+		}
+
+		maxSize, maxDeg := 0, 0
+		for _, id := range h.Edges() {
+			size, _ := h.EdgeSize(id)
+			maxSize = max(maxSize, size)
+		}
+		for _, v := range h.Vertices() {
+			maxDeg = max(maxDeg, h.VertexDegree(v))
+		}
+		harmonic := 0.0
+		for k := 1; k <= maxSize; k++ {
+			harmonic += 1 / float64(k)
+		}
+
+		greedy, _ := h.GreedyEdgeCover()
+		lp, _ := h.LPRoundingEdgeCover()
+		for name, c := range map[string]EdgeCover{"exact": exact, "greedy": greedy, "lp": lp} {
+			if !isEdgeCover(h, c.Edges) {
+				t.Fatalf("trial %d: %s result %v is not a cover", trial, name, c.Edges)
+			}
+		}
+		if greedy.Weight > harmonic*opt+1e-9 {
+			t.Errorf("trial %d: greedy %v exceeds H(k)*opt = %v", trial, greedy.Weight, harmonic*opt)
+		}
+		if lp.Weight > float64(maxDeg)*opt+1e-9 {
+			t.Errorf("trial %d: lp %v exceeds f*opt = %v", trial, lp.Weight, float64(maxDeg)*opt)
+		}
+	}
+}
+
+func TestSolveRelaxation_LowerBound(t *testing.T) {
+	t.Parallel()
+	// Odd cycle of pairs: the LP optimum is 1.5 with every x_e = 1/2.
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("ab", []string{"A", "B"})
+	_ = h.AddEdge("bc", []string{"B", "C"})
+	_ = h.AddEdge("ac", []string{"A", "C"})
+	c, err := newCoverInstance(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := c.solveRelaxation()
+	total := 0.0
+	for _, xe := range x {
+		total += xe
+	}
+	if math.Abs(total-1.5) > 1e-9 {
+		t.Errorf("LP optimum = %v (x = %v), want 1.5", total, x)
+	}
+}
+
+func TestExactEdgeCover_Cutoff(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(9, 9))
+	h := randomWeightedHypergraph(rng, 40, 120)
+	for v := range 40 {
+		_ = h.AddEdge("s"+fmtInt(v), []int{v})
+	}
+	cover, err := h.ExactEdgeCover(0)
+	if !errors.Is(err, ErrCutoff) {
+		t.Fatalf("err = %v, want ErrCutoff", err)
+	}
+	if !isEdgeCover(h, cover.Edges) {
+		t.Error("cutoff result should still be a valid cover")
+	}
+}
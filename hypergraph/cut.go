@@ -0,0 +1,341 @@
+package hypergraph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// HyperedgeCut is a set of hyperedges whose removal separates the vertex
+// set into Side and its complement.
+type HyperedgeCut[V comparable] struct {
+	// Weight is the total weight of the cut edges.
+	Weight float64
+	// Edges are the IDs of the cut edges, sorted.
+	Edges []string
+	// Side is the source side of the cut, sorted. For a global cut it is
+	// the side found by the algorithm.
+	Side []V
+}
+
+// MinSTCut returns a minimum-weight set of hyperedges whose removal
+// disconnects every vertex in sources from every vertex in sinks.
+//
+// It uses Lawler's construction: each hyperedge e becomes an arc
+// e_in -> e_out with capacity EdgeWeight(e), and every member v of e gets
+// uncapacitated arcs v -> e_in and e_out -> v. A maximum flow from the
+// sources to the sinks, computed with Dinic's algorithm, gives the cut.
+//
+// Both groups must be non-empty, disjoint, and contain only vertices of h.
+// Time complexity: O(N^2 * A) for N = |V| + 2|E| nodes and A arcs, and
+// usually much less in practice.
+func (h *Hypergraph[V]) MinSTCut(sources, sinks []V) (HyperedgeCut[V], error) {
+	if len(sources) == 0 || len(sinks) == 0 {
+		return HyperedgeCut[V]{}, errors.New("min cut: source and sink groups must be non-empty")
+	}
+	for _, v := range slices.Concat(sources, sinks) {
+		if !h.HasVertex(v) {
+			return HyperedgeCut[V]{}, fmt.Errorf("min cut: vertex not found: %v", v)
+		}
+	}
+	for _, s := range sources {
+		if slices.Contains(sinks, s) {
+			return HyperedgeCut[V]{}, fmt.Errorf("min cut: vertex %v is both source and sink", s)
+		}
+	}
+
+	vertices := h.sorted(maps.Keys(h.vertices))
+	edges := slices.Sorted(maps.Keys(h.edges))
+	vertexNode := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		vertexNode[v] = i
+	}
+	edgeIn := func(e int) int { return len(vertices) + 2*e }
+	source := len(vertices) + 2*len(edges)
+	sink := source + 1
+
+	net := newFlowNetwork(sink + 1)
+	inf := math.Inf(1)
+	for e, id := range edges {
+		net.addArc(edgeIn(e), edgeIn(e)+1, h.EdgeWeight(id))
+		for v := range h.Members(id, Unordered) {
+			net.addArc(vertexNode[v], edgeIn(e), inf)
+			net.addArc(edgeIn(e)+1, vertexNode[v], inf)
+		}
+	}
+	for _, s := range sources {
+		net.addArc(source, vertexNode[s], inf)
+	}
+	for _, t := range sinks {
+		net.addArc(vertexNode[t], sink, inf)
+	}
+
+	net.maxFlow(source, sink)
+	reach := net.reachable(source)
+	var cut HyperedgeCut[V]
+	for e, id := range edges {
+		if reach[edgeIn(e)] && !reach[edgeIn(e)+1] {
+			cut.Edges = append(cut.Edges, id)
+			cut.Weight += h.EdgeWeight(id)
+		}
+	}
+	for i, v := range vertices {
+		if reach[i] {
+			cut.Side = append(cut.Side, v)
+		}
+	}
+	return cut, nil
+}
+
+// GlobalMinCut returns a minimum-weight set of hyperedges whose removal
+// splits the hypergraph into two non-empty parts. A disconnected hypergraph
+// has a cut of weight 0 with no edges.
+//
+// It uses Queyranne's algorithm for symmetric submodular functions applied
+// to the hyperedge cut function, which generalizes the Stoer–Wagner and
+// Klimmek–Wagner orderings: each phase builds an ordering by repeatedly
+// adding the vertex v minimizing cut(A + v) - cut(v), records the cut
+// around the last vertex, and merges the last two vertices.
+//
+// The hypergraph needs at least two vertices.
+// Time complexity: O(|V|^2 * P) where P is the number of edge memberships.
+func (h *Hypergraph[V]) GlobalMinCut() (HyperedgeCut[V], error) {
+	if len(h.vertices) < 2 {
+		return HyperedgeCut[V]{}, errors.New("min cut: need at least two vertices")
+	}
+
+	vertices := h.sorted(maps.Keys(h.vertices))
+	edges := slices.Sorted(maps.Keys(h.edges))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	size := make([]int, len(edges))
+	weight := make([]float64, len(edges))
+	// groups[i] lists the original vertices merged into super-vertex i;
+	// pins[i] counts their memberships per edge, in ascending edge order so
+	// that floating-point sums are reproducible.
+	groups := make([][]int, len(vertices))
+	pins := make([][]edgeHit, len(vertices))
+	for i := range vertices {
+		groups[i] = []int{i}
+	}
+	for e, id := range edges {
+		weight[e] = h.EdgeWeight(id)
+		for v := range h.Members(id, Unordered) {
+			pins[index[v]] = append(pins[index[v]], edgeHit{e, 1})
+			size[e]++
+		}
+	}
+	// cutOf returns the weight of edges split by a super-vertex alone.
+	cutOf := func(i int) float64 {
+		total := 0.0
+		for _, p := range pins[i] {
+			if p.count < size[p.edge] {
+				total += weight[p.edge]
+			}
+		}
+		return total
+	}
+
+	alive := make([]int, len(vertices))
+	for i := range alive {
+		alive[i] = i
+	}
+	bestWeight := math.Inf(1)
+	var bestSide []int
+	for len(alive) > 1 {
+		// Build an ordering starting from alive[0].
+		inA := make([]int, len(edges)) // memberships of each edge inside A
+		added := map[int]bool{}
+		order := make([]int, 0, len(alive))
+		for len(order) < len(alive) {
+			next, nextKey := -1, math.Inf(1)
+			for _, v := range alive {
+				if added[v] {
+					continue
+				}
+				key := 0.0
+				if len(order) > 0 {
+					for _, p := range pins[v] {
+						e, c, a := p.edge, p.count, inA[p.edge]
+						key += weight[e] * (b2f(a+c > 0 && a+c < size[e]) - b2f(a > 0 && a < size[e]) - b2f(c < size[e]))
+					}
+				}
+				if next < 0 || key < nextKey-1e-12 {
+					next, nextKey = v, key
+				}
+			}
+			order = append(order, next)
+			added[next] = true
+			for _, p := range pins[next] {
+				inA[p.edge] += p.count
+			}
+		}
+
+		last, prev := order[len(order)-1], order[len(order)-2]
+		if w := cutOf(last); w < bestWeight {
+			bestWeight = w
+			bestSide = slices.Clone(groups[last])
+		}
+		// Merge last into prev.
+		groups[prev] = append(groups[prev], groups[last]...)
+		pins[prev] = mergeHits(pins[prev], pins[last])
+		alive = slices.DeleteFunc(alive, func(v int) bool { return v == last })
+	}
+
+	cut := HyperedgeCut[V]{Weight: bestWeight}
+	inSide := make(map[int]bool, len(bestSide))
+	for _, i := range bestSide {
+		inSide[i] = true
+	}
+	for _, i := range bestSide {
+		cut.Side = append(cut.Side, vertices[i])
+	}
+	h.sortVertices(cut.Side)
+	for _, id := range edges {
+		inside := 0
+		for v := range h.Members(id, Unordered) {
+			if inSide[index[v]] {
+				inside++
+			}
+		}
+		if inside > 0 && inside < len(h.edges[id].Set) {
+			cut.Edges = append(cut.Edges, id)
+		}
+	}
+	return cut, nil
+}
+
+// mergeHits merges two edge-ordered membership lists, adding counts.
+func mergeHits(a, b []edgeHit) []edgeHit {
+	out := make([]edgeHit, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i].edge < b[j].edge):
+			out = append(out, a[i])
+			i++
+		case i == len(a) || b[j].edge < a[i].edge:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, edgeHit{a[i].edge, a[i].count + b[j].count})
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+func b2f(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// flowNetwork is a directed graph with arc capacities for Dinic's
+// max-flow algorithm. Arcs are stored in pairs: arc i^1 is the reverse of
+// arc i.
+type flowNetwork struct {
+	adj   [][]int
+	head  []int
+	resid []float64
+	level []int
+	next  []int
+}
+
+func newFlowNetwork(n int) *flowNetwork {
+	return &flowNetwork{adj: make([][]int, n)}
+}
+
+func (f *flowNetwork) addArc(from, to int, capacity float64) {
+	f.adj[from] = append(f.adj[from], len(f.head))
+	f.head = append(f.head, to)
+	f.resid = append(f.resid, capacity)
+	f.adj[to] = append(f.adj[to], len(f.head))
+	f.head = append(f.head, from)
+	f.resid = append(f.resid, 0)
+}
+
+// maxFlow pushes a maximum flow from s to t and returns its value.
+func (f *flowNetwork) maxFlow(s, t int) float64 {
+	total := 0.0
+	for f.buildLevels(s, t) {
+		f.next = make([]int, len(f.adj))
+		for {
+			pushed := f.augment(s, t, math.Inf(1))
+			if pushed <= flowEpsilon {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+// flowEpsilon is the residual capacity treated as zero.
+const flowEpsilon = 1e-12
+
+// buildLevels computes BFS levels in the residual graph and reports
+// whether t is reachable.
+func (f *flowNetwork) buildLevels(s, t int) bool {
+	f.level = make([]int, len(f.adj))
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[s] = 0
+	queue := []int{s}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, a := range f.adj[u] {
+			if v := f.head[a]; f.level[v] < 0 && f.resid[a] > flowEpsilon {
+				f.level[v] = f.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return f.level[t] >= 0
+}
+
+// augment sends up to limit units along level-increasing paths from u to t.
+func (f *flowNetwork) augment(u, t int, limit float64) float64 {
+	if u == t {
+		return limit
+	}
+	for ; f.next[u] < len(f.adj[u]); f.next[u]++ {
+		a := f.adj[u][f.next[u]]
+		v := f.head[a]
+		if f.level[v] != f.level[u]+1 || f.resid[a] <= flowEpsilon {
+			continue
+		}
+		if pushed := f.augment(v, t, min(limit, f.resid[a])); pushed > flowEpsilon {
+			f.resid[a] -= pushed
+			f.resid[a^1] += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable marks the nodes reachable from s in the residual graph.
+func (f *flowNetwork) reachable(s int) []bool {
+	seen := make([]bool, len(f.adj))
+	seen[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, a := range f.adj[u] {
+			if v := f.head[a]; !seen[v] && f.resid[a] > flowEpsilon {
+				seen[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return seen
+}
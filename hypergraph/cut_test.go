@@ -0,0 +1,223 @@
+package hypergraph
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// cutWeight returns the weight of edges split by side, by brute force.
+func cutWeight(h *Hypergraph[int], side map[int]bool) float64 {
+	total := 0.0
+	for _, id := range h.Edges() {
+		in := 0
+		for _, v := range h.EdgeMembers(id) {
+			if side[v] {
+				in++
+			}
+		}
+		if in > 0 && in < len(h.EdgeMembers(id)) {
+			total += h.EdgeWeight(id)
+		}
+	}
+	return total
+}
+
+// randomWeightedHypergraph builds a small hypergraph on vertices 0..n-1.
+func randomWeightedHypergraph(rng *rand.Rand, n, m int) *Hypergraph[int] {
+	h := NewHypergraph[int]()
+	for v := range n {
+		h.AddVertex(v)
+	}
+	for e := range m {
+		size := 2 + rng.IntN(3)
+		members := rng.Perm(n)[:size]
+		id := "e" + fmtInt(e)
+		_ = h.AddEdge(id, members)
+		_ = h.SetEdgeWeight(id, float64(1+rng.IntN(4)))
+	}
+	return h
+}
+
+// ============================================================================
+// MinSTCut Tests
+// ============================================================================
+
+func TestMinSTCut_Simple(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("left", []string{"A", "B", "C"})
+	_ = h.AddEdge("mid1", []string{"C", "D"})
+	_ = h.AddEdge("mid2", []string{"B", "C", "D"})
+	_ = h.AddEdge("right", []string{"D", "E"})
+	_ = h.SetEdgeWeight("right", 5)
+
+	cut, err := h.MinSTCut([]string{"A"}, []string{"E"})
+	if err != nil {
+		t.Fatalf("MinSTCut failed: %v", err)
+	}
+	// Cutting "left" (1) is cheaper than mid1+mid2 (2) or right (5).
+	if cut.Weight != 1 || !slices.Equal(cut.Edges, []string{"left"}) {
+		t.Errorf("cut = %+v, want weight 1 via [left]", cut)
+	}
+	if !slices.Equal(cut.Side, []string{"A"}) {
+		t.Errorf("source side = %v, want [A]", cut.Side)
+	}
+}
+
+func TestMinSTCut_Groups(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "X"})
+	_ = h.AddEdge("E2", []string{"B", "X"})
+	_ = h.AddEdge("E3", []string{"X", "Y", "C"})
+
+	cut, err := h.MinSTCut([]string{"A", "B"}, []string{"C"})
+	if err != nil {
+		t.Fatalf("MinSTCut failed: %v", err)
+	}
+	if cut.Weight != 1 || !slices.Equal(cut.Edges, []string{"E3"}) {
+		t.Errorf("cut = %+v, want [E3]", cut)
+	}
+}
+
+func TestMinSTCut_Disconnected(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+
+	cut, err := h.MinSTCut([]string{"A"}, []string{"D"})
+	if err != nil {
+		t.Fatalf("MinSTCut failed: %v", err)
+	}
+	if cut.Weight != 0 || len(cut.Edges) != 0 {
+		t.Errorf("cut = %+v, want empty", cut)
+	}
+}
+
+func TestMinSTCut_Errors(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+
+	if _, err := h.MinSTCut(nil, []string{"B"}); err == nil {
+		t.Error("empty source group should fail")
+	}
+	if _, err := h.MinSTCut([]string{"A"}, []string{"missing"}); err == nil {
+		t.Error("unknown vertex should fail")
+	}
+	if _, err := h.MinSTCut([]string{"A"}, []string{"A", "B"}); err == nil {
+		t.Error("overlapping groups should fail")
+	}
+}
+
+func TestMinSTCut_MatchesBruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(7, 11))
+	const n = 7
+	for trial := range 40 {
+		h := randomWeightedHypergraph(rng, n, 8)
+		cut, err := h.MinSTCut([]int{0}, []int{n - 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		best := math.Inf(1)
+		for mask := range 1 << n {
+			if mask&1 == 0 || mask&(1<<(n-1)) != 0 {
+				continue
+			}
+			side := make(map[int]bool)
+			for v := range n {
+				side[v] = mask&(1<<v) != 0
+			}
+			best = min(best, cutWeight(h, side))
+		}
+		if cut.Weight != best {
+			t.Fatalf("trial %d: cut weight %v, brute force %v", trial, cut.Weight, best)
+		}
+		side := make(map[int]bool)
+		for _, v := range cut.Side {
+			side[v] = true
+		}
+		if !side[0] || side[n-1] || cutWeight(h, side) != cut.Weight {
+			t.Fatalf("trial %d: side %v does not realize the cut", trial, cut.Side)
+		}
+	}
+}
+
+// ============================================================================
+// GlobalMinCut Tests
+// ============================================================================
+
+func TestGlobalMinCut_Bridge(t *testing.T) {
+	t.Parallel()
+	h := twoCommunities()
+	cut, err := h.GlobalMinCut()
+	if err != nil {
+		t.Fatalf("GlobalMinCut failed: %v", err)
+	}
+	if cut.Weight != 1 || !slices.Equal(cut.Edges, []string{"bridge"}) {
+		t.Errorf("cut = %+v, want the bridge", cut)
+	}
+	if len(cut.Side) != 3 {
+		t.Errorf("side = %v, want one of the two triangles", cut.Side)
+	}
+}
+
+func TestGlobalMinCut_Disconnected(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	h.AddVertex("C")
+	cut, err := h.GlobalMinCut()
+	if err != nil {
+		t.Fatalf("GlobalMinCut failed: %v", err)
+	}
+	if cut.Weight != 0 || len(cut.Edges) != 0 {
+		t.Errorf("cut = %+v, want weight 0", cut)
+	}
+}
+
+func TestGlobalMinCut_TooSmall(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	h.AddVertex("A")
+	if _, err := h.GlobalMinCut(); err == nil {
+		t.Error("single vertex should fail")
+	}
+}
+
+func TestGlobalMinCut_MatchesBruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(3, 5))
+	const n = 7
+	for trial := range 40 {
+		h := randomWeightedHypergraph(rng, n, 6+rng.IntN(6))
+		cut, err := h.GlobalMinCut()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		best := math.Inf(1)
+		for mask := 1; mask < (1<<n)-1; mask++ {
+			side := make(map[int]bool)
+			for v := range n {
+				side[v] = mask&(1<<v) != 0
+			}
+			best = min(best, cutWeight(h, side))
+		}
+		if cut.Weight != best {
+			t.Fatalf("trial %d: cut weight %v, brute force %v", trial, cut.Weight, best)
+		}
+		side := make(map[int]bool)
+		for _, v := range cut.Side {
+			side[v] = true
+		}
+		if len(cut.Side) == 0 || len(cut.Side) == n || cutWeight(h, side) != cut.Weight {
+			t.Fatalf("trial %d: side %v does not realize the cut", trial, cut.Side)
+		}
+	}
+}
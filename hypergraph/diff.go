@@ -0,0 +1,432 @@
+package hypergraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Patch is the structural difference between two hypergraphs, as computed
+// by [Diff] and applied by [Hypergraph.ApplyPatch]. Vertex lists are
+// sorted, edge lists are sorted by ID and members are sorted, so the same
+// pair of hypergraphs always gives the same patch.
+type Patch[V comparable] struct {
+	AddedVertices   []V             `json:"added_vertices,omitempty"`
+	RemovedVertices []V             `json:"removed_vertices,omitempty"`
+	AddedEdges      []PatchEdge[V]  `json:"added_edges,omitempty"`
+	RemovedEdges    []PatchEdge[V]  `json:"removed_edges,omitempty"`
+	ChangedEdges    []EdgeChange[V] `json:"changed_edges,omitempty"`
+}
+
+// PatchEdge is a complete edge: its members, weight and role bindings.
+type PatchEdge[V comparable] struct {
+	ID      string           `json:"id"`
+	Members []V              `json:"members"`
+	Weight  float64          `json:"weight"`
+	Roles   []RoleBinding[V] `json:"roles,omitempty"`
+}
+
+// EdgeChange describes an edge present on both sides whose members or
+// attributes differ. Weight and Roles are nil when unchanged.
+type EdgeChange[V comparable] struct {
+	ID             string          `json:"id"`
+	AddedMembers   []V             `json:"added_members,omitempty"`
+	RemovedMembers []V             `json:"removed_members,omitempty"`
+	Weight         *WeightChange   `json:"weight,omitempty"`
+	Roles          *RolesChange[V] `json:"roles,omitempty"`
+}
+
+// WeightChange records an edge weight before and after.
+type WeightChange struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"`
+}
+
+// RolesChange records an edge's role bindings before and after.
+type RolesChange[V comparable] struct {
+	From []RoleBinding[V] `json:"from"`
+	To   []RoleBinding[V] `json:"to"`
+}
+
+// IsEmpty reports whether the patch makes no changes.
+func (p *Patch[V]) IsEmpty() bool {
+	return len(p.AddedVertices) == 0 && len(p.RemovedVertices) == 0 &&
+		len(p.AddedEdges) == 0 && len(p.RemovedEdges) == 0 && len(p.ChangedEdges) == 0
+}
+
+// Diff returns the patch that turns a into b. Vertices added along with an
+// edge are listed in AddedVertices too.
+//
+// Vertex lists, b's included, are sorted and compared in a's vertex order,
+// the order [Hypergraph.ApplyPatch] on a expects; b's own order is not
+// used.
+func Diff[V comparable](a, b *Hypergraph[V]) *Patch[V] {
+	p := &Patch[V]{}
+	inA := func(e PatchEdge[V]) PatchEdge[V] {
+		a.sortVertices(e.Members)
+		return e
+	}
+	for _, v := range a.sorted(maps.Keys(b.vertices)) {
+		if !a.HasVertex(v) {
+			p.AddedVertices = append(p.AddedVertices, v)
+		}
+	}
+	for _, v := range a.sorted(maps.Keys(a.vertices)) {
+		if !b.HasVertex(v) {
+			p.RemovedVertices = append(p.RemovedVertices, v)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(a.edges)) {
+		if !b.HasEdge(id) {
+			p.RemovedEdges = append(p.RemovedEdges, a.patchEdge(id))
+		} else if c, changed := a.diffEdge(a.patchEdge(id), inA(b.patchEdge(id))); changed {
+			p.ChangedEdges = append(p.ChangedEdges, c)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(b.edges)) {
+		if !a.HasEdge(id) {
+			p.AddedEdges = append(p.AddedEdges, inA(b.patchEdge(id)))
+		}
+	}
+	return p
+}
+
+// patchEdge returns the full state of an existing edge.
+func (h *Hypergraph[V]) patchEdge(id string) PatchEdge[V] {
+	return PatchEdge[V]{
+		ID:      id,
+		Members: slices.Collect(h.Members(id, Sorted)),
+		Weight:  h.EdgeWeight(id),
+		Roles:   h.EdgeRoles(id),
+	}
+}
+
+func (h *Hypergraph[V]) diffEdge(a, b PatchEdge[V]) (EdgeChange[V], bool) {
+	c := EdgeChange[V]{ID: a.ID}
+	for _, v := range b.Members {
+		if _, found := slices.BinarySearchFunc(a.Members, v, h.compare); !found {
+			c.AddedMembers = append(c.AddedMembers, v)
+		}
+	}
+	for _, v := range a.Members {
+		if _, found := slices.BinarySearchFunc(b.Members, v, h.compare); !found {
+			c.RemovedMembers = append(c.RemovedMembers, v)
+		}
+	}
+	if a.Weight != b.Weight {
+		c.Weight = &WeightChange{From: a.Weight, To: b.Weight}
+	}
+	if !slices.Equal(a.Roles, b.Roles) {
+		c.Roles = &RolesChange[V]{From: a.Roles, To: b.Roles}
+	}
+	changed := c.AddedMembers != nil || c.RemovedMembers != nil || c.Weight != nil || c.Roles != nil
+	return c, changed
+}
+
+func equalPatchEdges[V comparable](a, b PatchEdge[V]) bool {
+	return slices.Equal(a.Members, b.Members) && a.Weight == b.Weight && slices.Equal(a.Roles, b.Roles)
+}
+
+// ApplyPatch applies p to h. Every change is checked against the current
+// state first: vertices and edges being added must not exist, removed
+// edges and the "from" side of changes must match exactly, and removed
+// vertices must be left in no edge. On any mismatch ApplyPatch returns an
+// error wrapping [ErrPatchConflict] and leaves h unchanged.
+func (h *Hypergraph[V]) ApplyPatch(p *Patch[V]) error {
+	c := h.Copy()
+	if err := c.applyPatch(p); err != nil {
+		return err
+	}
+	*h = *c
+	return nil
+}
+
+func conflictf(format string, args ...any) error {
+	return fmt.Errorf(format+": %w", append(args, ErrPatchConflict)...)
+}
+
+func (h *Hypergraph[V]) applyPatch(p *Patch[V]) error {
+	for _, v := range p.AddedVertices {
+		if h.HasVertex(v) {
+			return conflictf("vertex %v already exists", v)
+		}
+		h.AddVertex(v)
+	}
+	for _, e := range p.RemovedEdges {
+		if !h.HasEdge(e.ID) {
+			return conflictf("edge %q does not exist", e.ID)
+		}
+		if !equalPatchEdges(h.patchEdge(e.ID), e) {
+			return conflictf("edge %q differs from the removed edge", e.ID)
+		}
+		h.RemoveEdge(e.ID)
+	}
+	for _, c := range p.ChangedEdges {
+		if err := h.applyEdgeChange(c); err != nil {
+			return err
+		}
+	}
+	for _, e := range p.AddedEdges {
+		if h.HasEdge(e.ID) {
+			return conflictf("edge %q already exists", e.ID)
+		}
+		if err := h.addPatchEdge(e); err != nil {
+			return fmt.Errorf("edge %q: %w", e.ID, err)
+		}
+	}
+	for _, v := range p.RemovedVertices {
+		if !h.HasVertex(v) {
+			return conflictf("vertex %v does not exist", v)
+		}
+		if id, ok := first(h.IncidentEdges(v, Sorted)); ok {
+			return conflictf("vertex %v is still in edge %q", v, id)
+		}
+		h.RemoveVertex(v)
+	}
+	return nil
+}
+
+// first returns the first value of seq, if any.
+func first[T any](seq iter.Seq[T]) (T, bool) {
+	for v := range seq {
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+func (h *Hypergraph[V]) applyEdgeChange(c EdgeChange[V]) error {
+	edge, ok := h.edges[c.ID]
+	if !ok {
+		return conflictf("edge %q does not exist", c.ID)
+	}
+	e := h.patchEdge(c.ID)
+	members := maps.Clone(edge.Set)
+	for _, v := range c.RemovedMembers {
+		if _, ok := members[v]; !ok {
+			return conflictf("edge %q has no member %v", c.ID, v)
+		}
+		delete(members, v)
+	}
+	for _, v := range c.AddedMembers {
+		if _, ok := edge.Set[v]; ok {
+			return conflictf("edge %q already has member %v", c.ID, v)
+		}
+		members[v] = struct{}{}
+	}
+	e.Members = h.sorted(maps.Keys(members))
+	if c.Weight != nil {
+		if e.Weight != c.Weight.From {
+			return conflictf("edge %q has weight %v, not %v", c.ID, e.Weight, c.Weight.From)
+		}
+		e.Weight = c.Weight.To
+	}
+	if c.Roles != nil {
+		if !slices.Equal(e.Roles, c.Roles.From) {
+			return conflictf("edge %q roles differ from the patch", c.ID)
+		}
+		e.Roles = c.Roles.To
+	}
+	h.RemoveEdge(c.ID)
+	if err := h.addPatchEdge(e); err != nil {
+		return fmt.Errorf("edge %q: %w", c.ID, err)
+	}
+	return nil
+}
+
+// addPatchEdge adds e with its weight and roles.
+func (h *Hypergraph[V]) addPatchEdge(e PatchEdge[V]) error {
+	if err := h.AddEdge(e.ID, e.Members); err != nil {
+		return err
+	}
+	if err := h.SetEdgeWeight(e.ID, e.Weight); err != nil {
+		return err
+	}
+	if len(e.Roles) > 0 {
+		return h.SetEdgeRoles(e.ID, e.Roles)
+	}
+	return nil
+}
+
+const (
+	patchFormat  = "hypergraph-patch"
+	patchVersion = 1
+)
+
+type patchFile[V comparable] struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	*Patch[V]
+}
+
+// SaveJSON writes the patch as indented JSON tagged with a format name
+// and version, for [LoadPatchJSON].
+func (p *Patch[V]) SaveJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(patchFile[V]{Format: patchFormat, Version: patchVersion, Patch: p})
+}
+
+// LoadPatchJSON reads a patch written by [Patch.SaveJSON].
+func LoadPatchJSON[V comparable](r io.Reader) (*Patch[V], error) {
+	f := patchFile[V]{Patch: &Patch[V]{}}
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	if f.Format != patchFormat {
+		return nil, fmt.Errorf("not a hypergraph patch (format %q)", f.Format)
+	}
+	if f.Version != patchVersion {
+		return nil, fmt.Errorf("unsupported patch version %d", f.Version)
+	}
+	return f.Patch, nil
+}
+
+// MergeConflict is a change that a three-way [Merge] could not reconcile.
+type MergeConflict struct {
+	// Edge is the edge involved; for a vertex conflict, the edge that
+	// still uses the vertex.
+	Edge string `json:"edge"`
+	// Kind is "edge" when the edge itself was removed, added or emptied
+	// inconsistently, "weight" or "roles" when both sides changed that
+	// attribute differently, and "vertex" when one side removed a vertex
+	// that the merged edges still use.
+	Kind string `json:"kind"`
+	// Message describes the conflict.
+	Message string `json:"message"`
+}
+
+// Merge combines the changes that ours and theirs each made to base.
+// Vertices, edge members, weights and roles merge independently, so
+// concurrent edits to different parts of the same edge both survive. Where
+// both sides changed the same thing differently, the result keeps ours and
+// the conflict is reported. Conflicts are ordered by edge ID, with vertex
+// conflicts last.
+func Merge[V comparable](base, ours, theirs *Hypergraph[V]) (*Hypergraph[V], []MergeConflict) {
+	out := base.empty()
+	var conflicts []MergeConflict
+
+	keep := make(map[V]bool)
+	vertices := make(map[V]struct{})
+	for _, h := range []*Hypergraph[V]{base, ours, theirs} {
+		maps.Copy(vertices, h.vertices)
+	}
+	for _, v := range out.sorted(maps.Keys(vertices)) {
+		if merge3(base.HasVertex(v), ours.HasVertex(v), theirs.HasVertex(v)) {
+			keep[v] = true
+			out.AddVertex(v)
+		}
+	}
+
+	ids := make(map[string]struct{})
+	for _, h := range []*Hypergraph[V]{base, ours, theirs} {
+		for id := range h.edges {
+			ids[id] = struct{}{}
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(ids)) {
+		b, inB := base.edgeState(id)
+		o, inO := ours.edgeState(id)
+		t, inT := theirs.edgeState(id)
+		e, exists := o, inO
+		switch {
+		case inO == inT && (!inO || equalPatchEdges(o, t)):
+		case inO == inB && (!inO || equalPatchEdges(o, b)):
+			e, exists = t, inT
+		case inT == inB && (!inT || equalPatchEdges(t, b)):
+		case !inO || !inT:
+			removed, modified := "ours", "theirs"
+			if inO {
+				removed, modified = "theirs", "ours"
+			}
+			conflicts = append(conflicts, MergeConflict{id, "edge",
+				fmt.Sprintf("edge %q removed by %s and modified by %s", id, removed, modified)})
+		case !inB:
+			conflicts = append(conflicts, MergeConflict{id, "edge",
+				fmt.Sprintf("edge %q added differently by ours and theirs", id)})
+		default:
+			var cs []MergeConflict
+			e, cs = out.mergeEdge(b, o, t)
+			conflicts = append(conflicts, cs...)
+		}
+		if exists {
+			_ = out.addPatchEdge(e)
+		}
+	}
+
+	for _, v := range out.sorted(maps.Keys(out.vertices)) {
+		if keep[v] {
+			continue
+		}
+		side := "ours"
+		if ours.HasVertex(v) {
+			side = "theirs"
+		}
+		id, _ := first(out.IncidentEdges(v, Sorted))
+		conflicts = append(conflicts, MergeConflict{id, "vertex",
+			fmt.Sprintf("vertex %v removed by %s but still in edge %q", v, side, id)})
+	}
+	return out, conflicts
+}
+
+func (h *Hypergraph[V]) edgeState(id string) (PatchEdge[V], bool) {
+	if !h.HasEdge(id) {
+		return PatchEdge[V]{}, false
+	}
+	return h.patchEdge(id), true
+}
+
+// merge3 merges a value that ours and theirs may each have changed from
+// base, preferring ours when both changed it.
+func merge3[T comparable](base, ours, theirs T) T {
+	if ours == base {
+		return theirs
+	}
+	return ours
+}
+
+// mergeEdge merges an edge that both sides modified.
+func (h *Hypergraph[V]) mergeEdge(b, o, t PatchEdge[V]) (PatchEdge[V], []MergeConflict) {
+	var conflicts []MergeConflict
+	e := PatchEdge[V]{ID: o.ID}
+	has := func(members []V, v V) bool {
+		_, found := slices.BinarySearchFunc(members, v, h.compare)
+		return found
+	}
+	union := slices.Concat(b.Members, o.Members, t.Members)
+	h.sortVertices(union)
+	for _, v := range slices.Compact(union) {
+		if merge3(has(b.Members, v), has(o.Members, v), has(t.Members, v)) {
+			e.Members = append(e.Members, v)
+		}
+	}
+	if len(e.Members) == 0 {
+		conflicts = append(conflicts, MergeConflict{e.ID, "edge",
+			fmt.Sprintf("edge %q left with no members", e.ID)})
+		return o, conflicts
+	}
+
+	e.Weight = merge3(b.Weight, o.Weight, t.Weight)
+	if o.Weight != b.Weight && t.Weight != b.Weight && o.Weight != t.Weight {
+		conflicts = append(conflicts, MergeConflict{e.ID, "weight",
+			fmt.Sprintf("edge %q weight changed to %v by ours and %v by theirs", e.ID, o.Weight, t.Weight)})
+	}
+
+	e.Roles = o.Roles
+	switch {
+	case slices.Equal(o.Roles, b.Roles):
+		e.Roles = t.Roles
+	case !slices.Equal(t.Roles, b.Roles) && !slices.Equal(o.Roles, t.Roles):
+		conflicts = append(conflicts, MergeConflict{e.ID, "roles",
+			fmt.Sprintf("edge %q roles changed differently by ours and theirs", e.ID)})
+	}
+	if slices.ContainsFunc(e.Roles, func(r RoleBinding[V]) bool { return !has(e.Members, r.Vertex) }) {
+		conflicts = append(conflicts, MergeConflict{e.ID, "roles",
+			fmt.Sprintf("edge %q roles bind vertices no longer in the edge", e.ID)})
+		e.Roles = slices.DeleteFunc(slices.Clone(e.Roles), func(r RoleBinding[V]) bool { return !has(e.Members, r.Vertex) })
+	}
+	return e, conflicts
+}
@@ -0,0 +1,254 @@
+package hypergraph
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Diff and Patch Tests
+// ============================================================================
+
+func TestDiff_Contents(t *testing.T) {
+	t.Parallel()
+	a := NewHypergraph[string]()
+	_ = a.AddEdge("e1", []string{"a", "b"})
+	_ = a.AddEdge("e2", []string{"b", "c"})
+	_ = a.AddEdge("e3", []string{"c", "d"})
+	b := a.Copy()
+	b.RemoveEdge("e1")
+	b.RemoveVertex("a")
+	b.RemoveEdge("e2")
+	_ = b.AddEdge("e2", []string{"c", "x"})
+	_ = b.SetEdgeWeight("e3", 2)
+	_ = b.SetEdgeRoles("e3", []RoleBinding[string]{{"head", "c"}})
+	_ = b.AddEdge("e4", []string{"d", "y"})
+
+	p := Diff(a, b)
+	if !slices.Equal(p.AddedVertices, []string{"x", "y"}) || !slices.Equal(p.RemovedVertices, []string{"a"}) {
+		t.Errorf("vertices: +%v -%v", p.AddedVertices, p.RemovedVertices)
+	}
+	if len(p.RemovedEdges) != 1 || p.RemovedEdges[0].ID != "e1" || !slices.Equal(p.RemovedEdges[0].Members, []string{"a", "b"}) {
+		t.Errorf("RemovedEdges = %+v", p.RemovedEdges)
+	}
+	if len(p.AddedEdges) != 1 || p.AddedEdges[0].ID != "e4" || p.AddedEdges[0].Weight != 1 {
+		t.Errorf("AddedEdges = %+v", p.AddedEdges)
+	}
+	if len(p.ChangedEdges) != 2 {
+		t.Fatalf("ChangedEdges = %+v", p.ChangedEdges)
+	}
+	e2, e3 := p.ChangedEdges[0], p.ChangedEdges[1]
+	if e2.ID != "e2" || !slices.Equal(e2.AddedMembers, []string{"x"}) || !slices.Equal(e2.RemovedMembers, []string{"b"}) ||
+		e2.Weight != nil || e2.Roles != nil {
+		t.Errorf("e2 change = %+v", e2)
+	}
+	if e3.ID != "e3" || e3.AddedMembers != nil || *e3.Weight != (WeightChange{1, 2}) || len(e3.Roles.To) != 1 {
+		t.Errorf("e3 change = %+v", e3)
+	}
+	if !Diff(a, a.Copy()).IsEmpty() {
+		t.Error("diff of a copy should be empty")
+	}
+}
+
+func TestDiff_Comparators(t *testing.T) {
+	t.Parallel()
+	numeric := CompareBy(func(v string) int { n, _ := strconv.Atoi(v); return n })
+	a := NewHypergraphFunc(numeric)
+	_ = a.AddEdge("e1", []string{"10", "9", "2"})
+	b := NewHypergraph[string]()
+	_ = b.AddEdge("e1", []string{"10", "9", "2", "100"})
+	_ = b.AddEdge("e2", []string{"30", "4"})
+
+	// b's lists come out in a's order, and equal members match.
+	p := Diff(a, b)
+	if !slices.Equal(p.AddedVertices, []string{"4", "30", "100"}) {
+		t.Errorf("AddedVertices = %v", p.AddedVertices)
+	}
+	if len(p.ChangedEdges) != 1 || p.ChangedEdges[0].RemovedMembers != nil ||
+		!slices.Equal(p.ChangedEdges[0].AddedMembers, []string{"100"}) {
+		t.Errorf("ChangedEdges = %+v", p.ChangedEdges)
+	}
+	if len(p.AddedEdges) != 1 || !slices.Equal(p.AddedEdges[0].Members, []string{"4", "30"}) {
+		t.Errorf("AddedEdges = %+v", p.AddedEdges)
+	}
+	if err := a.ApplyPatch(p); err != nil {
+		t.Fatal(err)
+	}
+	if !Diff(a, b).IsEmpty() {
+		t.Error("patched hypergraph differs")
+	}
+}
+
+func TestApplyPatch_RoundTrip(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(41, 1))
+	for trial := range 50 {
+		a := randomWeightedHypergraph(rng, 6+rng.IntN(6), 3+rng.IntN(6))
+		b := randomWeightedHypergraph(rng, 6+rng.IntN(6), 3+rng.IntN(6))
+		for _, h := range []*Hypergraph[int]{a, b} {
+			if members := h.EdgeMembers("e0"); rng.IntN(2) == 0 {
+				_ = h.SetEdgeRoles("e0", []RoleBinding[int]{{"head", members[0]}})
+			}
+		}
+
+		p := Diff(a, b)
+		var buf bytes.Buffer
+		if err := p.SaveJSON(&buf); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadPatchJSON[int](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := a.Copy()
+		if err := got.ApplyPatch(loaded); err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		if d := Diff(got, b); !d.IsEmpty() {
+			t.Fatalf("trial %d: patched graph differs from target: %+v", trial, d)
+		}
+		if !p.IsEmpty() && a.ApplyPatch(Diff(b, a)) == nil {
+			t.Fatalf("trial %d: reverse patch applied to the wrong base", trial)
+		}
+	}
+}
+
+func TestApplyPatch_Conflicts(t *testing.T) {
+	t.Parallel()
+	a := fromEdges(t, []string{"a", "b"}, []string{"b", "c"})
+	b := a.Copy()
+	_ = b.SetEdgeWeight("e0", 3)
+	b.RemoveVertex("c")
+	p := Diff(a, b)
+
+	target := a.Copy()
+	if err := target.ApplyPatch(p); err != nil {
+		t.Fatal(err)
+	}
+	err := target.ApplyPatch(p)
+	if !errors.Is(err, ErrPatchConflict) {
+		t.Fatalf("second apply: err = %v, want ErrPatchConflict", err)
+	}
+	if !Diff(target, b).IsEmpty() {
+		t.Error("failed patch modified the hypergraph")
+	}
+
+	// c is removed by the patch but used by an edge the patch does not know.
+	other := a.Copy()
+	_ = other.AddEdge("extra", []string{"c", "d"})
+	err = other.ApplyPatch(p)
+	if !errors.Is(err, ErrPatchConflict) || !strings.Contains(err.Error(), `still in edge "extra"`) {
+		t.Errorf("err = %v", err)
+	}
+	if other.EdgeWeight("e0") != 1 {
+		t.Error("failed patch modified the hypergraph")
+	}
+}
+
+func TestLoadPatchJSON_Errors(t *testing.T) {
+	t.Parallel()
+	for _, in := range []string{
+		`{"vertices": [], "edges": {}}`,
+		`{"format": "hypergraph-patch", "version": 9}`,
+		`{"format": `,
+	} {
+		if _, err := LoadPatchJSON[string](strings.NewReader(in)); err == nil {
+			t.Errorf("LoadPatchJSON(%s) should fail", in)
+		}
+	}
+}
+
+// ============================================================================
+// Merge Tests
+// ============================================================================
+
+func TestMerge_Clean(t *testing.T) {
+	t.Parallel()
+	base := fromEdges(t, []string{"a", "b"}, []string{"b", "c"})
+	ours := base.Copy()
+	theirs := base.Copy()
+
+	// Both edit e0: ours adds a member, theirs changes the weight.
+	_ = ours.ApplyPatch(&Patch[string]{
+		AddedVertices: []string{"x"},
+		ChangedEdges:  []EdgeChange[string]{{ID: "e0", AddedMembers: []string{"x"}}},
+	})
+	_ = theirs.SetEdgeWeight("e0", 5)
+	// Both add the same edge; theirs removes e1.
+	_ = ours.AddEdge("new", []string{"a", "c"})
+	_ = theirs.AddEdge("new", []string{"a", "c"})
+	theirs.RemoveEdge("e1")
+
+	merged, conflicts := Merge(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	if !slices.Equal(slices.Collect(merged.Members("e0", Sorted)), []string{"a", "b", "x"}) || merged.EdgeWeight("e0") != 5 {
+		t.Errorf("e0 = %v weight %v", slices.Collect(merged.Members("e0", Sorted)), merged.EdgeWeight("e0"))
+	}
+	if merged.HasEdge("e1") || !merged.HasEdge("new") {
+		t.Errorf("edges = %v", merged.Edges())
+	}
+	if !merged.HasVertex("c") {
+		t.Error("vertex c should survive as a member of new")
+	}
+}
+
+func TestMerge_Conflicts(t *testing.T) {
+	t.Parallel()
+	base := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"c", "d"})
+	ours := base.Copy()
+	theirs := base.Copy()
+
+	_ = ours.SetEdgeWeight("e0", 2)
+	_ = theirs.SetEdgeWeight("e0", 3)
+	ours.RemoveEdge("e1")
+	_ = theirs.SetEdgeRoles("e1", []RoleBinding[string]{{"head", "b"}})
+	theirs.RemoveVertex("d")
+	_ = ours.AddEdge("e3", []string{"d", "e"})
+	_ = ours.AddEdge("both", []string{"a"})
+	_ = theirs.AddEdge("both", []string{"b"})
+
+	merged, conflicts := Merge(base, ours, theirs)
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.Edge+"/"+c.Kind)
+	}
+	want := []string{"both/edge", "e0/weight", "e1/edge", "e3/vertex"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("conflicts = %v, want %v\n%+v", got, want, conflicts)
+	}
+	if !strings.Contains(conflicts[2].Message, "removed by ours and modified by theirs") {
+		t.Errorf("message = %q", conflicts[2].Message)
+	}
+	// Conflicts resolve to ours.
+	if merged.EdgeWeight("e0") != 2 || merged.HasEdge("e1") || !slices.Equal(merged.EdgeMembers("both"), []string{"a"}) {
+		t.Errorf("merged = %v", Diff(base, merged))
+	}
+	// theirs removed d, which also shrank e2; ours still uses d in e3.
+	if !slices.Equal(merged.EdgeMembers("e2"), []string{"c"}) || !merged.HasVertex("d") {
+		t.Errorf("e2 = %v", merged.EdgeMembers("e2"))
+	}
+}
+
+func TestMerge_EmptiedEdge(t *testing.T) {
+	t.Parallel()
+	base := fromEdges(t, []string{"a", "b"})
+	ours := base.Copy()
+	theirs := base.Copy()
+	_ = ours.ApplyPatch(&Patch[string]{ChangedEdges: []EdgeChange[string]{{ID: "e0", RemovedMembers: []string{"a"}}}})
+	_ = theirs.ApplyPatch(&Patch[string]{ChangedEdges: []EdgeChange[string]{{ID: "e0", RemovedMembers: []string{"b"}}}})
+
+	merged, conflicts := Merge(base, ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Kind != "edge" {
+		t.Fatalf("conflicts = %+v", conflicts)
+	}
+	if !slices.Equal(merged.EdgeMembers("e0"), []string{"b"}) {
+		t.Errorf("e0 = %v, want ours", merged.EdgeMembers("e0"))
+	}
+}
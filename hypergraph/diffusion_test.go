@@ -0,0 +1,66 @@
+package hypergraph
+
+import (
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// HeatDiffusion Tests
+// ============================================================================
+
+func TestHeatDiffusion_Conserves(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"a", "b", "c"}, []string{"c", "d"}, []string{"d", "e", "f", "g"})
+	_ = h.SetEdgeWeight("e1", 3)
+	got, err := h.HeatDiffusion(map[string]float64{"a": 7}, DiffusionOptions{Steps: 400})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 401 || got[0]["a"] != 7 || got[0]["g"] != 0 {
+		t.Fatalf("got %d steps, initial %v", len(got), got[0])
+	}
+	for step, x := range got {
+		var total float64
+		for _, heat := range x {
+			total += heat
+			if heat < -1e-12 {
+				t.Fatalf("step %d has negative heat %v", step, x)
+			}
+		}
+		if math.Abs(total-7) > 1e-9 {
+			t.Fatalf("step %d total heat %v, want 7", step, total)
+		}
+	}
+	for v, heat := range got[400] {
+		if math.Abs(heat-1) > 1e-3 {
+			t.Errorf("heat at %s = %v, want 1 at equilibrium", v, heat)
+		}
+	}
+}
+
+func TestHeatDiffusion_Components(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"a", "b"}, []string{"c", "d"})
+	h.AddVertex("z")
+	got, err := h.HeatDiffusion(map[string]float64{"a": 2, "z": 5}, DiffusionOptions{Steps: 50, Dt: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := got[50]
+	if math.Abs(last["a"]-1) > 1e-6 || math.Abs(last["b"]-1) > 1e-6 || last["c"] != 0 || last["z"] != 5 {
+		t.Errorf("final heat = %v", last)
+	}
+
+	if _, err := h.HeatDiffusion(map[string]float64{"nope": 1}, DiffusionOptions{}); err == nil {
+		t.Error("expected error for unknown vertex")
+	}
+	if got, _ := h.HeatDiffusion(nil, DiffusionOptions{}); len(got) != 1 {
+		t.Errorf("zero steps should return only the initial state, got %d", len(got))
+	}
+	for _, dt := range []float64{-0.1, math.NaN(), math.Inf(1)} {
+		if _, err := h.HeatDiffusion(nil, DiffusionOptions{Steps: 1, Dt: dt}); err == nil {
+			t.Errorf("Dt %v: expected error", dt)
+		}
+	}
+}
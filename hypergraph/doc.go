@@ -9,10 +9,20 @@
 //
 // The main type is [Hypergraph], parameterized by vertex type V:
 //
-//	type Hypergraph[V cmp.Ordered] struct { ... }
+//	type Hypergraph[V comparable] struct { ... }
 //
-// Vertices can be any ordered type (int, string, etc.). Edges are identified
-// by string IDs and contain sets of vertices.
+// Vertices can be any comparable type, including structs such as a
+// (host, port) pair. Edges are identified by string IDs and contain sets of
+// vertices.
+//
+// # Vertex Order
+//
+// Results that list vertices are sorted so that output is deterministic.
+// By default numbers sort numerically, strings lexically, and structs and
+// arrays field by field. [NewHypergraphFunc] takes a comparison function
+// instead, [CompareBy] builds one from a key, and [Hypergraph.SetCompare]
+// changes the order of a loaded hypergraph. Copies, subhypergraphs and
+// derived graphs keep the order of the hypergraph they came from.
 //
 // # Vertex and Edge Operations
 //
@@ -24,6 +34,27 @@
 //   - [Hypergraph.Vertices], [Hypergraph.Edges] - enumeration
 //   - [Hypergraph.EdgeMembers], [Hypergraph.EdgeSize] - edge queries
 //   - [Hypergraph.VertexDegree] - vertex degree (number of incident edges)
+//   - [Hypergraph.EdgeWeight], [Hypergraph.SetEdgeWeight] - edge weights (default 1)
+//
+// # Neighborhood Queries
+//
+// Iterator-based accessors avoid allocating per call. Each takes an [Order];
+// [Sorted] gives deterministic output at the cost of one allocation:
+//
+//   - [Hypergraph.IncidentEdges], [Hypergraph.Members] - incidence
+//   - [Hypergraph.Neighbors] - vertices sharing an edge
+//   - [Hypergraph.EdgeIntersection] - common members of two edges
+//   - [Hypergraph.KHopNeighborhood] - vertices within k hops, with distances
+//   - [Hypergraph.NeighborhoodHypergraph] - induced k-hop neighborhood
+//
+// # Roles
+//
+// Edges added with [Hypergraph.AddRoleEdge] or [Hypergraph.AddOrderedEdge]
+// record which role each member plays, for n-ary relational data. Query
+// them with [Hypergraph.EdgeRoles], [Hypergraph.RoleMembers],
+// [Hypergraph.VertexRoles] and [Hypergraph.EdgesWithRole]. Roles survive
+// copies, subhypergraphs and JSON; [Hypergraph.Dual] keeps them on the dual
+// edges and [Hypergraph.TwoSection] records them as [EdgeAnnotation] values.
 //
 // # Graph Algorithms
 //
@@ -31,8 +62,21 @@
 //
 //   - [Hypergraph.GreedyHittingSet] - approximates minimum hitting set
 //   - [Hypergraph.EnumerateMinimalTransversals] - enumerates all minimal transversals
+//   - [Hypergraph.MinimumVertexCover] - minimum transversal by branch and bound
+//   - [Hypergraph.GreedyStrongIndependentSet], [Hypergraph.MaximumStrongIndependentSet] -
+//     vertices no two of which share an edge
+//   - [Hypergraph.GreedyWeakIndependentSet], [Hypergraph.MaximumWeakIndependentSet] -
+//     vertices containing no edge; complements of transversals
 //   - [Hypergraph.GreedyColoring] - computes a vertex coloring
+//   - [Hypergraph.Coreness], [Hypergraph.Degeneracy] - core numbers by peeling
+//   - [Hypergraph.KCore], [Hypergraph.KLCore] - k-cores and (k,l)-cores
 //   - [Hypergraph.ConnectedComponents] - finds connected components
+//   - [Hypergraph.Distances] - hop distances from a vertex
+//   - [Hypergraph.Betweenness] - betweenness centrality of vertices
+//   - [Hypergraph.Modularity], [Hypergraph.Louvain] - community detection
+//   - [Hypergraph.MinSTCut], [Hypergraph.GlobalMinCut] - minimum hyperedge cuts
+//   - [Hypergraph.GreedyEdgeCover], [Hypergraph.LPRoundingEdgeCover],
+//     [Hypergraph.ExactEdgeCover] - minimum-weight edge covers
 //
 // # Transformations
 //
@@ -41,6 +85,85 @@
 //   - [Hypergraph.Dual] - swaps vertices and edges
 //   - [Hypergraph.TwoSection] - projects to ordinary graph
 //   - [Hypergraph.Primal] - synonym for TwoSection
+//   - [Hypergraph.InducedSubhypergraph] - restricts to a vertex set
+//   - [Hypergraph.StarExpansion] - bipartite incidence graph
+//   - [FromBipartite], [FromStarExpansion] - rebuild from an incidence graph
+//   - [FromMaximalCliques] - one edge per maximal clique of a graph
+//
+// # Graphs
+//
+// [Graph] is the simple undirected graph returned by the projections. It
+// supports [Graph.AddEdge], [Graph.Neighbors], [Graph.BFS],
+// [Graph.ShortestPath], [Graph.ConnectedComponents] and JSON I/O with
+// [Graph.SaveJSON] and [LoadGraphJSON].
+//
+// # Statistics
+//
+// [Hypergraph.Stats] gathers degree and edge size distributions,
+// [Hypergraph.Density], the joint degree–size distribution, clustering
+// coefficients ([Hypergraph.Clustering], [Hypergraph.HigherOrderClustering]),
+// [Hypergraph.DegreeAssortativity] and component sizes into a [Stats]
+// value that marshals to JSON.
+//
+// # Structural Properties
+//
+// Each check reports whether the property holds and, if not, a witness:
+//
+//   - [Hypergraph.IsKUniform] - an edge of another size
+//   - [Hypergraph.IsLinear] - two edges sharing two or more vertices
+//   - [Hypergraph.IsSimple] - an edge contained in another (Sperner)
+//   - [Hypergraph.IsConformal] - a maximal primal clique in no edge
+//   - [Hypergraph.IsHelly] - pairwise intersecting edges with no common vertex
+//   - [Hypergraph.IsBalanced] - an odd [SpecialCycle]
+//
+// # Pattern Matching
+//
+// [FindEmbeddings] lists the embeddings of a small pattern hypergraph in a
+// larger one, constrained by [MatchOptions] predicates on vertices and
+// edges. [Hypergraph.EdgeMotifCounts] counts the connected configurations
+// of three edges by the shape of their Venn diagram ([EdgeMotif]), exactly
+// or from a sample of edges.
+//
+// # Spreading Processes
+//
+// [Hypergraph.SimulateContagion] runs SIS and SIR processes ([ModelSIS],
+// [ModelSIR]) in which an edge transmits only while a threshold of its
+// members is infected, with linear or nonlinear infectivity. Vertices move
+// between [StateSusceptible], [StateInfected] and [StateRecovered]. [Hypergraph.SimulateThreshold] and
+// [Hypergraph.SimulateCascade] run the linear threshold and independent
+// cascade models, and [Hypergraph.HeatDiffusion] evolves heat under the
+// hypergraph Laplacian. Each run is seeded and returns a [Trajectory] of
+// per-step counts; [MonteCarlo] repeats a run over consecutive seeds in
+// parallel.
+//
+// # Robustness
+//
+// [Hypergraph.VertexPercolation] and [Hypergraph.EdgePercolation] remove
+// vertices or edges one at a time, at random or by degree, coreness or
+// betweenness ([RemovalStrategy]), and return the curve of
+// [PercolationPoint] values: the largest component, the number of
+// components and, optionally, s-connectivity after each removal. The
+// curve is computed backward with union-find in near-linear time.
+//
+// # Topology
+//
+// [Hypergraph.SimplicialComplex] returns the downward closure of a
+// hypergraph as a [SimplicialComplex], which exposes boundary matrices,
+// Z/2 Betti numbers, the Euler characteristic and homology generators.
+//
+// # Temporal Hypergraphs
+//
+// [TemporalHypergraph] attaches activation [Interval] values to edges. It
+// slices to an ordinary hypergraph with [TemporalHypergraph.Snapshot],
+// [TemporalHypergraph.Window] and [TemporalHypergraph.Aggregate], and
+// finds time-respecting paths with [TemporalHypergraph.EarliestArrival].
+//
+// # Rendering
+//
+// [Hypergraph.RenderSVG] writes an SVG drawing laid out by a seeded
+// force-directed layout of the star expansion. [RenderOptions] selects the
+// edge style ([StarStyle] or [HullStyle]), labels, class colors and size
+// limits.
 //
 // # Serialization
 //
@@ -49,18 +172,59 @@
 //   - [Hypergraph.SaveJSON] - writes to io.Writer
 //   - [LoadJSON] - reads from io.Reader
 //
+// For hypergraphs too large to hold twice in memory, [Hypergraph.WriteNDJSON]
+// and [LoadNDJSON] stream a line-delimited format with one vertex or edge
+// per line. [WriteNDJSONFunc] with [Unordered] skips sorting, so it writes
+// in memory that does not grow with the hypergraph.
+//
+// [ValidateJSON] checks a document without stopping at the first problem
+// and reports each as a [Diagnostic] with its line and column.
+//
+// Files of hypergraphs with integer vertices record [VertexTypeInt] under
+// "vertex_type", so that loading one with string vertices fails clearly
+// instead of with a decoding error. [MapVertices] converts between vertex
+// types, and [SaveJSONFunc], [LoadJSONFunc], [WriteNDJSONFunc] and
+// [LoadNDJSONFunc] convert while writing or reading, without a copy.
+//
+// # Persistence
+//
+// [Open] returns a [Store], a hypergraph kept in a directory. Mutations
+// through the store are appended to a write-ahead log, which is compacted
+// into a snapshot every [StoreOptions.SnapshotEvery] records; reopening
+// the store replays the log after the snapshot. A store locks its
+// directory until it is closed, so only one store, in one process, writes
+// to it.
+//
+// # Diff and Merge
+//
+// [Diff] returns the structural changes between two hypergraphs as a
+// [Patch], which [Hypergraph.ApplyPatch] replays and [Patch.SaveJSON]
+// stores. [Merge] combines two descendants of a common base, reporting
+// edits it cannot reconcile as [MergeConflict] values.
+//
 // # Thread Safety
 //
 // Hypergraph is NOT safe for concurrent use. If multiple goroutines access
 // a Hypergraph concurrently, and at least one modifies it, external
 // synchronization is required.
 //
+// [Hypergraph.ParallelBFS], [Hypergraph.ParallelConnectedComponents],
+// [Hypergraph.ParallelColoring] and [Hypergraph.ParallelLineGraph] spread
+// read-only work over a configurable number of goroutines. Their results
+// do not depend on the worker count or on scheduling.
+//
 // # Error Handling
 //
 // Operations that can fail return errors:
 //
 //   - [ErrDuplicateEdge] - returned by AddEdge if edge ID exists
-//   - [ErrCutoff] - returned by EnumerateMinimalTransversals when limits reached
+//   - [ErrEdgeNotFound] - returned by SetEdgeWeight for unknown edges
+//   - [ErrInfeasible] - returned by the edge cover solvers when some vertex is in no edge
+//   - [ErrCutoff] - returned by EnumerateMinimalTransversals, the exact solvers, IsBalanced and FindEmbeddings when limits reached
+//   - [ErrTooLarge] - returned by RenderSVG when the input exceeds its size limits
+//   - [ErrPatchConflict] - returned by ApplyPatch when the patch does not match
+//   - [ErrCorruptStore] - returned by Open when a store's snapshot or log is damaged
+//   - [ErrStoreLocked] - returned by Open when another store has the directory open
 //
 // # Example
 //
@@ -5,6 +5,18 @@ import "errors"
 var (
 	// ErrDuplicateEdge is returned when adding an edge with an existing ID.
 	ErrDuplicateEdge = errors.New("duplicate edge ID")
+	// ErrEdgeNotFound is returned when an operation names an edge that does not exist.
+	ErrEdgeNotFound = errors.New("edge not found")
+	// ErrInfeasible indicates a problem instance has no solution.
+	ErrInfeasible = errors.New("no feasible solution")
 	// ErrCutoff indicates an algorithm terminated early due to a configured cutoff.
 	ErrCutoff = errors.New("operation cutoff reached")
+	// ErrTooLarge indicates an input exceeds a configured size limit.
+	ErrTooLarge = errors.New("input too large")
+	// ErrPatchConflict indicates a patch does not match the hypergraph it is applied to.
+	ErrPatchConflict = errors.New("patch conflict")
+	// ErrCorruptStore indicates a store's snapshot or log is damaged beyond recovery.
+	ErrCorruptStore = errors.New("corrupt store")
+	// ErrStoreLocked indicates a store directory is already open in another store.
+	ErrStoreLocked = errors.New("store is locked")
 )
@@ -0,0 +1,463 @@
+package hypergraph
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Graph represents a simple undirected graph: no self-loops and at most one
+// edge between two vertices.
+type Graph[V comparable] struct {
+	adj         map[V]map[V]struct{}
+	annotations map[[2]V][]EdgeAnnotation // keyed by {smaller, larger}
+	compare     func(a, b V) int
+}
+
+// EdgeAnnotation records the roles two endpoints of a 2-section edge play in
+// one role-labeled hyperedge they share. FromRole belongs to the smaller
+// endpoint.
+type EdgeAnnotation struct {
+	Edge     string `json:"edge"`
+	FromRole string `json:"from_role"`
+	ToRole   string `json:"to_role"`
+}
+
+// NewGraph creates a new graph whose vertices sort in their natural order.
+func NewGraph[V comparable]() *Graph[V] {
+	return NewGraphFunc(defaultCompare[V]())
+}
+
+// NewGraphFunc creates a new graph whose vertices sort by compare.
+func NewGraphFunc[V comparable](compare func(a, b V) int) *Graph[V] {
+	return &Graph[V]{
+		adj:         make(map[V]map[V]struct{}),
+		annotations: make(map[[2]V][]EdgeAnnotation),
+		compare:     compare,
+	}
+}
+
+// edgeKey returns the annotation key of the edge between u and v.
+func (g *Graph[V]) edgeKey(u, v V) [2]V {
+	if g.compare(u, v) > 0 {
+		u, v = v, u
+	}
+	return [2]V{u, v}
+}
+
+// AddVertex adds a vertex. Adding an existing vertex has no effect.
+func (g *Graph[V]) AddVertex(v V) {
+	if _, ok := g.adj[v]; !ok {
+		g.adj[v] = make(map[V]struct{})
+	}
+}
+
+// AddEdge adds an undirected edge between u and v, adding the endpoints if
+// needed. Adding an existing edge has no effect; self-loops are rejected.
+func (g *Graph[V]) AddEdge(u, v V) error {
+	if u == v {
+		return fmt.Errorf("self-loop on %v", u)
+	}
+	g.AddVertex(u)
+	g.AddVertex(v)
+	g.adj[u][v] = struct{}{}
+	g.adj[v][u] = struct{}{}
+	return nil
+}
+
+// RemoveEdge removes the edge between u and v and its annotations, if any.
+func (g *Graph[V]) RemoveEdge(u, v V) {
+	delete(g.adj[u], v)
+	delete(g.adj[v], u)
+	delete(g.annotations, g.edgeKey(u, v))
+}
+
+// RemoveVertex removes a vertex and its incident edges.
+func (g *Graph[V]) RemoveVertex(v V) {
+	for u := range g.adj[v] {
+		g.RemoveEdge(v, u)
+	}
+	delete(g.adj, v)
+}
+
+// HasVertex reports whether v is a vertex of the graph.
+func (g *Graph[V]) HasVertex(v V) bool {
+	_, ok := g.adj[v]
+	return ok
+}
+
+// HasEdge reports whether u and v are adjacent.
+func (g *Graph[V]) HasEdge(u, v V) bool {
+	_, ok := g.adj[u][v]
+	return ok
+}
+
+// NumVertices returns the number of vertices.
+func (g *Graph[V]) NumVertices() int {
+	return len(g.adj)
+}
+
+// NumEdges returns the number of edges.
+func (g *Graph[V]) NumEdges() int {
+	n := 0
+	for _, nbrs := range g.adj {
+		n += len(nbrs)
+	}
+	return n / 2
+}
+
+// Degree returns the number of neighbors of v.
+func (g *Graph[V]) Degree(v V) int {
+	return len(g.adj[v])
+}
+
+// Neighbors returns the vertices adjacent to v. The graph must not be
+// modified during iteration.
+func (g *Graph[V]) Neighbors(v V, order Order) iter.Seq[V] {
+	return inOrder(maps.Keys(g.adj[v]), order, g.compare)
+}
+
+// Vertices returns all vertices of the graph.
+func (g *Graph[V]) Vertices() []V {
+	return slices.Collect(maps.Keys(g.adj))
+}
+
+// Edges returns all edges of the graph as pairs with From < To, sorted.
+func (g *Graph[V]) Edges() []struct{ From, To V } {
+	var es []struct{ From, To V }
+	for _, u := range g.sorted(maps.Keys(g.adj)) {
+		for v := range g.Neighbors(u, Sorted) {
+			if g.compare(u, v) < 0 {
+				es = append(es, struct{ From, To V }{u, v})
+			}
+		}
+	}
+	return es
+}
+
+// Annotations returns the role annotations of the edge between u and v, in
+// either order, sorted by hyperedge ID and then roles. FromRole is the role
+// of the smaller of u and v. It returns nil for unannotated or missing
+// edges.
+func (g *Graph[V]) Annotations(u, v V) []EdgeAnnotation {
+	return slices.Clone(g.annotations[g.edgeKey(u, v)])
+}
+
+// BFS performs breadth-first search starting from a vertex, returning
+// reachable vertices in visit order. Neighbors are visited in ascending
+// order, so the result is deterministic.
+func (g *Graph[V]) BFS(start V) []V {
+	order, _ := g.bfs(start)
+	return order
+}
+
+// Distances returns the hop distance from start to every reachable vertex.
+// Unreachable vertices are absent from the result; it is nil if start is
+// not a vertex.
+func (g *Graph[V]) Distances(start V) map[V]int {
+	if !g.HasVertex(start) {
+		return nil
+	}
+	order, parent := g.bfs(start)
+	dist := make(map[V]int, len(order))
+	dist[start] = 0
+	for _, v := range order[1:] {
+		dist[v] = dist[parent[v]] + 1
+	}
+	return dist
+}
+
+// ShortestPath returns a path with the fewest edges from "from" to "to",
+// including both endpoints, and false if there is none.
+func (g *Graph[V]) ShortestPath(from, to V) ([]V, bool) {
+	if !g.HasVertex(from) || !g.HasVertex(to) {
+		return nil, false
+	}
+	_, parent := g.bfs(from)
+	if _, ok := parent[to]; !ok && to != from {
+		return nil, false
+	}
+	path := []V{to}
+	for v := to; v != from; {
+		v = parent[v]
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+func (g *Graph[V]) bfs(start V) ([]V, map[V]V) {
+	if !g.HasVertex(start) {
+		return nil, nil
+	}
+	parent := make(map[V]V)
+	order := []V{start}
+	for i := 0; i < len(order); i++ {
+		cur := order[i]
+		for u := range g.Neighbors(cur, Sorted) {
+			if _, seen := parent[u]; seen || u == start {
+				continue
+			}
+			parent[u] = cur
+			order = append(order, u)
+		}
+	}
+	return order, parent
+}
+
+// ConnectedComponents returns the connected components, each sorted, in
+// order of their smallest vertex.
+func (g *Graph[V]) ConnectedComponents() [][]V {
+	visited := make(map[V]bool, len(g.adj))
+	var components [][]V
+	for _, v := range g.sorted(maps.Keys(g.adj)) {
+		if visited[v] {
+			continue
+		}
+		component := g.BFS(v)
+		for _, u := range component {
+			visited[u] = true
+		}
+		slices.SortFunc(component, g.compare)
+		components = append(components, component)
+	}
+	return components
+}
+
+// graphAnnotationJSON is one entry of the "annotations" list in graph JSON.
+type graphAnnotationJSON[V comparable] struct {
+	From V `json:"from"`
+	To   V `json:"to"`
+	EdgeAnnotation
+}
+
+// SaveJSON writes the graph as {"vertices": [...], "edges": [[u, v], ...]},
+// with vertices and edges sorted and each edge's smaller endpoint first.
+// Role annotations, if any, are written under "annotations".
+func (g *Graph[V]) SaveJSON(w io.Writer) error {
+	vertices := g.sorted(maps.Keys(g.adj))
+	edges := make([][2]V, 0)
+	var anns []graphAnnotationJSON[V]
+	for _, e := range g.Edges() {
+		edges = append(edges, [2]V{e.From, e.To})
+		for _, a := range g.annotations[[2]V{e.From, e.To}] {
+			anns = append(anns, graphAnnotationJSON[V]{e.From, e.To, a})
+		}
+	}
+	data := map[string]interface{}{
+		"vertices": vertices,
+		"edges":    edges,
+	}
+	if len(anns) > 0 {
+		data["annotations"] = anns
+	}
+	return json.NewEncoder(w).Encode(data)
+}
+
+// LoadGraphJSON reads a graph written by [Graph.SaveJSON].
+func LoadGraphJSON[V comparable](r io.Reader) (*Graph[V], error) {
+	var data struct {
+		Vertices    []V                      `json:"vertices"`
+		Edges       [][]V                    `json:"edges"`
+		Annotations []graphAnnotationJSON[V] `json:"annotations"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	g := NewGraph[V]()
+	for _, v := range data.Vertices {
+		g.AddVertex(v)
+	}
+	for _, e := range data.Edges {
+		if len(e) != 2 {
+			return nil, fmt.Errorf("edge %v: want 2 endpoints, got %d", e, len(e))
+		}
+		if err := g.AddEdge(e[0], e[1]); err != nil {
+			return nil, err
+		}
+	}
+	for _, a := range data.Annotations {
+		if !g.HasEdge(a.From, a.To) {
+			return nil, fmt.Errorf("annotation on missing edge %v-%v", a.From, a.To)
+		}
+		if g.compare(a.From, a.To) > 0 {
+			a.From, a.To = a.To, a.From
+			a.FromRole, a.ToRole = a.ToRole, a.FromRole
+		}
+		key := [2]V{a.From, a.To}
+		g.annotations[key] = append(g.annotations[key], a.EdgeAnnotation)
+	}
+	for _, anns := range g.annotations {
+		sortAnnotations(anns)
+	}
+	return g, nil
+}
+
+func sortAnnotations(anns []EdgeAnnotation) {
+	slices.SortFunc(anns, func(a, b EdgeAnnotation) int {
+		return cmp.Or(cmp.Compare(a.Edge, b.Edge), cmp.Compare(a.FromRole, b.FromRole), cmp.Compare(a.ToRole, b.ToRole))
+	})
+}
+
+// Node name prefixes used by [Hypergraph.StarExpansion].
+const (
+	StarVertexPrefix = "v:"
+	StarEdgePrefix   = "e:"
+)
+
+// StarExpansion returns the star expansion (incidence graph) of h: a
+// bipartite graph with a node "v:<vertex>" for every vertex, a node
+// "e:<id>" for every edge, and an edge between them whenever the vertex
+// is a member of the edge. Vertices are formatted with fmt. Isolated
+// vertices become isolated nodes. [FromStarExpansion] reverses it.
+func (h *Hypergraph[V]) StarExpansion() *Graph[string] {
+	g := NewGraph[string]()
+	for v := range h.vertices {
+		g.AddVertex(StarVertexPrefix + fmt.Sprint(v))
+	}
+	for id, e := range h.edges {
+		en := StarEdgePrefix + id
+		g.AddVertex(en)
+		for v := range e.Set {
+			_ = g.AddEdge(StarVertexPrefix+fmt.Sprint(v), en)
+		}
+	}
+	return g
+}
+
+// FromBipartite builds a hypergraph from a bipartite graph. Nodes for which
+// isEdge returns true become edges, with ID fmt.Sprint(node) and the
+// adjacent nodes as members; all other nodes become vertices. It returns
+// an error if an edge joins two nodes on the same side, if an edge node
+// has no neighbors, or if two edge nodes format to the same ID.
+func FromBipartite[V comparable](g *Graph[V], isEdge func(V) bool) (*Hypergraph[V], error) {
+	h := NewHypergraphFunc(g.compare)
+	for _, n := range g.sorted(maps.Keys(g.adj)) {
+		if !isEdge(n) {
+			h.AddVertex(n)
+		}
+	}
+	for _, n := range g.sorted(maps.Keys(g.adj)) {
+		if !isEdge(n) {
+			continue
+		}
+		members := slices.Collect(g.Neighbors(n, Sorted))
+		for _, m := range members {
+			if isEdge(m) {
+				return nil, fmt.Errorf("graph is not bipartite: edge nodes %v and %v are adjacent", n, m)
+			}
+		}
+		id := fmt.Sprint(n)
+		if err := h.AddEdge(id, members); err != nil {
+			return nil, fmt.Errorf("edge node %v: %w", n, err)
+		}
+	}
+	return h, nil
+}
+
+// FromStarExpansion reverses [Hypergraph.StarExpansion]: nodes prefixed
+// with "e:" become edges and nodes prefixed with "v:" become vertices, with
+// the prefixes removed. Any other node name is an error.
+func FromStarExpansion(g *Graph[string]) (*Hypergraph[string], error) {
+	names := make(map[string]string, len(g.adj))
+	for n := range g.adj {
+		name, ok := strings.CutPrefix(n, StarVertexPrefix)
+		if !ok {
+			name, ok = strings.CutPrefix(n, StarEdgePrefix)
+		}
+		if !ok {
+			return nil, fmt.Errorf("node %q has neither %q nor %q prefix", n, StarVertexPrefix, StarEdgePrefix)
+		}
+		names[n] = name
+	}
+	isEdge := func(n string) bool {
+		return strings.HasPrefix(n, StarEdgePrefix)
+	}
+	raw, err := FromBipartite(g, isEdge)
+	if err != nil {
+		return nil, err
+	}
+	h := NewHypergraph[string]()
+	for v := range raw.vertices {
+		h.AddVertex(names[v])
+	}
+	for id, e := range raw.edges {
+		members := make([]string, 0, len(e.Set))
+		for v := range e.Set {
+			members = append(members, names[v])
+		}
+		if err := h.AddEdge(names[id], members); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// FromMaximalCliques returns the hypergraph whose edges are the maximal
+// cliques of g, found with the Bron–Kerbosch algorithm with pivoting.
+// Each clique's members are sorted, cliques are ordered lexicographically
+// and named "c0", "c1", ... in that order. Isolated vertices form
+// single-vertex cliques, so the 2-section of the result is g again.
+// Time complexity: O(3^(n/3)) in the worst case.
+func FromMaximalCliques[V comparable](g *Graph[V]) *Hypergraph[V] {
+	var cliques [][]V
+	var expand func(r, p, x []V)
+	expand = func(r, p, x []V) {
+		if len(p) == 0 {
+			if len(x) == 0 {
+				cliques = append(cliques, g.sorted(slices.Values(r)))
+			}
+			return
+		}
+		// Pivot on the vertex of P ∪ X with the most neighbors in P.
+		pivot, best := p[0], -1
+		for _, u := range slices.Concat(p, x) {
+			n := 0
+			for _, w := range p {
+				if g.HasEdge(u, w) {
+					n++
+				}
+			}
+			if n > best {
+				pivot, best = u, n
+			}
+		}
+		for _, v := range slices.Clone(p) {
+			if g.HasEdge(pivot, v) {
+				continue
+			}
+			expand(append(slices.Clone(r), v), g.keepAdjacent(p, v), g.keepAdjacent(x, v))
+			p = slices.DeleteFunc(p, func(u V) bool { return u == v })
+			x = append(x, v)
+		}
+	}
+	expand(nil, g.sorted(maps.Keys(g.adj)), nil)
+	slices.SortFunc(cliques, func(a, b []V) int { return slices.CompareFunc(a, b, g.compare) })
+
+	h := NewHypergraphFunc(g.compare)
+	for i, c := range cliques {
+		_ = h.AddEdge(fmt.Sprintf("c%d", i), c)
+	}
+	return h
+}
+
+// sorted collects seq into a slice in vertex order.
+func (g *Graph[V]) sorted(seq iter.Seq[V]) []V {
+	return slices.SortedFunc(seq, g.compare)
+}
+
+// keepAdjacent returns the elements of s adjacent to v, in order.
+func (g *Graph[V]) keepAdjacent(s []V, v V) []V {
+	var out []V
+	for _, u := range s {
+		if g.HasEdge(u, v) {
+			out = append(out, u)
+		}
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package hypergraph
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// pathGraph builds A-B-C-D plus the separate edge X-Y and isolated vertex Z.
+func pathGraph(t *testing.T) *Graph[string] {
+	t.Helper()
+	g := NewGraph[string]()
+	for _, e := range [][2]string{{"A", "B"}, {"B", "C"}, {"C", "D"}, {"X", "Y"}} {
+		if err := g.AddEdge(e[0], e[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.AddVertex("Z")
+	return g
+}
+
+// ============================================================================
+// Graph Structure Tests
+// ============================================================================
+
+func TestGraph_AddRemove(t *testing.T) {
+	t.Parallel()
+	g := pathGraph(t)
+
+	if g.NumVertices() != 7 || g.NumEdges() != 4 {
+		t.Fatalf("got %d vertices, %d edges; want 7, 4", g.NumVertices(), g.NumEdges())
+	}
+	if err := g.AddEdge("A", "A"); err == nil {
+		t.Error("self-loop accepted")
+	}
+	if err := g.AddEdge("B", "A"); err != nil || g.NumEdges() != 4 {
+		t.Errorf("duplicate edge: err=%v, edges=%d", err, g.NumEdges())
+	}
+	if !g.HasEdge("B", "A") || g.HasEdge("A", "C") {
+		t.Error("HasEdge wrong")
+	}
+	if g.Degree("B") != 2 || g.Degree("Z") != 0 || g.Degree("missing") != 0 {
+		t.Error("Degree wrong")
+	}
+	if got := slices.Collect(g.Neighbors("B", Sorted)); !slices.Equal(got, []string{"A", "C"}) {
+		t.Errorf("Neighbors(B) = %v", got)
+	}
+
+	g.RemoveEdge("C", "B")
+	if g.HasEdge("B", "C") || g.NumEdges() != 3 {
+		t.Error("RemoveEdge failed")
+	}
+	g.RemoveVertex("X")
+	if g.HasVertex("X") || g.HasEdge("Y", "X") || g.Degree("Y") != 0 {
+		t.Error("RemoveVertex left edges behind")
+	}
+}
+
+// ============================================================================
+// Graph Traversal Tests
+// ============================================================================
+
+func TestGraph_Traversal(t *testing.T) {
+	t.Parallel()
+	g := pathGraph(t)
+
+	if got := g.BFS("B"); !slices.Equal(got, []string{"B", "A", "C", "D"}) {
+		t.Errorf("BFS(B) = %v", got)
+	}
+	if g.BFS("missing") != nil {
+		t.Error("BFS of missing vertex should be nil")
+	}
+	want := map[string]int{"A": 0, "B": 1, "C": 2, "D": 3}
+	if got := g.Distances("A"); !maps.Equal(got, want) {
+		t.Errorf("Distances(A) = %v, want %v", got, want)
+	}
+	if path, ok := g.ShortestPath("D", "A"); !ok || !slices.Equal(path, []string{"D", "C", "B", "A"}) {
+		t.Errorf("ShortestPath(D, A) = %v, %v", path, ok)
+	}
+	if path, ok := g.ShortestPath("A", "A"); !ok || !slices.Equal(path, []string{"A"}) {
+		t.Errorf("ShortestPath(A, A) = %v, %v", path, ok)
+	}
+	if _, ok := g.ShortestPath("A", "X"); ok {
+		t.Error("path found between components")
+	}
+
+	comps := g.ConnectedComponents()
+	wantComps := [][]string{{"A", "B", "C", "D"}, {"X", "Y"}, {"Z"}}
+	if !slices.EqualFunc(comps, wantComps, slices.Equal) {
+		t.Errorf("ConnectedComponents = %v, want %v", comps, wantComps)
+	}
+}
+
+// ============================================================================
+// Graph Serialization Tests
+// ============================================================================
+
+func TestGraph_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	g := facts(t).TwoSection()
+
+	var buf bytes.Buffer
+	if err := g.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadGraphJSON[string](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(loaded.Edges(), g.Edges()) {
+		t.Errorf("edges = %v, want %v", loaded.Edges(), g.Edges())
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(loaded.Vertices())), slices.Sorted(slices.Values(g.Vertices()))) {
+		t.Errorf("vertices = %v", loaded.Vertices())
+	}
+	for _, e := range g.Edges() {
+		if !slices.Equal(loaded.Annotations(e.From, e.To), g.Annotations(e.From, e.To)) {
+			t.Errorf("annotations %s-%s = %v, want %v", e.From, e.To,
+				loaded.Annotations(e.From, e.To), g.Annotations(e.From, e.To))
+		}
+	}
+}
+
+func TestLoadGraphJSON_Invalid(t *testing.T) {
+	t.Parallel()
+	for name, in := range map[string]string{
+		"malformed":          `{"edges": [`,
+		"self-loop":          `{"edges": [["A", "A"]]}`,
+		"three endpoints":    `{"edges": [["A", "B", "C"]]}`,
+		"annotation no edge": `{"vertices": ["A", "B"], "annotations": [{"from": "A", "to": "B", "edge": "e"}]}`,
+	} {
+		if _, err := LoadGraphJSON[string](bytes.NewBufferString(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+// ============================================================================
+// Star Expansion and Reconstruction Tests
+// ============================================================================
+
+func TestStarExpansion_RoundTrip(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+	h.AddVertex("lonely")
+
+	g := h.StarExpansion()
+	if g.NumVertices() != 7 || g.NumEdges() != 5 {
+		t.Fatalf("star expansion has %d nodes, %d edges; want 7, 5", g.NumVertices(), g.NumEdges())
+	}
+	if !g.HasEdge("v:C", "e:E2") || g.HasEdge("v:A", "e:E2") {
+		t.Error("incidence edges wrong")
+	}
+
+	back, err := FromStarExpansion(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want, got bytes.Buffer
+	_ = h.SaveJSON(&want)
+	_ = back.SaveJSON(&got)
+	if got.String() != want.String() {
+		t.Errorf("round trip = %s, want %s", got.String(), want.String())
+	}
+}
+
+func TestFromBipartite_Errors(t *testing.T) {
+	t.Parallel()
+	isEdge := func(n int) bool { return n >= 100 }
+
+	g := NewGraph[int]()
+	_ = g.AddEdge(100, 1)
+	_ = g.AddEdge(100, 2)
+	_ = g.AddEdge(101, 2)
+	h, err := FromBipartite(g, isEdge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.EdgeMembers("100"); !slices.Equal(slices.Sorted(slices.Values(got)), []int{1, 2}) {
+		t.Errorf("edge 100 = %v", got)
+	}
+
+	_ = g.AddEdge(100, 101)
+	if _, err := FromBipartite(g, isEdge); err == nil {
+		t.Error("adjacent edge nodes accepted")
+	}
+
+	g = NewGraph[int]()
+	g.AddVertex(100)
+	if _, err := FromBipartite(g, isEdge); err == nil {
+		t.Error("edge node with no members accepted")
+	}
+
+	s := NewGraph[string]()
+	_ = s.AddEdge("v:A", "B")
+	if _, err := FromStarExpansion(s); err == nil {
+		t.Error("unprefixed node accepted")
+	}
+}
+
+func TestFromMaximalCliques(t *testing.T) {
+	t.Parallel()
+	// Two triangles sharing B-C, a pendant D-E and an isolated F.
+	g := NewGraph[string]()
+	for _, e := range [][2]string{{"A", "B"}, {"A", "C"}, {"B", "C"}, {"B", "D"}, {"C", "D"}, {"D", "E"}} {
+		_ = g.AddEdge(e[0], e[1])
+	}
+	g.AddVertex("F")
+
+	h := FromMaximalCliques(g)
+	want := [][]string{{"A", "B", "C"}, {"B", "C", "D"}, {"D", "E"}, {"F"}}
+	if h.NumEdges() != len(want) {
+		t.Fatalf("got %d cliques, want %d", h.NumEdges(), len(want))
+	}
+	for i, c := range want {
+		got := slices.Collect(h.Members(fmt.Sprintf("c%d", i), Sorted))
+		if !slices.Equal(got, c) {
+			t.Errorf("c%d = %v, want %v", i, got, c)
+		}
+	}
+}
+
+func TestFromMaximalCliques_TwoSectionRoundTrip(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewPCG(7, 7))
+	for trial := range 20 {
+		g := NewGraph[int]()
+		n := 2 + r.IntN(10)
+		for u := range n {
+			g.AddVertex(u)
+			for v := range u {
+				if r.IntN(2) == 0 {
+					_ = g.AddEdge(u, v)
+				}
+			}
+		}
+		h := FromMaximalCliques(g)
+		if got := h.TwoSection(); !slices.Equal(got.Edges(), g.Edges()) || got.NumVertices() != n {
+			t.Fatalf("trial %d: 2-section of cliques differs from graph", trial)
+		}
+		// Every clique is maximal: no outside vertex is adjacent to all members.
+		for _, id := range h.Edges() {
+			members := h.EdgeMembers(id)
+			for v := range n {
+				if slices.Contains(members, v) {
+					continue
+				}
+				if !slices.ContainsFunc(members, func(u int) bool { return !g.HasEdge(u, v) }) {
+					t.Fatalf("trial %d: clique %v extends by %d", trial, members, v)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package hgclient is a client for hg serve, which holds hypergraphs in
+// memory and runs hg commands against them over HTTP and JSON:
+//
+//	GET  /graphs              list the hypergraphs
+//	POST /graphs/NAME/exec    run {"command": "..."} and return {"output": "..."}
+//	POST /graphs/NAME/save    write the hypergraph back to its file
+//
+// A failed command returns status 422 with its message in "error".
+//
+// Usage:
+//
+//	c := hgclient.New("http://127.0.0.1:7070")
+//	out, err := c.Exec("social", "add-edge e9 alice,bob")
+package hgclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GraphInfo describes a served hypergraph in GET /graphs.
+type GraphInfo struct {
+	Name     string `json:"name"`
+	File     string `json:"file"`
+	Vertices int    `json:"vertices"`
+	Edges    int    `json:"edges"`
+	Modified bool   `json:"modified"`
+}
+
+// ExecRequest is the body of POST /graphs/NAME/exec.
+type ExecRequest struct {
+	Command string `json:"command"`
+}
+
+// ExecResponse is the reply to POST /graphs/NAME/exec and /save. Error is
+// set when the command or the save failed.
+type ExecResponse struct {
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Client calls an hg serve server.
+type Client struct {
+	base string
+	// HTTPClient sends the requests. New sets it to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// New returns a client for the server at base, such as
+// http://127.0.0.1:7070.
+func New(base string) *Client {
+	return &Client{base: strings.TrimSuffix(base, "/"), HTTPClient: http.DefaultClient}
+}
+
+func (c *Client) call(method, path string, body, result any) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.base+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("%s %s: %s: %w", method, path, resp.Status, err)
+	}
+	return nil
+}
+
+// Graphs lists the served hypergraphs.
+func (c *Client) Graphs() ([]GraphInfo, error) {
+	var infos []GraphInfo
+	err := c.call(http.MethodGet, "/graphs", nil, &infos)
+	return infos, err
+}
+
+// Exec runs a command against the named hypergraph and returns its
+// output. A failed command returns what it printed with its error.
+func (c *Client) Exec(graph, command string) (string, error) {
+	var resp ExecResponse
+	if err := c.call(http.MethodPost, "/graphs/"+url.PathEscape(graph)+"/exec", ExecRequest{command}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Error != "" {
+		return resp.Output, errors.New(resp.Error)
+	}
+	return resp.Output, nil
+}
+
+// Save writes the named hypergraph back to its file.
+func (c *Client) Save(graph string) error {
+	var resp ExecResponse
+	if err := c.call(http.MethodPost, "/graphs/"+url.PathEscape(graph)+"/save", nil, &resp); err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package hgclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubServer answers like hg serve for a single hypergraph named "g",
+// echoing each command back as its output and failing "fail".
+func stubServer(t *testing.T) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /graphs", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]GraphInfo{{Name: "g", File: "g.json", Vertices: 3, Edges: 2}})
+	})
+	mux.HandleFunc("POST /graphs/g/exec", func(w http.ResponseWriter, r *http.Request) {
+		var req ExecRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		resp := ExecResponse{Output: req.Command + "\n"}
+		if req.Command == "fail" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			resp.Error = "failed"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	mux.HandleFunc("POST /graphs/g/save", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(ExecResponse{})
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return New(ts.URL + "/")
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	c := stubServer(t)
+
+	infos, err := c.Graphs()
+	if err != nil || len(infos) != 1 || infos[0] != (GraphInfo{Name: "g", File: "g.json", Vertices: 3, Edges: 2}) {
+		t.Errorf("Graphs = %+v, %v", infos, err)
+	}
+	if out, err := c.Exec("g", "vertices"); err != nil || out != "vertices\n" {
+		t.Errorf("Exec = %q, %v", out, err)
+	}
+	if out, err := c.Exec("g", "fail"); err == nil || err.Error() != "failed" || out != "fail\n" {
+		t.Errorf("failed Exec = %q, %v", out, err)
+	}
+	if err := c.Save("g"); err != nil {
+		t.Errorf("Save: %v", err)
+	}
+	// The stub has no other hypergraph, so the reply is not JSON.
+	if _, err := c.Exec("nope", "vertices"); err == nil {
+		t.Error("expected error for a missing endpoint")
+	}
+}
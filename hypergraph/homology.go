@@ -0,0 +1,300 @@
+package hypergraph
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// SimplicialComplex is an abstract simplicial complex over vertices of type V.
+// A k-simplex is a sorted slice of k+1 distinct vertices. Simplices of each
+// dimension are kept in lexicographic order, which fixes the row and column
+// order of the boundary matrices.
+type SimplicialComplex[V comparable] struct {
+	simplices [][][]V // simplices[k] holds the k-simplices, sorted
+	compare   func(a, b V) int
+}
+
+// BoundaryMatrix is a sparse boundary matrix in coordinate format. Entry i
+// has value Vals[i] at (Rows[i], Cols[i]). Rows index (k-1)-simplices and
+// columns index k-simplices, both in [SimplicialComplex.Simplices] order.
+type BoundaryMatrix struct {
+	COO
+	Vals    []int
+	NumRows int
+	NumCols int
+}
+
+// SimplicialComplex returns the downward closure of h: every non-empty
+// subset of every edge becomes a simplex, and isolated vertices become
+// 0-simplices. An edge with n members contributes 2^n - 1 simplices, so
+// maxDim caps the dimension of the simplices generated; pass a negative
+// value to build the full closure.
+func (h *Hypergraph[V]) SimplicialComplex(maxDim int) *SimplicialComplex[V] {
+	var levels [][][]V
+	add := func(s []V) {
+		k := len(s) - 1
+		for len(levels) <= k {
+			levels = append(levels, nil)
+		}
+		levels[k] = append(levels[k], slices.Clone(s))
+	}
+	for v := range h.vertices {
+		add([]V{v})
+	}
+	for id := range h.edges {
+		members := slices.Collect(h.Members(id, Sorted))
+		limit := len(members)
+		if maxDim >= 0 {
+			limit = min(limit, maxDim+1)
+		}
+		// Enumerate subsets of size 2..limit in lexicographic order.
+		var subset []V
+		var rec func(start int)
+		rec = func(start int) {
+			if len(subset) >= 2 {
+				add(subset)
+			}
+			if len(subset) == limit {
+				return
+			}
+			for i := start; i < len(members); i++ {
+				subset = append(subset, members[i])
+				rec(i + 1)
+				subset = subset[:len(subset)-1]
+			}
+		}
+		rec(0)
+	}
+	c := &SimplicialComplex[V]{simplices: levels, compare: h.compare}
+	for k := range levels {
+		slices.SortFunc(levels[k], c.compareSimplices)
+		levels[k] = slices.CompactFunc(levels[k], slices.Equal)
+	}
+	return c
+}
+
+// Dim returns the dimension of the complex, or -1 if it is empty.
+func (c *SimplicialComplex[V]) Dim() int {
+	return len(c.simplices) - 1
+}
+
+// NumSimplices returns the number of k-simplices.
+func (c *SimplicialComplex[V]) NumSimplices(k int) int {
+	if k < 0 || k >= len(c.simplices) {
+		return 0
+	}
+	return len(c.simplices[k])
+}
+
+// Simplices returns the k-simplices in lexicographic order.
+func (c *SimplicialComplex[V]) Simplices(k int) [][]V {
+	if k < 0 || k >= len(c.simplices) {
+		return nil
+	}
+	out := make([][]V, len(c.simplices[k]))
+	for i, s := range c.simplices[k] {
+		out[i] = slices.Clone(s)
+	}
+	return out
+}
+
+// index returns the position of a (k-1)-simplex face in its level.
+func (c *SimplicialComplex[V]) index(face []V) int {
+	i, _ := slices.BinarySearchFunc(c.simplices[len(face)-1], face, c.compareSimplices)
+	return i
+}
+
+// compareSimplices orders simplices lexicographically in vertex order.
+func (c *SimplicialComplex[V]) compareSimplices(a, b []V) int {
+	return slices.CompareFunc(a, b, c.compare)
+}
+
+// Boundary returns the integer boundary matrix of ∂_k, which maps each
+// k-simplex [v0, ..., vk] to the alternating sum of its faces with signs
+// (-1)^i. ∂_0 is the zero map into the trivial group, and ∂_k for k above
+// the dimension of the complex has no columns.
+func (c *SimplicialComplex[V]) Boundary(k int) BoundaryMatrix {
+	m := BoundaryMatrix{NumRows: c.NumSimplices(k - 1), NumCols: c.NumSimplices(k)}
+	if k <= 0 || k >= len(c.simplices) {
+		return m
+	}
+	face := make([]V, 0, k)
+	for col, s := range c.simplices[k] {
+		for i := range s {
+			face = append(append(face[:0], s[:i]...), s[i+1:]...)
+			sign := 1
+			if i%2 == 1 {
+				sign = -1
+			}
+			m.Rows = append(m.Rows, c.index(face))
+			m.Cols = append(m.Cols, col)
+			m.Vals = append(m.Vals, sign)
+		}
+	}
+	return m
+}
+
+// BoundaryZ2 returns the boundary matrix of ∂_k over Z/2, where every
+// nonzero entry is 1.
+func (c *SimplicialComplex[V]) BoundaryZ2(k int) BoundaryMatrix {
+	m := c.Boundary(k)
+	for i := range m.Vals {
+		m.Vals[i] = 1
+	}
+	return m
+}
+
+// EulerCharacteristic returns the alternating sum of simplex counts.
+func (c *SimplicialComplex[V]) EulerCharacteristic() int {
+	chi := 0
+	for k, level := range c.simplices {
+		if k%2 == 0 {
+			chi += len(level)
+		} else {
+			chi -= len(level)
+		}
+	}
+	return chi
+}
+
+// BettiNumbers returns the Z/2 Betti numbers b_0 through b_Dim.
+// For a complex built with a dimension cap, the top Betti number counts
+// all cycles because the higher simplices that would bound them are absent.
+func (c *SimplicialComplex[V]) BettiNumbers() []int {
+	ranks := make([]int, len(c.simplices)+1)
+	for k := 1; k < len(c.simplices); k++ {
+		ranks[k] = newZ2Reducer(c.NumSimplices(k - 1)).rank(c.z2Columns(k))
+	}
+	betti := make([]int, len(c.simplices))
+	for k := range betti {
+		betti[k] = c.NumSimplices(k) - ranks[k] - ranks[k+1]
+	}
+	return betti
+}
+
+// HomologyGenerators returns representative cycles forming a basis of the
+// k-th homology group over Z/2. Each cycle is a list of k-simplices whose
+// boundaries cancel mod 2. For k = 0 there is one vertex per connected
+// component.
+func (c *SimplicialComplex[V]) HomologyGenerators(k int) [][][]V {
+	n := c.NumSimplices(k)
+	if n == 0 {
+		return nil
+	}
+
+	// Boundaries of (k+1)-simplices span B_k.
+	basis := newZ2Reducer(n)
+	for _, col := range c.z2Columns(k + 1) {
+		basis.insert(col)
+	}
+
+	var generators [][][]V
+	for _, z := range c.cycleBasis(k) {
+		if basis.insert(slices.Clone(z)) {
+			var cycle [][]V
+			for i := range n {
+				if z.has(i) {
+					cycle = append(cycle, slices.Clone(c.simplices[k][i]))
+				}
+			}
+			generators = append(generators, cycle)
+		}
+	}
+	return generators
+}
+
+// cycleBasis returns a basis of Z_k = ker ∂_k as vectors over the k-simplices.
+func (c *SimplicialComplex[V]) cycleBasis(k int) []z2Vec {
+	n := c.NumSimplices(k)
+	cols := c.z2Columns(k)
+	reducer := newZ2Reducer(c.NumSimplices(k - 1))
+	combos := make(map[int]z2Vec) // pivot row -> combination of columns
+	var cycles []z2Vec
+	for j := range n {
+		combo := newZ2Vec(n)
+		combo.set(j)
+		v := cols[j]
+		for low := v.low(); low >= 0 && reducer.pivots[low] != nil; low = v.low() {
+			v.xor(reducer.pivots[low])
+			combo.xor(combos[low])
+		}
+		if low := v.low(); low >= 0 {
+			reducer.pivots[low] = v
+			combos[low] = combo
+		} else {
+			cycles = append(cycles, combo)
+		}
+	}
+	return cycles
+}
+
+// z2Columns returns the columns of ∂_k over Z/2 as bit vectors.
+func (c *SimplicialComplex[V]) z2Columns(k int) []z2Vec {
+	m := c.BoundaryZ2(k)
+	cols := make([]z2Vec, m.NumCols)
+	for j := range cols {
+		cols[j] = newZ2Vec(m.NumRows)
+	}
+	for i := range m.Rows {
+		cols[m.Cols[i]].set(m.Rows[i])
+	}
+	return cols
+}
+
+// z2Vec is a bit vector over Z/2.
+type z2Vec []uint64
+
+func newZ2Vec(n int) z2Vec { return make(z2Vec, (n+63)/64) }
+
+func (v z2Vec) set(i int)      { v[i/64] |= 1 << (i % 64) }
+func (v z2Vec) has(i int) bool { return v[i/64]&(1<<(i%64)) != 0 }
+
+func (v z2Vec) xor(w z2Vec) {
+	for i := range v {
+		v[i] ^= w[i]
+	}
+}
+
+// low returns the index of the highest set bit, or -1 for the zero vector.
+func (v z2Vec) low() int {
+	for i := len(v) - 1; i >= 0; i-- {
+		if v[i] != 0 {
+			return i*64 + 63 - bits.LeadingZeros64(v[i])
+		}
+	}
+	return -1
+}
+
+// z2Reducer maintains vectors in echelon form keyed by their lowest entry.
+type z2Reducer struct {
+	pivots []z2Vec
+}
+
+func newZ2Reducer(n int) *z2Reducer {
+	return &z2Reducer{pivots: make([]z2Vec, n)}
+}
+
+// insert reduces v against the stored pivots and stores the remainder. It
+// reports whether v was independent of the vectors inserted so far. v is
+// modified in place.
+func (r *z2Reducer) insert(v z2Vec) bool {
+	for low := v.low(); low >= 0; low = v.low() {
+		if r.pivots[low] == nil {
+			r.pivots[low] = v
+			return true
+		}
+		v.xor(r.pivots[low])
+	}
+	return false
+}
+
+// rank returns the rank of the span of cols, consuming them.
+func (r *z2Reducer) rank(cols []z2Vec) int {
+	rank := 0
+	for _, col := range cols {
+		if r.insert(col) {
+			rank++
+		}
+	}
+	return rank
+}
@@ -0,0 +1,249 @@
+package hypergraph
+
+import (
+	"slices"
+	"testing"
+)
+
+func complexFromEdges(t *testing.T, maxDim int, edges ...[]string) *SimplicialComplex[string] {
+	t.Helper()
+	h := NewHypergraph[string]()
+	for i, e := range edges {
+		if err := h.AddEdge("E"+fmtInt(i), e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return h.SimplicialComplex(maxDim)
+}
+
+// ============================================================================
+// SimplicialComplex Construction Tests
+// ============================================================================
+
+func TestSimplicialComplex_DownwardClosure(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1, []string{"A", "B", "C"}, []string{"C", "D"})
+
+	if sc.Dim() != 2 {
+		t.Fatalf("Dim = %d, want 2", sc.Dim())
+	}
+	wantCounts := []int{4, 4, 1}
+	for k, want := range wantCounts {
+		if got := sc.NumSimplices(k); got != want {
+			t.Errorf("NumSimplices(%d) = %d, want %d", k, got, want)
+		}
+	}
+	want1 := [][]string{{"A", "B"}, {"A", "C"}, {"B", "C"}, {"C", "D"}}
+	if got := sc.Simplices(1); !slices.EqualFunc(got, want1, slices.Equal) {
+		t.Errorf("Simplices(1) = %v, want %v", got, want1)
+	}
+	if sc.Simplices(5) != nil || sc.NumSimplices(-1) != 0 {
+		t.Error("out-of-range dimensions should be empty")
+	}
+}
+
+func TestSimplicialComplex_SharedFacesDeduplicated(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1, []string{"A", "B", "C"}, []string{"A", "B", "D"})
+	// Both triangles share the face {A, B}.
+	if got := sc.NumSimplices(1); got != 5 {
+		t.Errorf("NumSimplices(1) = %d, want 5", got)
+	}
+}
+
+func TestSimplicialComplex_IsolatedVertex(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	h.AddVertex(7)
+	sc := h.SimplicialComplex(-1)
+	if sc.Dim() != 0 || sc.NumSimplices(0) != 1 {
+		t.Errorf("isolated vertex: Dim=%d n0=%d, want 0 and 1", sc.Dim(), sc.NumSimplices(0))
+	}
+	if empty := NewHypergraph[int]().SimplicialComplex(-1); empty.Dim() != -1 {
+		t.Errorf("empty complex Dim = %d, want -1", empty.Dim())
+	}
+}
+
+func TestSimplicialComplex_MaxDim(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, 1, []string{"A", "B", "C", "D"})
+	if sc.Dim() != 1 {
+		t.Fatalf("Dim = %d, want 1", sc.Dim())
+	}
+	if got := sc.NumSimplices(1); got != 6 {
+		t.Errorf("NumSimplices(1) = %d, want 6", got)
+	}
+}
+
+// ============================================================================
+// Boundary Matrix Tests
+// ============================================================================
+
+func TestBoundary_Triangle(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1, []string{"A", "B", "C"})
+	m := sc.Boundary(2)
+	if m.NumRows != 3 || m.NumCols != 1 {
+		t.Fatalf("shape = %dx%d, want 3x1", m.NumRows, m.NumCols)
+	}
+	// ∂[A,B,C] = [B,C] - [A,C] + [A,B]; edge rows are AB=0, AC=1, BC=2.
+	got := make(map[int]int)
+	for i := range m.Rows {
+		got[m.Rows[i]] = m.Vals[i]
+	}
+	want := map[int]int{0: 1, 1: -1, 2: 1}
+	for r, v := range want {
+		if got[r] != v {
+			t.Errorf("entry row %d = %d, want %d", r, got[r], v)
+		}
+	}
+	for _, v := range sc.BoundaryZ2(2).Vals {
+		if v != 1 {
+			t.Errorf("Z/2 entry = %d, want 1", v)
+		}
+	}
+	if m0 := sc.Boundary(0); len(m0.Rows) != 0 || m0.NumCols != 3 {
+		t.Errorf("Boundary(0) should be an empty 0x3 matrix, got %+v", m0)
+	}
+}
+
+func TestBoundary_SquaresToZero(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1, []string{"A", "B", "C", "D"}, []string{"C", "D", "E"})
+	for k := 2; k <= sc.Dim(); k++ {
+		outer := dense(sc.Boundary(k - 1))
+		inner := dense(sc.Boundary(k))
+		for i := range outer {
+			for j := range inner[0] {
+				sum := 0
+				for m := range inner {
+					sum += outer[i][m] * inner[m][j]
+				}
+				if sum != 0 {
+					t.Fatalf("(∂%d ∂%d)[%d][%d] = %d, want 0", k-1, k, i, j, sum)
+				}
+			}
+		}
+	}
+}
+
+func dense(m BoundaryMatrix) [][]int {
+	d := make([][]int, m.NumRows)
+	for i := range d {
+		d[i] = make([]int, m.NumCols)
+	}
+	for i := range m.Rows {
+		d[m.Rows[i]][m.Cols[i]] = m.Vals[i]
+	}
+	return d
+}
+
+// ============================================================================
+// Betti Number and Euler Characteristic Tests
+// ============================================================================
+
+func TestBettiNumbers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		edges [][]string
+		betti []int
+		euler int
+	}{
+		{"filled triangle", [][]string{{"A", "B", "C"}}, []int{1, 0, 0}, 1},
+		{"hollow triangle", [][]string{{"A", "B"}, {"B", "C"}, {"A", "C"}}, []int{1, 1}, 0},
+		{"two components", [][]string{{"A", "B"}, {"C", "D"}}, []int{2, 0}, 2},
+		{"hollow tetrahedron", [][]string{
+			{"A", "B", "C"}, {"A", "B", "D"}, {"A", "C", "D"}, {"B", "C", "D"},
+		}, []int{1, 0, 1}, 2},
+		{"two loops sharing a vertex", [][]string{
+			{"A", "B"}, {"B", "C"}, {"A", "C"}, {"C", "D"}, {"D", "E"}, {"C", "E"},
+		}, []int{1, 2}, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := complexFromEdges(t, -1, tt.edges...)
+			if got := sc.BettiNumbers(); !slices.Equal(got, tt.betti) {
+				t.Errorf("BettiNumbers = %v, want %v", got, tt.betti)
+			}
+			if got := sc.EulerCharacteristic(); got != tt.euler {
+				t.Errorf("EulerCharacteristic = %d, want %d", got, tt.euler)
+			}
+			// Euler–Poincaré: χ equals the alternating sum of Betti numbers.
+			alt := 0
+			for k, b := range sc.BettiNumbers() {
+				if k%2 == 0 {
+					alt += b
+				} else {
+					alt -= b
+				}
+			}
+			if alt != tt.euler {
+				t.Errorf("alternating Betti sum = %d, want %d", alt, tt.euler)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// HomologyGenerators Tests
+// ============================================================================
+
+func TestHomologyGenerators_HollowTriangle(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1, []string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"})
+
+	gens := sc.HomologyGenerators(1)
+	if len(gens) != 1 {
+		t.Fatalf("H1 generators = %d, want 1", len(gens))
+	}
+	want := [][]string{{"A", "B"}, {"A", "C"}, {"B", "C"}}
+	if !slices.EqualFunc(gens[0], want, slices.Equal) {
+		t.Errorf("H1 generator = %v, want %v", gens[0], want)
+	}
+
+	if h0 := sc.HomologyGenerators(0); len(h0) != 1 {
+		t.Errorf("H0 generators = %d, want 1", len(h0))
+	}
+}
+
+func TestHomologyGenerators_AreCycles(t *testing.T) {
+	t.Parallel()
+	sc := complexFromEdges(t, -1,
+		[]string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"},
+		[]string{"C", "D"}, []string{"D", "E"}, []string{"C", "E"},
+		[]string{"X", "Y", "Z"},
+	)
+	for k, b := range sc.BettiNumbers() {
+		gens := sc.HomologyGenerators(k)
+		if len(gens) != b {
+			t.Errorf("H%d: %d generators, want %d", k, len(gens), b)
+		}
+		if k == 0 {
+			continue
+		}
+		for _, cycle := range gens {
+			// Every (k-1)-face must appear an even number of times.
+			faces := make(map[string]int)
+			for _, s := range cycle {
+				for i := range s {
+					key := ""
+					for j, v := range s {
+						if j != i {
+							key += v + ","
+						}
+					}
+					faces[key]++
+				}
+			}
+			for f, n := range faces {
+				if n%2 != 0 {
+					t.Errorf("H%d generator %v has odd boundary face %s", k, cycle, f)
+				}
+			}
+		}
+	}
+	if gens := sc.HomologyGenerators(7); gens != nil {
+		t.Errorf("out-of-range generators = %v, want nil", gens)
+	}
+}
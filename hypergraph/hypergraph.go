@@ -6,30 +6,48 @@
 package hypergraph
 
 import (
-	"cmp"
 	"fmt"
+	"math"
+	"slices"
 )
 
 // Hypergraph represents a hypergraph with generic vertex type V.
-// V must satisfy cmp.Ordered to enable efficient sorting operations.
-type Hypergraph[V cmp.Ordered] struct {
+// Wherever vertices are sorted, for deterministic output, they are sorted
+// by the hypergraph's comparison function; see [NewHypergraphFunc].
+type Hypergraph[V comparable] struct {
 	vertices      map[V]struct{}
 	edges         map[string]Edge[V]
 	vertexToEdges map[V]map[string]struct{}
+	weights       map[string]float64          // only edges with a non-default weight
+	roles         map[string][]RoleBinding[V] // only role-labeled edges
+	compare       func(a, b V) int
 }
 
 // Edge represents a hyperedge with an ID and a set of vertices.
-type Edge[V cmp.Ordered] struct {
+type Edge[V comparable] struct {
 	ID  string
 	Set map[V]struct{}
 }
 
-// NewHypergraph creates a new empty hypergraph.
-func NewHypergraph[V cmp.Ordered]() *Hypergraph[V] {
+// NewHypergraph creates a new empty hypergraph whose vertices sort in
+// their natural order: numerically for numbers, lexically for strings,
+// and field by field for structs.
+func NewHypergraph[V comparable]() *Hypergraph[V] {
+	return NewHypergraphFunc(defaultCompare[V]())
+}
+
+// NewHypergraphFunc creates a new empty hypergraph whose vertices sort by
+// compare, which returns a negative number, zero or a positive number as
+// a is less than, equal to or greater than b. [CompareBy] builds one from
+// a key.
+func NewHypergraphFunc[V comparable](compare func(a, b V) int) *Hypergraph[V] {
 	return &Hypergraph[V]{
 		vertices:      make(map[V]struct{}),
 		edges:         make(map[string]Edge[V]),
 		vertexToEdges: make(map[V]map[string]struct{}),
+		weights:       make(map[string]float64),
+		roles:         make(map[string][]RoleBinding[V]),
+		compare:       compare,
 	}
 }
 
@@ -51,6 +69,10 @@ func (h *Hypergraph[V]) RemoveVertex(v V) {
 		delete(h.edges[edgeID].Set, v)
 		if len(h.edges[edgeID].Set) == 0 {
 			delete(h.edges, edgeID)
+			delete(h.weights, edgeID)
+			delete(h.roles, edgeID)
+		} else if bindings, ok := h.roles[edgeID]; ok {
+			h.setRolesOrDelete(edgeID, slices.DeleteFunc(bindings, func(b RoleBinding[V]) bool { return b.Vertex == v }))
 		}
 	}
 	delete(h.vertexToEdges, v)
@@ -83,6 +105,53 @@ func (h *Hypergraph[V]) RemoveEdge(id string) {
 			delete(h.vertexToEdges[v], id)
 		}
 		delete(h.edges, id)
+		delete(h.weights, id)
+		delete(h.roles, id)
+	}
+}
+
+// EdgeWeight returns the weight of an edge. Edges have weight 1 unless set
+// otherwise with [Hypergraph.SetEdgeWeight]; missing edges have weight 0.
+func (h *Hypergraph[V]) EdgeWeight(id string) float64 {
+	if !h.HasEdge(id) {
+		return 0
+	}
+	if w, ok := h.weights[id]; ok {
+		return w
+	}
+	return 1
+}
+
+// SetEdgeWeight sets the weight of an existing edge. Weights must be
+// finite and non-negative.
+func (h *Hypergraph[V]) SetEdgeWeight(id string, w float64) error {
+	if !h.HasEdge(id) {
+		return ErrEdgeNotFound
+	}
+	if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+		return fmt.Errorf("invalid edge weight %v", w)
+	}
+	if w == 1 {
+		delete(h.weights, id)
+	} else {
+		h.weights[id] = w
+	}
+	return nil
+}
+
+// copyEdgeAttributes copies the weight and role bindings of edge id from
+// src, for an edge id that h already has. Bindings whose vertex is not a
+// member of the edge in h are dropped.
+func (h *Hypergraph[V]) copyEdgeAttributes(src *Hypergraph[V], id string) {
+	if w, ok := src.weights[id]; ok {
+		h.weights[id] = w
+	}
+	if bindings, ok := src.roles[id]; ok {
+		members := h.edges[id].Set
+		h.setRolesOrDelete(id, slices.DeleteFunc(slices.Clone(bindings), func(b RoleBinding[V]) bool {
+			_, ok := members[b.Vertex]
+			return !ok
+		}))
 	}
 }
 
@@ -142,14 +211,11 @@ func (h *Hypergraph[V]) EdgeSize(id string) (int, bool) {
 	return 0, false
 }
 
-// EdgeMembers returns the members of an edge.
+// EdgeMembers returns the members of an edge as a new, unsorted slice.
+// Use [Hypergraph.Members] to iterate without allocating.
 func (h *Hypergraph[V]) EdgeMembers(id string) []V {
-	if edge, exists := h.edges[id]; exists {
-		members := make([]V, 0, len(edge.Set))
-		for v := range edge.Set {
-			members = append(members, v)
-		}
-		return members
+	if !h.HasEdge(id) {
+		return nil
 	}
-	return nil
+	return slices.AppendSeq(make([]V, 0, len(h.edges[id].Set)), h.Members(id, Unordered))
 }
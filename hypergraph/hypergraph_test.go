@@ -2,6 +2,8 @@ package hypergraph
 
 import (
 	"bytes"
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -228,6 +230,79 @@ func TestCopy_EmptyHypergraph(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// InducedSubhypergraph Tests
+// ============================================================================
+
+func TestInducedSubhypergraph(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	_ = h.AddEdge("E2", []string{"B", "C"})
+	_ = h.AddEdge("E3", []string{"A", "B", "D"})
+
+	sub := h.InducedSubhypergraph([]string{"A", "B", "C", "missing"})
+
+	if sub.NumVertices() != 3 {
+		t.Errorf("NumVertices = %d, want 3", sub.NumVertices())
+	}
+	if !sub.HasEdge("E1") || !sub.HasEdge("E2") {
+		t.Error("edges contained in the vertex set should be kept")
+	}
+	if sub.HasEdge("E3") {
+		t.Error("E3 has a member outside the vertex set and should be dropped")
+	}
+	if sub.HasVertex("missing") {
+		t.Error("vertices absent from the source graph should be ignored")
+	}
+
+	// The source graph is untouched.
+	if h.NumEdges() != 3 || h.NumVertices() != 4 {
+		t.Error("InducedSubhypergraph modified the source graph")
+	}
+}
+
+// ============================================================================
+// Edge Weight Tests
+// ============================================================================
+
+func TestEdgeWeight(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+
+	if w := h.EdgeWeight("E1"); w != 1 {
+		t.Errorf("default weight = %v, want 1", w)
+	}
+	if w := h.EdgeWeight("missing"); w != 0 {
+		t.Errorf("missing edge weight = %v, want 0", w)
+	}
+	if err := h.SetEdgeWeight("E1", 4); err != nil {
+		t.Fatalf("SetEdgeWeight failed: %v", err)
+	}
+	if w := h.EdgeWeight("E1"); w != 4 {
+		t.Errorf("weight = %v, want 4", w)
+	}
+	if err := h.SetEdgeWeight("missing", 2); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("SetEdgeWeight on missing edge: err = %v, want ErrEdgeNotFound", err)
+	}
+	for _, bad := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := h.SetEdgeWeight("E1", bad); err == nil {
+			t.Errorf("SetEdgeWeight(%v) should fail", bad)
+		}
+	}
+
+	// Weights follow copies and are dropped with their edge.
+	if w := h.Copy().EdgeWeight("E1"); w != 4 {
+		t.Errorf("copied weight = %v, want 4", w)
+	}
+	h.RemoveEdge("E1")
+	_ = h.AddEdge("E1", []string{"A"})
+	if w := h.EdgeWeight("E1"); w != 1 {
+		t.Errorf("re-added edge weight = %v, want 1", w)
+	}
+}
+
 // ============================================================================
 // AddEdge with Duplicate Vertices Tests
 // ============================================================================
@@ -1,7 +1,6 @@
 package hypergraph
 
 import (
-	"slices"
 	"sort"
 )
 
@@ -15,7 +14,7 @@ type COO struct {
 func (h *Hypergraph[V]) IncidenceMatrix() (vertexIndex map[V]int, edgeIndex map[string]int, coo COO) {
 	// Create stable vertex index
 	vertices := h.Vertices()
-	slices.Sort(vertices)
+	h.sortVertices(vertices)
 	vertexIndex = make(map[V]int)
 	for i, v := range vertices {
 		vertexIndex[v] = i
@@ -33,7 +32,7 @@ func (h *Hypergraph[V]) IncidenceMatrix() (vertexIndex map[V]int, edgeIndex map[
 	var rows, cols []int
 	for _, e := range edges {
 		col := edgeIndex[e]
-		for v := range h.edges[e].Set {
+		for v := range h.Members(e, Unordered) {
 			row := vertexIndex[v]
 			rows = append(rows, row)
 			cols = append(cols, col)
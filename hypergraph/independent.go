@@ -0,0 +1,335 @@
+package hypergraph
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// IsStrongIndependentSet reports whether set consists of vertices of h no
+// two of which share an edge.
+func (h *Hypergraph[V]) IsStrongIndependentSet(set []V) bool {
+	seen := make(map[string]struct{})
+	for _, v := range set {
+		if !h.HasVertex(v) {
+			return false
+		}
+		for e := range h.IncidentEdges(v, Unordered) {
+			if _, dup := seen[e]; dup {
+				return false
+			}
+			seen[e] = struct{}{}
+		}
+	}
+	return true
+}
+
+// IsWeakIndependentSet reports whether set consists of vertices of h and
+// contains no edge entirely.
+func (h *Hypergraph[V]) IsWeakIndependentSet(set []V) bool {
+	inSet := make(map[V]struct{}, len(set))
+	for _, v := range set {
+		if !h.HasVertex(v) {
+			return false
+		}
+		inSet[v] = struct{}{}
+	}
+	for _, e := range h.edges {
+		contained := true
+		for v := range e.Set {
+			if _, ok := inSet[v]; !ok {
+				contained = false
+				break
+			}
+		}
+		if contained {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTransversal reports whether set intersects every edge of h. A set is a
+// transversal exactly when its complement is a weak independent set.
+func (h *Hypergraph[V]) IsTransversal(set []V) bool {
+	inSet := make(map[V]struct{}, len(set))
+	for _, v := range set {
+		inSet[v] = struct{}{}
+	}
+	for _, e := range h.edges {
+		hit := false
+		for v := range e.Set {
+			if _, ok := inSet[v]; ok {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return false
+		}
+	}
+	return true
+}
+
+// GreedyStrongIndependentSet computes a maximal strong independent set:
+// no two chosen vertices share an edge. It repeatedly picks the vertex with
+// the fewest remaining neighbors, breaking ties by vertex order, and
+// discards its neighbors.
+//
+// Returns the vertices in sorted order.
+// Time complexity: O(|V|^2 + |V| * d) where d is the largest neighbor count.
+func (h *Hypergraph[V]) GreedyStrongIndependentSet() []V {
+	vertices, adj := h.neighborLists()
+	alive := make([]bool, len(vertices))
+	degree := make([]int, len(vertices))
+	for i := range vertices {
+		alive[i] = true
+		degree[i] = len(adj[i])
+	}
+	var set []V
+	for {
+		pick := -1
+		for i := range vertices {
+			if alive[i] && (pick < 0 || degree[i] < degree[pick]) {
+				pick = i
+			}
+		}
+		if pick < 0 {
+			return set
+		}
+		set = append(set, vertices[pick])
+		removed := append([]int{pick}, adj[pick]...)
+		for _, u := range removed {
+			if !alive[u] {
+				continue
+			}
+			alive[u] = false
+			for _, w := range adj[u] {
+				degree[w]--
+			}
+		}
+	}
+}
+
+// GreedyWeakIndependentSet computes a maximal weak independent set: no edge
+// is entirely contained in it. Vertices are considered in increasing order
+// of degree, breaking ties by vertex order, and added whenever doing so
+// leaves every edge with a member outside the set.
+//
+// The complement of the result is a minimal transversal, so it appears
+// among the results of [Hypergraph.EnumerateMinimalTransversals] when the
+// enumeration is not truncated by its limits.
+// Returns the vertices in sorted order.
+// Time complexity: O(|V| log |V| + P) where P is the number of edge memberships.
+func (h *Hypergraph[V]) GreedyWeakIndependentSet() []V {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	order := slices.Clone(vertices)
+	slices.SortStableFunc(order, func(a, b V) int {
+		return cmp.Compare(len(h.vertexToEdges[a]), len(h.vertexToEdges[b]))
+	})
+	inside := make(map[string]int, len(h.edges))
+	chosen := make(map[V]bool)
+	for _, v := range order {
+		ok := true
+		for e := range h.IncidentEdges(v, Unordered) {
+			if inside[e]+1 == len(h.edges[e].Set) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		chosen[v] = true
+		for e := range h.IncidentEdges(v, Unordered) {
+			inside[e]++
+		}
+	}
+	return slices.DeleteFunc(vertices, func(v V) bool { return !chosen[v] })
+}
+
+// MaximumStrongIndependentSet computes a largest set of vertices no two of
+// which share an edge, by branch and bound on the 2-section. It branches on
+// the candidate with the most candidate neighbors, first including it and
+// then excluding it, and prunes when the chosen vertices plus all remaining
+// candidates cannot beat the incumbent, which is seeded by
+// [Hypergraph.GreedyStrongIndependentSet].
+//
+// If maxTime elapses first, the best set found so far is returned with
+// [ErrCutoff]. Returns the vertices in sorted order.
+// Time complexity: exponential in the worst case (the problem is NP-hard).
+func (h *Hypergraph[V]) MaximumStrongIndependentSet(maxTime time.Duration) ([]V, error) {
+	vertices, adj := h.neighborLists()
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	var best []int
+	for _, v := range h.GreedyStrongIndependentSet() {
+		best = append(best, index[v])
+	}
+
+	start := time.Now()
+	cutoff := false
+	mark := make([]int, len(vertices)) // stamp of the last call that marked i
+	stamp := 0
+	var chosen []int
+	var search func(cand []int)
+	search = func(cand []int) {
+		if cutoff || time.Since(start) > maxTime {
+			cutoff = true
+			return
+		}
+		if len(chosen)+len(cand) <= len(best) {
+			return
+		}
+		stamp++
+		for _, u := range cand {
+			mark[u] = stamp
+		}
+		pick, pickDeg := -1, -1
+		for _, u := range cand {
+			deg := 0
+			for _, w := range adj[u] {
+				if mark[w] == stamp {
+					deg++
+				}
+			}
+			if deg > pickDeg {
+				pick, pickDeg = u, deg
+			}
+		}
+		if pickDeg <= 0 {
+			// The candidates are pairwise non-adjacent; take them all.
+			best = slices.Concat(chosen, cand)
+			return
+		}
+
+		stamp++
+		mark[pick] = stamp
+		for _, w := range adj[pick] {
+			mark[w] = stamp
+		}
+		include := slices.DeleteFunc(slices.Clone(cand), func(u int) bool { return mark[u] == stamp })
+		exclude := slices.DeleteFunc(slices.Clone(cand), func(u int) bool { return u == pick })
+
+		chosen = append(chosen, pick)
+		search(include)
+		chosen = chosen[:len(chosen)-1]
+		search(exclude)
+	}
+	all := make([]int, len(vertices))
+	for i := range all {
+		all[i] = i
+	}
+	search(all)
+
+	set := make([]V, 0, len(best))
+	for _, i := range best {
+		set = append(set, vertices[i])
+	}
+	h.sortVertices(set)
+	if cutoff {
+		return set, ErrCutoff
+	}
+	return set, nil
+}
+
+// MinimumVertexCover computes a smallest transversal (also called a vertex
+// cover or hitting set): a set of vertices meeting every edge. It runs the
+// set cover branch and bound with vertices as sets and edges as elements,
+// seeded with the smaller of [Hypergraph.GreedyHittingSet] and the
+// complement of [Hypergraph.GreedyWeakIndependentSet].
+//
+// If maxTime elapses first, the best transversal found so far is returned
+// with [ErrCutoff]. Returns the vertices in sorted order.
+// Time complexity: exponential in the worst case (the problem is NP-hard).
+func (h *Hypergraph[V]) MinimumVertexCover(maxTime time.Duration) ([]V, error) {
+	c, vertices := newTransversalInstance(h)
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	weak := h.GreedyWeakIndependentSet()
+	seed := h.GreedyHittingSet()
+	if len(vertices)-len(weak) < len(seed) {
+		seed = complement(vertices, weak)
+	}
+	seedIdx := make([]int, 0, len(seed))
+	for _, v := range seed {
+		seedIdx = append(seedIdx, index[v])
+	}
+
+	best, cutoff := c.branchAndBound(seedIdx, maxTime)
+	cover := make([]V, 0, len(best))
+	for _, i := range best {
+		cover = append(cover, vertices[i])
+	}
+	h.sortVertices(cover)
+	if cutoff {
+		return cover, ErrCutoff
+	}
+	return cover, nil
+}
+
+// MaximumWeakIndependentSet computes a largest set of vertices containing
+// no edge entirely. It is the complement of [Hypergraph.MinimumVertexCover].
+//
+// If maxTime elapses first, the best set found so far is returned with
+// [ErrCutoff]. Returns the vertices in sorted order.
+// Time complexity: exponential in the worst case (the problem is NP-hard).
+func (h *Hypergraph[V]) MaximumWeakIndependentSet(maxTime time.Duration) ([]V, error) {
+	cover, err := h.MinimumVertexCover(maxTime)
+	return complement(h.sorted(maps.Keys(h.vertices)), cover), err
+}
+
+// neighborLists returns the sorted vertices of h and, for each, the
+// indices of its neighbors in the 2-section.
+func (h *Hypergraph[V]) neighborLists() ([]V, [][]int) {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	adj := make([][]int, len(vertices))
+	mark := make([]int, len(vertices))
+	for i, v := range vertices {
+		adj[i] = h.appendNeighbors(adj[i], v, i, index, mark)
+		slices.Sort(adj[i])
+	}
+	return vertices, adj
+}
+
+// appendNeighbors appends the indices of the neighbors of v, the vertex
+// with index i, to dst. mark has an entry per vertex and is set to i+1 for
+// each neighbor found, which lets a caller reuse it across vertices in
+// increasing order of i without clearing it.
+func (h *Hypergraph[V]) appendNeighbors(dst []int, v V, i int, index map[V]int, mark []int) []int {
+	mark[i] = i + 1
+	for e := range h.IncidentEdges(v, Unordered) {
+		for u := range h.Members(e, Unordered) {
+			if j := index[u]; mark[j] != i+1 {
+				mark[j] = i + 1
+				dst = append(dst, j)
+			}
+		}
+	}
+	return dst
+}
+
+// complement returns the elements of sorted that are not in remove,
+// preserving order.
+func complement[V comparable](sorted, remove []V) []V {
+	drop := make(map[V]struct{}, len(remove))
+	for _, v := range remove {
+		drop[v] = struct{}{}
+	}
+	out := make([]V, 0, len(sorted))
+	for _, v := range sorted {
+		if _, ok := drop[v]; !ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
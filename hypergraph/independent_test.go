@@ -0,0 +1,195 @@
+package hypergraph
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+// bruteForceIndependent returns the largest strong and weak independent
+// set sizes by enumeration.
+func bruteForceIndependent(h *Hypergraph[int]) (strong, weak int) {
+	vertices := h.Vertices()
+	slices.Sort(vertices)
+	for mask := range 1 << len(vertices) {
+		var set []int
+		for i, v := range vertices {
+			if mask&(1<<i) != 0 {
+				set = append(set, v)
+			}
+		}
+		if h.IsStrongIndependentSet(set) {
+			strong = max(strong, len(set))
+		}
+		if h.IsWeakIndependentSet(set) {
+			weak = max(weak, len(set))
+		}
+	}
+	return strong, weak
+}
+
+// ============================================================================
+// Independence Predicate Tests
+// ============================================================================
+
+func TestIndependencePredicates(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+
+	tests := []struct {
+		set                       []string
+		strong, weak, transversal bool
+	}{
+		{[]string{"A", "D"}, true, true, true},
+		{[]string{"A", "B"}, false, true, false},
+		{[]string{"A", "B", "C"}, false, false, true},
+		{[]string{"C", "D"}, false, false, true},
+		{[]string{"C"}, true, true, true},
+		{[]string{"missing"}, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := h.IsStrongIndependentSet(tt.set); got != tt.strong {
+			t.Errorf("IsStrongIndependentSet(%v) = %v, want %v", tt.set, got, tt.strong)
+		}
+		if got := h.IsWeakIndependentSet(tt.set); got != tt.weak {
+			t.Errorf("IsWeakIndependentSet(%v) = %v, want %v", tt.set, got, tt.weak)
+		}
+		if got := h.IsTransversal(tt.set); got != tt.transversal {
+			t.Errorf("IsTransversal(%v) = %v, want %v", tt.set, got, tt.transversal)
+		}
+	}
+}
+
+// ============================================================================
+// Solver Tests
+// ============================================================================
+
+func TestIndependentSets_Simple(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+	_ = h.AddEdge("E3", []string{"E"})
+	h.AddVertex("F")
+
+	strong, err := h.MaximumStrongIndependentSet(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(strong, []string{"A", "D", "E", "F"}) {
+		t.Errorf("strong = %v, want [A D E F]", strong)
+	}
+
+	weak, err := h.MaximumWeakIndependentSet(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// E must be excluded because of the singleton edge.
+	if len(weak) != 4 || slices.Contains(weak, "E") || !h.IsWeakIndependentSet(weak) {
+		t.Errorf("weak = %v, want 4 vertices without E", weak)
+	}
+
+	cover, err := h.MinimumVertexCover(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cover, []string{"C", "E"}) {
+		t.Errorf("cover = %v, want [C E]", cover)
+	}
+}
+
+func TestIndependentSets_Empty(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	if s := h.GreedyStrongIndependentSet(); len(s) != 0 {
+		t.Errorf("strong = %v, want empty", s)
+	}
+	if s, err := h.MaximumWeakIndependentSet(time.Second); err != nil || len(s) != 0 {
+		t.Errorf("weak = %v, %v, want empty", s, err)
+	}
+}
+
+func TestIndependentSets_AgainstBruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(4, 8))
+	for trial := range 40 {
+		h := randomWeightedHypergraph(rng, 9, 3+rng.IntN(8))
+		wantStrong, wantWeak := bruteForceIndependent(h)
+
+		strong, err := h.MaximumStrongIndependentSet(time.Second)
+		if err != nil || len(strong) != wantStrong || !h.IsStrongIndependentSet(strong) {
+			t.Fatalf("trial %d: strong = %v (%v), want size %d", trial, strong, err, wantStrong)
+		}
+		weak, err := h.MaximumWeakIndependentSet(time.Second)
+		if err != nil || len(weak) != wantWeak || !h.IsWeakIndependentSet(weak) {
+			t.Fatalf("trial %d: weak = %v (%v), want size %d", trial, weak, err, wantWeak)
+		}
+
+		greedyStrong := h.GreedyStrongIndependentSet()
+		if !h.IsStrongIndependentSet(greedyStrong) || len(greedyStrong) > wantStrong {
+			t.Errorf("trial %d: greedy strong %v invalid", trial, greedyStrong)
+		}
+		greedyWeak := h.GreedyWeakIndependentSet()
+		if !h.IsWeakIndependentSet(greedyWeak) || len(greedyWeak) > wantWeak {
+			t.Errorf("trial %d: greedy weak %v invalid", trial, greedyWeak)
+		}
+	}
+}
+
+func TestVertexCover_MatchesTransversals(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(6, 1))
+	for trial := range 30 {
+		h := randomWeightedHypergraph(rng, 8, 2+rng.IntN(6))
+		all, err := h.EnumerateMinimalTransversals(1<<20, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		minSize := h.NumVertices()
+		for _, tr := range all {
+			minSize = min(minSize, len(tr))
+		}
+
+		cover, err := h.MinimumVertexCover(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cover) != minSize || !h.IsTransversal(cover) {
+			t.Fatalf("trial %d: cover %v, want a transversal of size %d", trial, cover, minSize)
+		}
+		if len(cover) > len(h.GreedyHittingSet()) {
+			t.Errorf("trial %d: exact cover larger than greedy hitting set", trial)
+		}
+
+		// The complement of a maximal weak independent set is a minimal
+		// transversal.
+		vertices := h.Vertices()
+		slices.Sort(vertices)
+		comp := complement(vertices, h.GreedyWeakIndependentSet())
+		if !slices.ContainsFunc(all, func(tr []int) bool {
+			slices.Sort(tr)
+			return slices.Equal(tr, comp)
+		}) {
+			t.Errorf("trial %d: complement %v of greedy weak set is not a minimal transversal", trial, comp)
+		}
+	}
+}
+
+func TestIndependentSets_Cutoff(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(2, 2))
+	h := randomWeightedHypergraph(rng, 60, 150)
+
+	strong, err := h.MaximumStrongIndependentSet(0)
+	if !errors.Is(err, ErrCutoff) || !h.IsStrongIndependentSet(strong) {
+		t.Errorf("strong cutoff: %v, %v", strong, err)
+	}
+	weak, err := h.MaximumWeakIndependentSet(0)
+	if !errors.Is(err, ErrCutoff) || !h.IsWeakIndependentSet(weak) {
+		t.Errorf("weak cutoff: %v, %v", weak, err)
+	}
+}
@@ -0,0 +1,266 @@
+package hypergraph
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Embedding maps a pattern hypergraph into a host hypergraph: pattern
+// vertices to distinct host vertices and pattern edges to distinct host
+// edges with exactly the image members.
+type Embedding[P, V comparable] struct {
+	Vertices map[P]V
+	Edges    map[string]string
+}
+
+// MatchOptions configures [FindEmbeddings].
+type MatchOptions[P, V comparable] struct {
+	// VertexMatch, if set, reports whether pattern vertex p may map to host
+	// vertex v, for example because their labels agree.
+	VertexMatch func(p P, v V) bool
+	// EdgeMatch, if set, reports whether pattern edge pe may map to host
+	// edge e.
+	EdgeMatch func(pe, e string) bool
+	// Induced also requires that no other host edge lies entirely within
+	// the image of the pattern vertices.
+	Induced bool
+	// MaxEmbeddings stops the search after this many embeddings. Zero
+	// means no limit.
+	MaxEmbeddings int
+	// MaxTime stops the search after this duration. Zero means no limit.
+	MaxTime time.Duration
+}
+
+// FindEmbeddings returns every embedding of pattern into h: an injective
+// map of pattern vertices to host vertices together with an injective map
+// of pattern edges to host edges, such that each pattern edge maps to a
+// host edge whose members are exactly the images of its members. Host
+// edges and vertices outside the image are unconstrained unless
+// opts.Induced is set. A symmetric pattern matches each occurrence once
+// per automorphism.
+//
+// The search extends a partial embedding one pattern edge at a time, in an
+// order where each edge overlaps the ones before it where possible, and
+// draws candidates for an edge from the incident edges of an already
+// mapped member. Pattern vertices in no edge are placed last. Embeddings
+// are returned in search order, which is deterministic.
+//
+// If opts.MaxEmbeddings or opts.MaxTime stops the search before it is
+// complete, the embeddings found so far are returned with [ErrCutoff].
+// Time complexity: exponential in the size of the pattern in the worst case.
+func FindEmbeddings[P, V comparable](pattern *Hypergraph[P], h *Hypergraph[V], opts MatchOptions[P, V]) ([]Embedding[P, V], error) {
+	m := &matcher[P, V]{
+		pattern:   pattern,
+		h:         h,
+		opts:      opts,
+		start:     time.Now(),
+		vmap:      make(map[P]V),
+		used:      make(map[V]bool),
+		emap:      make(map[string]string),
+		usedEdges: make(map[string]bool),
+		bySize:    make(map[int][]string),
+	}
+	m.order = pattern.matchOrder()
+	for _, id := range slices.Sorted(maps.Keys(h.edges)) {
+		size := len(h.edges[id].Set)
+		m.bySize[size] = append(m.bySize[size], id)
+	}
+	for _, p := range pattern.sorted(maps.Keys(pattern.vertices)) {
+		if len(pattern.vertexToEdges[p]) == 0 {
+			m.isolated = append(m.isolated, p)
+		}
+	}
+	m.hostVertices = h.sorted(maps.Keys(h.vertices))
+	m.matchEdge(0)
+	if m.cutoff {
+		return m.found, ErrCutoff
+	}
+	return m.found, nil
+}
+
+// matchOrder returns the edges of a pattern in the order they are matched:
+// each next edge has the most members already covered by earlier edges,
+// then the most members, then the smallest ID.
+func (h *Hypergraph[V]) matchOrder() []string {
+	remaining := slices.Sorted(maps.Keys(h.edges))
+	covered := make(map[V]bool)
+	var order []string
+	for len(remaining) > 0 {
+		best, bestCovered := 0, -1
+		for i, id := range remaining {
+			n := 0
+			for v := range h.edges[id].Set {
+				if covered[v] {
+					n++
+				}
+			}
+			if c := cmp.Or(cmp.Compare(n, bestCovered), cmp.Compare(len(h.edges[id].Set), len(h.edges[remaining[best]].Set))); c > 0 {
+				best, bestCovered = i, n
+			}
+		}
+		id := remaining[best]
+		order = append(order, id)
+		for v := range h.edges[id].Set {
+			covered[v] = true
+		}
+		remaining = slices.Delete(remaining, best, best+1)
+	}
+	return order
+}
+
+// matcher holds the state of a [FindEmbeddings] search.
+type matcher[P, V comparable] struct {
+	pattern      *Hypergraph[P]
+	h            *Hypergraph[V]
+	opts         MatchOptions[P, V]
+	start        time.Time
+	order        []string // pattern edges in matching order
+	isolated     []P      // pattern vertices in no edge
+	bySize       map[int][]string
+	hostVertices []V
+
+	vmap      map[P]V
+	used      map[V]bool
+	emap      map[string]string
+	usedEdges map[string]bool
+	found     []Embedding[P, V]
+	cutoff    bool
+}
+
+// stopped reports whether the search should end, recording a cutoff if a
+// limit was reached.
+func (m *matcher[P, V]) stopped() bool {
+	if !m.cutoff && m.opts.MaxTime > 0 && time.Since(m.start) > m.opts.MaxTime {
+		m.cutoff = true
+	}
+	return m.cutoff
+}
+
+// matchEdge maps the i-th pattern edge in matching order and continues
+// with the rest.
+func (m *matcher[P, V]) matchEdge(i int) {
+	if m.stopped() {
+		return
+	}
+	if i == len(m.order) {
+		m.matchIsolated(0)
+		return
+	}
+	pe := m.order[i]
+	members := slices.Collect(m.pattern.Members(pe, Sorted))
+	var images []V
+	var free []P
+	for _, p := range members {
+		if v, ok := m.vmap[p]; ok {
+			images = append(images, v)
+		} else {
+			free = append(free, p)
+		}
+	}
+
+	candidates := m.bySize[len(members)]
+	if len(images) > 0 {
+		// Any host edge for pe contains every image; the image of lowest
+		// degree has the fewest incident edges to try.
+		anchor := slices.MinFunc(images, func(a, b V) int {
+			return cmp.Compare(len(m.h.vertexToEdges[a]), len(m.h.vertexToEdges[b]))
+		})
+		candidates = slices.Collect(m.h.IncidentEdges(anchor, Sorted))
+	}
+	for _, e := range candidates {
+		set := m.h.edges[e].Set
+		if m.usedEdges[e] || len(set) != len(members) {
+			continue
+		}
+		if m.opts.EdgeMatch != nil && !m.opts.EdgeMatch(pe, e) {
+			continue
+		}
+		if slices.ContainsFunc(images, func(v V) bool { _, ok := set[v]; return !ok }) {
+			continue
+		}
+		var targets []V
+		for v := range m.h.Members(e, Sorted) {
+			if !m.used[v] {
+				targets = append(targets, v)
+			}
+		}
+		if len(targets) != len(free) {
+			continue // e has a member mapped from outside pe
+		}
+		m.usedEdges[e] = true
+		m.emap[pe] = e
+		m.assign(free, targets, func() { m.matchEdge(i + 1) })
+		delete(m.emap, pe)
+		delete(m.usedEdges, e)
+		if m.cutoff {
+			return
+		}
+	}
+}
+
+// assign maps the pattern vertices free bijectively onto targets in every
+// way allowed by VertexMatch, calling next for each complete assignment.
+func (m *matcher[P, V]) assign(free []P, targets []V, next func()) {
+	if len(free) == 0 {
+		next()
+		return
+	}
+	p := free[0]
+	for _, v := range targets {
+		if m.used[v] || !m.vertexMatches(p, v) {
+			continue
+		}
+		m.vmap[p] = v
+		m.used[v] = true
+		m.assign(free[1:], targets, next)
+		delete(m.used, v)
+		delete(m.vmap, p)
+		if m.stopped() {
+			return
+		}
+	}
+}
+
+// matchIsolated places the pattern vertices in no edge on unused host
+// vertices.
+func (m *matcher[P, V]) matchIsolated(i int) {
+	if i == len(m.isolated) {
+		m.emit()
+		return
+	}
+	m.assign(m.isolated[i:i+1], m.hostVertices, func() { m.matchIsolated(i + 1) })
+}
+
+func (m *matcher[P, V]) vertexMatches(p P, v V) bool {
+	return m.opts.VertexMatch == nil || m.opts.VertexMatch(p, v)
+}
+
+// emit records the current complete embedding, after the induced check.
+func (m *matcher[P, V]) emit() {
+	if m.opts.Induced {
+		for v := range m.used {
+			for e := range m.h.IncidentEdges(v, Unordered) {
+				if m.usedEdges[e] {
+					continue
+				}
+				inside := true
+				for u := range m.h.Members(e, Unordered) {
+					if !m.used[u] {
+						inside = false
+						break
+					}
+				}
+				if inside {
+					return
+				}
+			}
+		}
+	}
+	if m.opts.MaxEmbeddings > 0 && len(m.found) == m.opts.MaxEmbeddings {
+		m.cutoff = true
+		return
+	}
+	m.found = append(m.found, Embedding[P, V]{Vertices: maps.Clone(m.vmap), Edges: maps.Clone(m.emap)})
+}
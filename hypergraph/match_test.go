@@ -0,0 +1,204 @@
+package hypergraph
+
+import (
+	"errors"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// FindEmbeddings Tests
+// ============================================================================
+
+func TestFindEmbeddings_Path(t *testing.T) {
+	t.Parallel()
+	// Pattern: two 3-edges sharing one vertex.
+	pattern := fromEdges(t, []string{"x", "y", "z"}, []string{"z", "u", "w"})
+	h := fromEdges(t,
+		[]string{"a", "b", "c"},
+		[]string{"c", "d", "e"},
+		[]string{"e", "f"},
+		[]string{"a", "b", "g", "h"},
+	)
+	got, err := FindEmbeddings(pattern, h, MatchOptions[string, string]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The shared vertex must be c; each edge can be matched in 2 ways
+	// (which member of the edge plays which role besides the shared one),
+	// and the pattern edges can be swapped: 2 * 2 * 2 = 8.
+	if len(got) != 8 {
+		t.Fatalf("got %d embeddings, want 8: %v", len(got), got)
+	}
+	for _, e := range got {
+		if e.Vertices["z"] != "c" {
+			t.Errorf("shared vertex mapped to %v", e.Vertices["z"])
+		}
+		if len(e.Edges) != 2 || e.Edges["e0"] == e.Edges["e1"] {
+			t.Errorf("edges = %v", e.Edges)
+		}
+		for pe, he := range e.Edges {
+			var image []string
+			for p := range pattern.Members(pe, Unordered) {
+				image = append(image, e.Vertices[p])
+			}
+			slices.Sort(image)
+			if !slices.Equal(image, slices.Collect(h.Members(he, Sorted))) {
+				t.Errorf("edge %s maps to %s but members map to %v", pe, he, image)
+			}
+		}
+	}
+}
+
+func TestFindEmbeddings_Labels(t *testing.T) {
+	t.Parallel()
+	pattern := NewHypergraph[string]()
+	_ = pattern.AddEdge("paper", []string{"author", "coauthor"})
+	h := fromEdges(t, []string{"alice", "bob"}, []string{"bob", "carol"}, []string{"carol", "dave"})
+	senior := map[string]bool{"alice": true, "carol": true}
+	got, err := FindEmbeddings(pattern, h, MatchOptions[string, string]{
+		VertexMatch: func(p, v string) bool { return (p == "author") == senior[v] },
+		EdgeMatch:   func(pe, e string) bool { return e != "e2" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authors []string
+	for _, e := range got {
+		authors = append(authors, e.Vertices["author"]+"-"+e.Vertices["coauthor"])
+	}
+	if want := []string{"alice-bob", "carol-bob"}; !slices.Equal(authors, want) {
+		t.Errorf("embeddings = %v, want %v", authors, want)
+	}
+}
+
+func TestFindEmbeddings_Induced(t *testing.T) {
+	t.Parallel()
+	pattern := fromEdges(t, []string{"x", "y"}, []string{"y", "z"})
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"a", "c"}, []string{"c", "d"})
+	all, _ := FindEmbeddings(pattern, h, MatchOptions[string, string]{})
+	induced, _ := FindEmbeddings(pattern, h, MatchOptions[string, string]{Induced: true})
+	// Paths of length 2: three around the triangle abc (each twice, by
+	// symmetry) plus a-c-d, b-c-d: 5 * 2 = 10. Induced excludes the three
+	// inside the triangle.
+	if len(all) != 10 || len(induced) != 4 {
+		t.Errorf("got %d embeddings and %d induced, want 10 and 4", len(all), len(induced))
+	}
+	for _, e := range induced {
+		if e.Vertices["y"] != "c" || !slices.Contains([]string{e.Vertices["x"], e.Vertices["z"]}, "d") {
+			t.Errorf("induced embedding %v", e.Vertices)
+		}
+	}
+}
+
+func TestFindEmbeddings_IsolatedAndEmpty(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"a", "b"})
+	h.AddVertex("c")
+
+	pattern := NewHypergraph[int]()
+	_ = pattern.AddEdge("p", []int{1, 2})
+	pattern.AddVertex(3)
+	got, err := FindEmbeddings(pattern, h, MatchOptions[int, string]{})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("got %v, %v; want 2 embeddings", got, err)
+	}
+	if got[0].Vertices[3] != "c" {
+		t.Errorf("isolated vertex mapped to %v", got[0].Vertices[3])
+	}
+
+	empty, err := FindEmbeddings(NewHypergraph[int](), h, MatchOptions[int, string]{})
+	if err != nil || len(empty) != 1 || len(empty[0].Vertices) != 0 {
+		t.Errorf("empty pattern: %v, %v", empty, err)
+	}
+	none, _ := FindEmbeddings(fromEdges(t, []string{"x", "y", "z"}), h, MatchOptions[string, string]{})
+	if len(none) != 0 {
+		t.Errorf("3-edge matched into 2-edges: %v", none)
+	}
+}
+
+func TestFindEmbeddings_Cutoff(t *testing.T) {
+	t.Parallel()
+	pattern := fromEdges(t, []string{"x", "y"})
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"})
+	got, err := FindEmbeddings(pattern, h, MatchOptions[string, string]{MaxEmbeddings: 3})
+	if !errors.Is(err, ErrCutoff) || len(got) != 3 {
+		t.Errorf("got %d embeddings, err %v; want 3 and ErrCutoff", len(got), err)
+	}
+	got, err = FindEmbeddings(pattern, h, MatchOptions[string, string]{MaxEmbeddings: 4})
+	if err != nil || len(got) != 4 {
+		t.Errorf("exactly MaxEmbeddings: got %d, err %v", len(got), err)
+	}
+
+	rng := rand.New(rand.NewPCG(7, 1))
+	big := randomWeightedHypergraph(rng, 30, 200)
+	_, err = FindEmbeddings(fromEdges(t, []string{"x", "y"}, []string{"y", "z"}, []string{"z", "w"}, []string{"w", "u"}),
+		big, MatchOptions[string, int]{MaxTime: time.Nanosecond})
+	if !errors.Is(err, ErrCutoff) {
+		t.Errorf("MaxTime: err = %v, want ErrCutoff", err)
+	}
+}
+
+func TestFindEmbeddings_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(11, 3))
+	h := randomWeightedHypergraph(rng, 8, 10)
+	pattern := fromEdges(t, []string{"x", "y"}, []string{"y", "z", "w"})
+	got, err := FindEmbeddings(pattern, h, MatchOptions[string, int]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Try every injective vertex map and count host edges for each
+	// pattern edge.
+	want := 0
+	vs := h.Vertices()
+	for _, perm := range permutations(len(vs), 4) {
+		vmap := map[string]int{"x": vs[perm[0]], "y": vs[perm[1]], "z": vs[perm[2]], "w": vs[perm[3]]}
+		var chosen []map[string]bool
+		for _, pe := range []string{"e0", "e1"} {
+			image := map[int]bool{}
+			for p := range pattern.Members(pe, Unordered) {
+				image[vmap[p]] = true
+			}
+			matches := map[string]bool{}
+			for _, id := range h.Edges() {
+				if maps.Equal(image, setOf(h.EdgeMembers(id))) {
+					matches[id] = true
+				}
+			}
+			chosen = append(chosen, matches)
+		}
+		// e0 has 2 members and e1 has 3, so they never share a host edge.
+		want += len(chosen[0]) * len(chosen[1])
+	}
+	if len(got) != want {
+		t.Errorf("got %d embeddings, brute force finds %d", len(got), want)
+	}
+}
+
+func permutations(n, k int) [][]int {
+	if k == 0 {
+		return [][]int{nil}
+	}
+	var out [][]int
+	for _, p := range permutations(n, k-1) {
+		for i := range n {
+			if !slices.Contains(p, i) {
+				out = append(out, append(slices.Clone(p), i))
+			}
+		}
+	}
+	return out
+}
+
+func setOf[T comparable](s []T) map[T]bool {
+	m := make(map[T]bool, len(s))
+	for _, x := range s {
+		m[x] = true
+	}
+	return m
+}
@@ -0,0 +1,245 @@
+package hypergraph
+
+import (
+	"maps"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+)
+
+// NumEdgeMotifs is the number of 3-edge motifs counted by
+// [Hypergraph.EdgeMotifCounts].
+const NumEdgeMotifs = 26
+
+// Regions of the Venn diagram of three edges A, B and C, as bit positions
+// in the patterns returned by [EdgeMotif].
+const (
+	RegionA   = iota // in A only
+	RegionB          // in B only
+	RegionC          // in C only
+	RegionAB         // in A and B, not C
+	RegionAC         // in A and C, not B
+	RegionBC         // in B and C, not A
+	RegionABC        // in all three
+)
+
+// edgeMotifs lists the canonical region pattern of each motif, ascending.
+// motifOf maps every region pattern to its motif, or -1 for patterns of
+// disconnected triples or triples with two equal edges.
+var edgeMotifs, motifOf = buildEdgeMotifs()
+
+// buildEdgeMotifs enumerates the patterns of three distinct, connected
+// edges and groups them up to relabeling the edges.
+func buildEdgeMotifs() ([]uint8, [128]int) {
+	canonical := func(p uint8) uint8 {
+		best := p
+		for _, perm := range [][3]int{{0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}} {
+			var q uint8
+			for mask := 1; mask < 8; mask++ {
+				if p&(1<<regionOfMask[mask]) == 0 {
+					continue
+				}
+				permuted := 0
+				for i := range 3 {
+					if mask&(1<<i) != 0 {
+						permuted |= 1 << perm[i]
+					}
+				}
+				q |= 1 << regionOfMask[permuted]
+			}
+			best = min(best, q)
+		}
+		return best
+	}
+	has := func(p uint8, regions ...int) bool {
+		for _, r := range regions {
+			if p&(1<<r) != 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	var motifs []uint8
+	var of [128]int
+	for p := range uint8(128) {
+		of[p] = -1
+		meets := 0
+		for _, pair := range [][2]int{{RegionAB, RegionABC}, {RegionAC, RegionABC}, {RegionBC, RegionABC}} {
+			if has(p, pair[:]...) {
+				meets++
+			}
+		}
+		distinct := has(p, RegionA, RegionAC, RegionB, RegionBC) &&
+			has(p, RegionA, RegionAB, RegionC, RegionBC) &&
+			has(p, RegionB, RegionAB, RegionC, RegionAC)
+		if meets >= 2 && distinct && canonical(p) == p {
+			motifs = append(motifs, p)
+		}
+	}
+	for p := range uint8(128) {
+		if i, ok := slices.BinarySearch(motifs, canonical(p)); ok {
+			of[p] = i
+		}
+	}
+	return motifs, of
+}
+
+// regionOfMask maps a membership mask (bit 0 for A, 1 for B, 2 for C) to
+// its region.
+var regionOfMask = [8]int{-1, RegionA, RegionB, RegionAB, RegionC, RegionAC, RegionBC, RegionABC}
+
+// EdgeMotif returns the region pattern of motif id, for id in
+// [0, NumEdgeMotifs): bit r is set when region r (see [RegionA] and the
+// following constants) is non-empty, for one labeling of the three edges.
+// Motifs are numbered in ascending order of their smallest pattern.
+func EdgeMotif(id int) uint8 {
+	return edgeMotifs[id]
+}
+
+// IsOpenEdgeMotif reports whether motif id has two disjoint edges; in a
+// closed motif every two of the three edges intersect.
+func IsOpenEdgeMotif(id int) bool {
+	p := edgeMotifs[id]
+	pairs := uint8(1<<RegionAB | 1<<RegionAC | 1<<RegionBC)
+	return p&(1<<RegionABC) == 0 && bits.OnesCount8(p&pairs) < 3
+}
+
+// MotifOptions configures [Hypergraph.EdgeMotifCounts].
+type MotifOptions struct {
+	// Samples, if positive, estimates the counts from this many edges drawn
+	// uniformly at random with replacement instead of counting every
+	// instance.
+	Samples int
+	// Seed makes sampling reproducible.
+	Seed uint64
+}
+
+// EdgeMotifCounts counts the instances of each 3-edge motif (h-motif): the
+// unordered triples of distinct edges that are connected, meaning at least
+// two of the three pairs intersect, classified by which of the seven
+// regions of their Venn diagram are non-empty. Up to relabeling the edges
+// there are [NumEdgeMotifs] such patterns; entry i of the result counts
+// motif i as described by [EdgeMotif]. Triples in which two edges have the
+// same members are not counted. The normalized counts form a motif profile
+// that can be compared across hypergraphs.
+//
+// With opts.Samples > 0 the counts are unbiased estimates: each sampled
+// edge contributes the instances containing it, scaled by |E| / (3 ·
+// Samples). Otherwise they are exact.
+//
+// Time complexity: O(Σ_e |N(e)| · (|N(e)| + Σ_{f ∈ N(e)} |N(f)|) · s) for
+// exact counting, where N(e) are the edges meeting e and s bounds the
+// edge size, and the same per sampled edge for estimation.
+func (h *Hypergraph[V]) EdgeMotifCounts(opts MotifOptions) []float64 {
+	c := newMotifCounter(h)
+	counts := make([]float64, NumEdgeMotifs)
+	if len(c.ids) < 3 {
+		return counts
+	}
+	if opts.Samples <= 0 {
+		for s := range c.ids {
+			c.countAt(s, true, counts)
+		}
+		return counts
+	}
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x6d6f74696673))
+	for range opts.Samples {
+		c.countAt(rng.IntN(len(c.ids)), false, counts)
+	}
+	scale := float64(len(c.ids)) / (3 * float64(opts.Samples))
+	for i := range counts {
+		counts[i] *= scale
+	}
+	return counts
+}
+
+// motifCounter indexes the edges of a hypergraph for motif counting.
+type motifCounter struct {
+	ids     []string
+	members [][]int // members[e] lists the vertex indices of e, ascending
+	nbrs    [][]int // nbrs[e] lists the other edges meeting e, ascending
+	mark    []int   // mark[f] == stamp when f meets the current edge
+	stamp   int
+}
+
+func newMotifCounter[V comparable](h *Hypergraph[V]) *motifCounter {
+	ids := slices.Sorted(maps.Keys(h.edges))
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	vindex := make(map[V]int, len(h.vertices))
+	for v := range h.vertices {
+		vindex[v] = len(vindex)
+	}
+	c := &motifCounter{ids: ids, members: make([][]int, len(ids)), nbrs: make([][]int, len(ids)), mark: make([]int, len(ids))}
+	for i, id := range ids {
+		seen := map[int]bool{i: true}
+		for v := range h.Members(id, Unordered) {
+			c.members[i] = append(c.members[i], vindex[v])
+			for f := range h.IncidentEdges(v, Unordered) {
+				if j := index[f]; !seen[j] {
+					seen[j] = true
+					c.nbrs[i] = append(c.nbrs[i], j)
+				}
+			}
+		}
+		slices.Sort(c.members[i])
+		slices.Sort(c.nbrs[i])
+	}
+	return c
+}
+
+// countAt adds the motif instances containing edge s to counts. With
+// minimal set, only instances in which s is the smallest edge are counted,
+// so that summing over all s counts each instance once.
+func (c *motifCounter) countAt(s int, minimal bool, counts []float64) {
+	c.stamp++
+	for _, x := range c.nbrs[s] {
+		c.mark[x] = c.stamp
+	}
+	ns := c.nbrs[s]
+	if minimal {
+		above, _ := slices.BinarySearch(ns, s)
+		ns = ns[above:]
+	}
+	for i, x := range ns {
+		// Both others meet s.
+		for _, y := range ns[i+1:] {
+			c.classify(s, x, y, counts)
+		}
+		// The third meets only x.
+		for _, y := range c.nbrs[x] {
+			if y != s && c.mark[y] != c.stamp && (!minimal || y > s) {
+				c.classify(s, x, y, counts)
+			}
+		}
+	}
+}
+
+// classify adds one to the count of the motif formed by edges x, y and z,
+// merging their sorted member lists.
+func (c *motifCounter) classify(x, y, z int, counts []float64) {
+	lists := [3][]int{c.members[x], c.members[y], c.members[z]}
+	var p uint8
+	for len(lists[0])+len(lists[1])+len(lists[2]) > 0 {
+		least := -1
+		for _, l := range lists {
+			if len(l) > 0 && (least < 0 || l[0] < least) {
+				least = l[0]
+			}
+		}
+		mask := 0
+		for i, l := range lists {
+			if len(l) > 0 && l[0] == least {
+				mask |= 1 << i
+				lists[i] = l[1:]
+			}
+		}
+		p |= 1 << regionOfMask[mask]
+	}
+	if m := motifOf[p]; m >= 0 {
+		counts[m]++
+	}
+}
@@ -0,0 +1,119 @@
+package hypergraph
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// ============================================================================
+// Motif Table Tests
+// ============================================================================
+
+func TestEdgeMotifs_Table(t *testing.T) {
+	t.Parallel()
+	open := 0
+	for id := range NumEdgeMotifs {
+		if IsOpenEdgeMotif(id) {
+			open++
+		}
+		if id > 0 && EdgeMotif(id) <= EdgeMotif(id-1) {
+			t.Errorf("motifs not ascending at %d", id)
+		}
+	}
+	if open != 6 {
+		t.Errorf("%d open motifs, want 6", open)
+	}
+}
+
+// ============================================================================
+// EdgeMotifCounts Tests
+// ============================================================================
+
+func TestEdgeMotifCounts_Small(t *testing.T) {
+	t.Parallel()
+	// A path of three edges whose ends are disjoint: one open motif.
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"c", "d"})
+	counts := h.EdgeMotifCounts(MotifOptions{})
+	id := motifOf[1<<RegionA|1<<RegionAB|1<<RegionBC|1<<RegionC]
+	if id < 0 || counts[id] != 1 || sum(counts) != 1 || !IsOpenEdgeMotif(id) {
+		t.Errorf("path counts = %v, motif %d", counts, id)
+	}
+
+	// Three edges through one vertex, each with a private vertex.
+	star := fromEdges(t, []string{"o", "a"}, []string{"o", "b"}, []string{"o", "c"})
+	counts = star.EdgeMotifCounts(MotifOptions{})
+	id = motifOf[1<<RegionA|1<<RegionB|1<<RegionC|1<<RegionABC]
+	if counts[id] != 1 || sum(counts) != 1 || IsOpenEdgeMotif(id) {
+		t.Errorf("star counts = %v, motif %d", counts, id)
+	}
+
+	few := fromEdges(t, []string{"a", "b"}, []string{"b", "c"})
+	if sum(few.EdgeMotifCounts(MotifOptions{})) != 0 {
+		t.Error("two edges should have no motifs")
+	}
+	disjoint := fromEdges(t, []string{"a", "b"}, []string{"c", "d"}, []string{"b", "c"}, []string{"x", "y"})
+	if got := sum(disjoint.EdgeMotifCounts(MotifOptions{})); got != 1 {
+		t.Errorf("disconnected triples counted: total %v, want 1", got)
+	}
+}
+
+func TestEdgeMotifCounts_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(5, 9))
+	h := randomWeightedHypergraph(rng, 12, 25)
+	got := h.EdgeMotifCounts(MotifOptions{})
+
+	want := make([]float64, NumEdgeMotifs)
+	ids := h.Edges()
+	slices.Sort(ids)
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			for k := j + 1; k < len(ids); k++ {
+				var p uint8
+				for v := range 12 {
+					mask := 0
+					for bit, id := range []string{ids[i], ids[j], ids[k]} {
+						if slices.Contains(h.EdgeMembers(id), v) {
+							mask |= 1 << bit
+						}
+					}
+					if mask != 0 {
+						p |= 1 << regionOfMask[mask]
+					}
+				}
+				if m := motifOf[p]; m >= 0 {
+					want[m]++
+				}
+			}
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("counts = %v\nbrute force = %v", got, want)
+	}
+}
+
+func TestEdgeMotifCounts_Sampling(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(2, 4))
+	h := randomWeightedHypergraph(rng, 15, 40)
+	exact := h.EdgeMotifCounts(MotifOptions{})
+
+	opts := MotifOptions{Samples: 1000, Seed: 42}
+	a, b := h.EdgeMotifCounts(opts), h.EdgeMotifCounts(opts)
+	if !slices.Equal(a, b) {
+		t.Fatal("sampling with the same seed should be deterministic")
+	}
+	if math.Abs(sum(a)-sum(exact)) > 0.1*sum(exact) {
+		t.Errorf("estimated total %v, exact %v", sum(a), sum(exact))
+	}
+}
+
+func sum(xs []float64) float64 {
+	var s float64
+	for _, x := range xs {
+		s += x
+	}
+	return s
+}
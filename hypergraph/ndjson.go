@@ -0,0 +1,170 @@
+package hypergraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+)
+
+// ndjsonRecord is one line of the NDJSON format: either a vertex, an
+// edge with its members and optional attributes, or a vertex type header.
+type ndjsonRecord[V comparable] struct {
+	VertexType string           `json:"vertex_type,omitempty"`
+	Vertex     *V               `json:"vertex,omitempty"`
+	Edge       *string          `json:"edge,omitempty"`
+	Members    []V              `json:"members,omitempty"`
+	Weight     *float64         `json:"weight,omitempty"`
+	Roles      []RoleBinding[V] `json:"roles,omitempty"`
+}
+
+// WriteNDJSON writes the hypergraph as newline-delimited JSON: one line
+// per vertex, {"vertex": v}, in ascending order, then one line per edge,
+// {"edge": id, "members": [...]}, in ID order, with "weight" and "roles"
+// when the edge has them. Each line is encoded and written as it is
+// produced; only the sorted vertex and edge lists grow with the size of the
+// hypergraph. [WriteNDJSONFunc] with [Unordered] avoids those as well.
+// Hypergraphs with integer vertices start with a header line,
+// {"vertex_type": "int"}.
+func (h *Hypergraph[V]) WriteNDJSON(w io.Writer) error {
+	return WriteNDJSONFunc(w, h, Sorted, keepVertex[V])
+}
+
+// WriteNDJSONFunc writes h like [Hypergraph.WriteNDJSON] with every vertex
+// converted by f, which should be one-to-one, so that the file records
+// vertex type W without building a converted copy of h. With [Unordered]
+// vertices and edges are written in map order and memory use does not grow
+// with the size of the hypergraph; with [Sorted] they are written in the
+// order of WriteNDJSON.
+func WriteNDJSONFunc[V, W comparable](w io.Writer, h *Hypergraph[V], order Order, f func(V) (W, error)) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if t := vertexTypeOf[W](); t != VertexTypeString {
+		if err := enc.Encode(ndjsonRecord[W]{VertexType: t}); err != nil {
+			return err
+		}
+	}
+	for v := range inOrder(maps.Keys(h.vertices), order, h.compare) {
+		x, err := f(v)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(ndjsonRecord[W]{Vertex: &x}); err != nil {
+			return err
+		}
+	}
+	for id := range inOrder(maps.Keys(h.edges), order, strings.Compare) {
+		rec := ndjsonRecord[W]{Edge: &id}
+		for v := range h.Members(id, order) {
+			x, err := f(v)
+			if err != nil {
+				return err
+			}
+			rec.Members = append(rec.Members, x)
+		}
+		for _, b := range h.roles[id] {
+			x, err := f(b.Vertex)
+			if err != nil {
+				return err
+			}
+			rec.Roles = append(rec.Roles, RoleBinding[W]{b.Role, x})
+		}
+		if weight, ok := h.weights[id]; ok {
+			rec.Weight = &weight
+		}
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// LoadNDJSON reads a hypergraph written by [Hypergraph.WriteNDJSON],
+// adding each line to the hypergraph as it is decoded. Lines may come in
+// any order, edges may name vertices without a vertex line, and blank
+// lines are skipped. Errors report the 1-based line number.
+func LoadNDJSON[V comparable](r io.Reader) (*Hypergraph[V], error) {
+	return LoadNDJSONFunc(r, keepVertex[V])
+}
+
+// LoadNDJSONFunc reads a file of vertex type W like [LoadNDJSON] into a
+// hypergraph over V, converting every vertex with f, which should be
+// one-to-one, as its line is decoded.
+func LoadNDJSONFunc[V, W comparable](r io.Reader, f func(W) (V, error)) (*Hypergraph[V], error) {
+	h := NewHypergraph[V]()
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		data, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			if recErr := addNDJSONRecord(h, data, f); recErr != nil {
+				return nil, fmt.Errorf("line %d: %w", line, recErr)
+			}
+		}
+		if err != nil {
+			return h, nil
+		}
+	}
+}
+
+func addNDJSONRecord[V, W comparable](h *Hypergraph[V], data []byte, f func(W) (V, error)) error {
+	var rec ndjsonRecord[W]
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rec); err != nil {
+		return checkVertexType[W](rec.VertexType, err)
+	}
+	if dec.More() {
+		return errors.New("more than one value on the line")
+	}
+	switch {
+	case rec.VertexType != "":
+		if rec.Vertex != nil || rec.Edge != nil || rec.Members != nil || rec.Weight != nil || rec.Roles != nil {
+			return errors.New("vertex type header with other fields")
+		}
+		return checkVertexType[W](rec.VertexType, nil)
+	case rec.Vertex != nil && rec.Edge != nil:
+		return errors.New(`record has both "vertex" and "edge"`)
+	case rec.Vertex != nil:
+		if rec.Members != nil || rec.Weight != nil || rec.Roles != nil {
+			return errors.New("vertex record with edge fields")
+		}
+		v, err := f(*rec.Vertex)
+		if err != nil {
+			return err
+		}
+		h.AddVertex(v)
+		return nil
+	case rec.Edge != nil:
+		id := *rec.Edge
+		members, err := convertVertices(rec.Members, f)
+		if err != nil {
+			return fmt.Errorf("edge %q: %w", id, err)
+		}
+		if err := h.AddEdge(id, members); err != nil {
+			return fmt.Errorf("edge %q: %w", id, err)
+		}
+		if rec.Weight != nil {
+			if err := h.SetEdgeWeight(id, *rec.Weight); err != nil {
+				return fmt.Errorf("edge %q: %w", id, err)
+			}
+		}
+		if rec.Roles != nil {
+			bindings, err := convertBindings(rec.Roles, f)
+			if err == nil {
+				err = h.SetEdgeRoles(id, bindings)
+			}
+			if err != nil {
+				return fmt.Errorf("edge %q: %w", id, err)
+			}
+		}
+		return nil
+	}
+	return errors.New(`record has neither "vertex" nor "edge"`)
+}
@@ -0,0 +1,160 @@
+package hypergraph
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// NDJSON Tests
+// ============================================================================
+
+func TestNDJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	_ = h.SetEdgeWeight("f1", 0.5)
+	h.AddVertex("loner")
+
+	var buf bytes.Buffer
+	if err := h.WriteNDJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != h.NumVertices()+h.NumEdges() {
+		t.Fatalf("wrote %d lines:\n%s", len(lines), buf.String())
+	}
+	if lines[0] != `{"vertex":"alice"}` {
+		t.Errorf("first line = %s", lines[0])
+	}
+	if want := `{"edge":"f1","members":["alice","bob","carol"],"weight":0.5,"roles":[{"role":"subject","vertex":"alice"},{"role":"object","vertex":"bob"},{"role":"witness","vertex":"carol"}]}`; !strings.Contains(buf.String(), want+"\n") {
+		t.Errorf("missing edge line %s in\n%s", want, buf.String())
+	}
+
+	got, err := LoadNDJSON[string](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Diff(h, got); !d.IsEmpty() {
+		t.Errorf("round trip differs: %+v", d)
+	}
+}
+
+func TestNDJSON_RandomRoundTrip(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(43, 1))
+	h := randomWeightedHypergraph(rng, 40, 60)
+	var buf bytes.Buffer
+	_ = h.WriteNDJSON(&buf)
+	got, err := LoadNDJSON[int](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Diff(h, got); !d.IsEmpty() {
+		t.Errorf("round trip differs: %+v", d)
+	}
+}
+
+func TestNDJSON_VertexType(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	_ = h.AddEdge("e", []int{10, 2})
+	var buf bytes.Buffer
+	_ = h.WriteNDJSON(&buf)
+	if want := "{\"vertex_type\":\"int\"}\n{\"vertex\":2}\n{\"vertex\":10}\n"; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("WriteNDJSON = %s", buf.String())
+	}
+	if _, err := LoadNDJSON[string](bytes.NewReader(buf.Bytes())); err == nil || err.Error() != "line 1: file has int vertices, not string" {
+		t.Errorf("LoadNDJSON[string] error = %v", err)
+	}
+	if _, err := LoadNDJSON[string](strings.NewReader(`{"vertex_type": "int", "vertex": "a"}`)); err == nil {
+		t.Error("header with other fields should be an error")
+	}
+}
+
+func TestNDJSONFunc(t *testing.T) {
+	t.Parallel()
+	ints := NewHypergraph[int]()
+	_ = ints.AddRoleEdge("e", []RoleBinding[int]{{"from", 10}, {"to", 2}})
+	_ = ints.SetEdgeWeight("e", 2)
+	ints.AddVertex(7)
+	var want bytes.Buffer
+	_ = ints.WriteNDJSON(&want)
+
+	h, err := LoadNDJSONFunc(bytes.NewReader(want.Bytes()), func(v int) (string, error) { return strconv.Itoa(v), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetCompare(CompareBy(func(v string) int { n, _ := strconv.Atoi(v); return n }))
+	var got bytes.Buffer
+	if err := WriteNDJSONFunc(&got, h, Sorted, strconv.Atoi); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("WriteNDJSONFunc = %s, want %s", got.String(), want.String())
+	}
+
+	got.Reset()
+	if err := WriteNDJSONFunc(&got, ints, Unordered, keepVertex[int]); err != nil {
+		t.Fatal(err)
+	}
+	back, err := LoadNDJSON[int](&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Diff(ints, back); !d.IsEmpty() {
+		t.Errorf("unordered round trip differs: %+v", d)
+	}
+
+	h.AddVertex("x")
+	if err := WriteNDJSONFunc(&got, h, Unordered, strconv.Atoi); err == nil {
+		t.Error("WriteNDJSONFunc should return the conversion error")
+	}
+	_, err = LoadNDJSONFunc(strings.NewReader(`{"edge": "e", "members": [1, -1]}`), func(v int) (uint, error) {
+		if v < 0 {
+			return 0, errors.New("negative")
+		}
+		return uint(v), nil
+	})
+	if err == nil || err.Error() != `line 1: edge "e": negative` {
+		t.Errorf("LoadNDJSONFunc error = %v", err)
+	}
+}
+
+func TestLoadNDJSON_Lenient(t *testing.T) {
+	t.Parallel()
+	in := "{\"edge\": \"e1\", \"members\": [\"a\", \"b\"]}\n\n{\"vertex\": \"c\"}\r\n{\"edge\": \"e2\", \"members\": [\"b\", \"d\"]}"
+	h, err := LoadNDJSON[string](strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.NumVertices() != 4 || h.NumEdges() != 2 {
+		t.Errorf("loaded %d vertices, %d edges", h.NumVertices(), h.NumEdges())
+	}
+}
+
+func TestLoadNDJSON_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in, want string
+	}{
+		{"{\"vertex\": \"a\"}\n{\"vertex\": ", "line 2: unexpected EOF"},
+		{`{"vertex": "a", "edge": "e"}`, `line 1: record has both "vertex" and "edge"`},
+		{`{"vertex": "a", "weight": 2}`, "line 1: vertex record with edge fields"},
+		{`{}`, `line 1: record has neither "vertex" nor "edge"`},
+		{`{"edge": "e", "members": []}`, `line 1: edge "e": edge cannot be empty`},
+		{"{\"edge\": \"e\", \"members\": [\"a\"]}\n{\"edge\": \"e\", \"members\": [\"b\"]}", `line 2: edge "e": duplicate edge ID`},
+		{`{"edge": "e", "members": ["a"], "weight": -1}`, "line 1: edge \"e\": invalid edge weight -1"},
+		{`{"vertex": "a", "colour": "red"}`, `line 1: json: unknown field "colour"`},
+		{`{"vertex": "a"} {"vertex": "b"}`, "line 1: more than one value on the line"},
+	}
+	for _, tt := range tests {
+		_, err := LoadNDJSON[string](strings.NewReader(tt.in))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("LoadNDJSON(%q) error = %v, want %q", tt.in, err, tt.want)
+		}
+	}
+}
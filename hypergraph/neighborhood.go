@@ -0,0 +1,139 @@
+package hypergraph
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Order selects the iteration order of the neighborhood accessors.
+type Order int
+
+const (
+	// Unordered yields elements in map iteration order without allocating.
+	Unordered Order = iota
+	// Sorted yields elements in ascending order. It collects the elements
+	// into a slice first, so it allocates once per iteration.
+	Sorted
+)
+
+// inOrder returns seq unchanged for Unordered, or a copy sorted by compare
+// for Sorted.
+func inOrder[T any](seq iter.Seq[T], order Order, compare func(a, b T) int) iter.Seq[T] {
+	if order != Sorted {
+		return seq
+	}
+	return func(yield func(T) bool) {
+		for _, x := range slices.SortedFunc(seq, compare) {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// IncidentEdges returns the IDs of the edges containing v.
+// The hypergraph must not be modified during iteration.
+func (h *Hypergraph[V]) IncidentEdges(v V, order Order) iter.Seq[string] {
+	return inOrder(maps.Keys(h.vertexToEdges[v]), order, strings.Compare)
+}
+
+// Members returns the members of an edge, or nothing if the edge does not
+// exist. Unlike EdgeMembers it does not allocate for Unordered iteration.
+// The hypergraph must not be modified during iteration.
+func (h *Hypergraph[V]) Members(id string, order Order) iter.Seq[V] {
+	return inOrder(maps.Keys(h.edges[id].Set), order, h.compare)
+}
+
+// Neighbors returns the vertices sharing at least one edge with v, each
+// once. v itself is not included. The hypergraph must not be modified
+// during iteration.
+func (h *Hypergraph[V]) Neighbors(v V, order Order) iter.Seq[V] {
+	seq := func(yield func(V) bool) {
+		seen := map[V]struct{}{v: {}}
+		for e := range h.IncidentEdges(v, Unordered) {
+			for u := range h.Members(e, Unordered) {
+				if _, dup := seen[u]; dup {
+					continue
+				}
+				seen[u] = struct{}{}
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+	return inOrder(seq, order, h.compare)
+}
+
+// EdgeIntersection returns the vertices belonging to both edges. It is
+// empty if either edge does not exist. The hypergraph must not be modified
+// during iteration.
+func (h *Hypergraph[V]) EdgeIntersection(e1, e2 string, order Order) iter.Seq[V] {
+	seq := func(yield func(V) bool) {
+		small, large := h.edges[e1].Set, h.edges[e2].Set
+		if len(small) > len(large) {
+			small, large = large, small
+		}
+		for v := range small {
+			if _, ok := large[v]; ok && !yield(v) {
+				return
+			}
+		}
+	}
+	return inOrder(seq, order, h.compare)
+}
+
+// KHopNeighborhood yields every vertex within k hops of v together with
+// its distance, in breadth-first order starting with (v, 0). With Sorted,
+// vertices at the same distance are yielded in ascending order; with
+// Unordered their order within a level is unspecified. A negative k yields
+// nothing. The hypergraph must not be modified during iteration.
+func (h *Hypergraph[V]) KHopNeighborhood(v V, k int, order Order) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		if k < 0 || !h.HasVertex(v) {
+			return
+		}
+		seen := map[V]struct{}{v: {}}
+		level := []V{v}
+		for dist := 0; len(level) > 0; dist++ {
+			for _, u := range level {
+				if !yield(u, dist) {
+					return
+				}
+			}
+			if dist == k {
+				return
+			}
+			var next []V
+			for _, u := range level {
+				for e := range h.IncidentEdges(u, Unordered) {
+					for w := range h.Members(e, Unordered) {
+						if _, ok := seen[w]; !ok {
+							seen[w] = struct{}{}
+							next = append(next, w)
+						}
+					}
+				}
+			}
+			if order == Sorted {
+				h.sortVertices(next)
+			}
+			level = next
+		}
+	}
+}
+
+// NeighborhoodHypergraph returns the subhypergraph induced by all vertices
+// within k hops of any vertex in seeds. With k = 1 it contains every edge
+// incident to a seed. Seeds that are not in h are ignored.
+func (h *Hypergraph[V]) NeighborhoodHypergraph(seeds []V, k int) *Hypergraph[V] {
+	region := make(map[V]struct{})
+	for _, s := range seeds {
+		for u := range h.KHopNeighborhood(s, k, Unordered) {
+			region[u] = struct{}{}
+		}
+	}
+	return h.InducedSubhypergraph(slices.Collect(maps.Keys(region)))
+}
@@ -0,0 +1,170 @@
+package hypergraph
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// neighborhoodTestGraph returns a graph with a path A-B-C-D-E built from
+// overlapping edges, a large edge around A, and an isolated vertex.
+func neighborhoodTestGraph() *Hypergraph[string] {
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+	_ = h.AddEdge("E3", []string{"D", "E"})
+	_ = h.AddEdge("E4", []string{"A", "X", "Y"})
+	h.AddVertex("Z")
+	return h
+}
+
+// ============================================================================
+// IncidentEdges / Members / Neighbors Tests
+// ============================================================================
+
+func TestIncidentEdges(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	if got := slices.Collect(h.IncidentEdges("C", Sorted)); !slices.Equal(got, []string{"E1", "E2"}) {
+		t.Errorf("IncidentEdges(C) = %v, want [E1 E2]", got)
+	}
+	if got := slices.Sorted(h.IncidentEdges("A", Unordered)); !slices.Equal(got, []string{"E1", "E4"}) {
+		t.Errorf("IncidentEdges(A) = %v, want [E1 E4]", got)
+	}
+	if got := slices.Collect(h.IncidentEdges("Z", Sorted)); len(got) != 0 {
+		t.Errorf("IncidentEdges(Z) = %v, want empty", got)
+	}
+	if got := slices.Collect(h.IncidentEdges("missing", Unordered)); len(got) != 0 {
+		t.Errorf("IncidentEdges(missing) = %v, want empty", got)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	if got := slices.Collect(h.Members("E1", Sorted)); !slices.Equal(got, []string{"A", "B", "C"}) {
+		t.Errorf("Members(E1) = %v, want [A B C]", got)
+	}
+	if got := slices.Collect(h.Members("missing", Sorted)); len(got) != 0 {
+		t.Errorf("Members(missing) = %v, want empty", got)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	if got := slices.Collect(h.Neighbors("A", Sorted)); !slices.Equal(got, []string{"B", "C", "X", "Y"}) {
+		t.Errorf("Neighbors(A) = %v, want [B C X Y]", got)
+	}
+	// C is in E1 and E2; each neighbor appears once and C itself is excluded.
+	if got := slices.Sorted(h.Neighbors("C", Unordered)); !slices.Equal(got, []string{"A", "B", "D"}) {
+		t.Errorf("Neighbors(C) = %v, want [A B D]", got)
+	}
+	if got := slices.Collect(h.Neighbors("Z", Sorted)); len(got) != 0 {
+		t.Errorf("Neighbors(Z) = %v, want empty", got)
+	}
+}
+
+func TestNeighbors_EarlyBreak(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+	for _, order := range []Order{Unordered, Sorted} {
+		n := 0
+		for range h.Neighbors("A", order) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("order %d: iterated %d times after break, want 1", order, n)
+		}
+	}
+}
+
+// ============================================================================
+// EdgeIntersection Tests
+// ============================================================================
+
+func TestEdgeIntersection(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	if got := slices.Collect(h.EdgeIntersection("E1", "E2", Sorted)); !slices.Equal(got, []string{"C"}) {
+		t.Errorf("EdgeIntersection(E1, E2) = %v, want [C]", got)
+	}
+	if got := slices.Collect(h.EdgeIntersection("E1", "E1", Sorted)); !slices.Equal(got, []string{"A", "B", "C"}) {
+		t.Errorf("EdgeIntersection(E1, E1) = %v, want [A B C]", got)
+	}
+	if got := slices.Collect(h.EdgeIntersection("E1", "E3", Sorted)); len(got) != 0 {
+		t.Errorf("EdgeIntersection(E1, E3) = %v, want empty", got)
+	}
+	if got := slices.Collect(h.EdgeIntersection("E1", "missing", Sorted)); len(got) != 0 {
+		t.Errorf("EdgeIntersection with missing edge = %v, want empty", got)
+	}
+}
+
+// ============================================================================
+// KHopNeighborhood Tests
+// ============================================================================
+
+func TestKHopNeighborhood_SortedOrder(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	type hop struct {
+		v string
+		d int
+	}
+	var got []hop
+	for v, d := range h.KHopNeighborhood("C", 2, Sorted) {
+		got = append(got, hop{v, d})
+	}
+	want := []hop{{"C", 0}, {"A", 1}, {"B", 1}, {"D", 1}, {"E", 2}, {"X", 2}, {"Y", 2}}
+	if !slices.Equal(got, want) {
+		t.Errorf("KHopNeighborhood(C, 2) = %v, want %v", got, want)
+	}
+}
+
+func TestKHopNeighborhood_Limits(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	zero := maps.Collect(h.KHopNeighborhood("A", 0, Unordered))
+	if len(zero) != 1 || zero["A"] != 0 {
+		t.Errorf("k=0 neighborhood = %v, want only A", zero)
+	}
+	if got := maps.Collect(h.KHopNeighborhood("A", -1, Unordered)); len(got) != 0 {
+		t.Errorf("k=-1 neighborhood = %v, want empty", got)
+	}
+	if got := maps.Collect(h.KHopNeighborhood("missing", 3, Unordered)); len(got) != 0 {
+		t.Errorf("neighborhood of missing vertex = %v, want empty", got)
+	}
+	all := maps.Collect(h.KHopNeighborhood("A", 100, Unordered))
+	if len(all) != 7 || all["E"] != 3 {
+		t.Errorf("unbounded neighborhood = %v, want 7 vertices with E at 3", all)
+	}
+}
+
+// ============================================================================
+// NeighborhoodHypergraph Tests
+// ============================================================================
+
+func TestNeighborhoodHypergraph(t *testing.T) {
+	t.Parallel()
+	h := neighborhoodTestGraph()
+
+	sub := h.NeighborhoodHypergraph([]string{"E", "missing"}, 1)
+	if got := slices.Sorted(maps.Keys(sub.edges)); !slices.Equal(got, []string{"E3"}) {
+		t.Errorf("1-hop edges around E = %v, want [E3]", got)
+	}
+
+	sub = h.NeighborhoodHypergraph([]string{"B", "E"}, 1)
+	if got := slices.Sorted(maps.Keys(sub.edges)); !slices.Equal(got, []string{"E1", "E2", "E3"}) {
+		t.Errorf("1-hop edges around B,E = %v, want [E1 E2 E3]", got)
+	}
+	if sub.HasVertex("X") {
+		t.Error("X is two hops from B and should be excluded")
+	}
+}
@@ -1,5 +1,7 @@
 package hypergraph
 
+import "slices"
+
 // IsEmpty checks if the hypergraph has no vertices or edges.
 func (h *Hypergraph[V]) IsEmpty() bool {
 	return len(h.vertices) == 0 && len(h.edges) == 0
@@ -7,16 +9,68 @@ func (h *Hypergraph[V]) IsEmpty() bool {
 
 // Copy returns a deep copy of the hypergraph.
 func (h *Hypergraph[V]) Copy() *Hypergraph[V] {
-	copy := NewHypergraph[V]()
+	copy := h.empty()
 	for v := range h.vertices {
 		copy.AddVertex(v)
 	}
-	for id, edge := range h.edges {
-		members := make([]V, 0)
-		for v := range edge.Set {
-			members = append(members, v)
-		}
-		copy.AddEdge(id, members) //nolint:errcheck // original edges are valid and IDs unique
+	for id := range h.edges {
+		copy.AddEdge(id, h.EdgeMembers(id)) //nolint:errcheck // original edges are valid and IDs unique
+		copy.copyEdgeAttributes(h, id)
 	}
 	return copy
 }
+
+// InducedSubhypergraph returns the subhypergraph induced by the given vertices.
+// It keeps every listed vertex that exists in h and every edge whose members
+// all lie within that set. Edge IDs, weights and roles are preserved.
+func (h *Hypergraph[V]) InducedSubhypergraph(vertices []V) *Hypergraph[V] {
+	sub := h.empty()
+	for _, v := range vertices {
+		if h.HasVertex(v) {
+			sub.AddVertex(v)
+		}
+	}
+	for id := range h.edges {
+		members := h.EdgeMembers(id)
+		if !slices.ContainsFunc(members, func(v V) bool { return !sub.HasVertex(v) }) {
+			sub.AddEdge(id, members) //nolint:errcheck // IDs unique in source graph
+			sub.copyEdgeAttributes(h, id)
+		}
+	}
+	return sub
+}
+
+// MapVertices returns a copy of h with every vertex v replaced by f(v),
+// keeping edge IDs, weights and roles. It stops at the first error f
+// returns. f should be one-to-one; vertices it maps to the same value are
+// merged. The copy sorts its vertices in the natural order of W.
+func MapVertices[V, W comparable](h *Hypergraph[V], f func(V) (W, error)) (*Hypergraph[W], error) {
+	to := make(map[V]W, len(h.vertices))
+	out := NewHypergraph[W]()
+	for v := range h.vertices {
+		w, err := f(v)
+		if err != nil {
+			return nil, err
+		}
+		to[v] = w
+		out.AddVertex(w)
+	}
+	for id, e := range h.edges {
+		members := make([]W, 0, len(e.Set))
+		for v := range e.Set {
+			members = append(members, to[v])
+		}
+		out.AddEdge(id, members) //nolint:errcheck // IDs unique in source graph
+		if w, ok := h.weights[id]; ok {
+			out.weights[id] = w
+		}
+		if bindings, ok := h.roles[id]; ok {
+			mapped := make([]RoleBinding[W], len(bindings))
+			for i, b := range bindings {
+				mapped[i] = RoleBinding[W]{b.Role, to[b.Vertex]}
+			}
+			out.roles[id] = mapped
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,136 @@
+package hypergraph
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// CompareBy returns a comparison function that orders values by a key, for
+// vertex types that are not ordered themselves:
+//
+//	type Addr struct{ Host string; Port int }
+//	h := NewHypergraphFunc(CompareBy(func(a Addr) string { return a.Host }))
+//
+// Values with equal keys compare as equal, so for deterministic output
+// the key should tell distinct vertices apart.
+func CompareBy[V any, K cmp.Ordered](key func(V) K) func(a, b V) int {
+	return func(a, b V) int { return cmp.Compare(key(a), key(b)) }
+}
+
+// defaultCompare returns the comparison used when none is given:
+// [cmp.Compare] for the built-in ordered types, and otherwise a
+// comparison by value that orders numbers numerically, strings and
+// booleans in the usual way, and structs and arrays field by field or
+// element by element.
+func defaultCompare[V comparable]() func(a, b V) int {
+	var f any
+	switch any(*new(V)).(type) {
+	case string:
+		f = cmp.Compare[string]
+	case int:
+		f = cmp.Compare[int]
+	case int64:
+		f = cmp.Compare[int64]
+	case int32:
+		f = cmp.Compare[int32]
+	case uint:
+		f = cmp.Compare[uint]
+	case uint64:
+		f = cmp.Compare[uint64]
+	case uint32:
+		f = cmp.Compare[uint32]
+	case float64:
+		f = cmp.Compare[float64]
+	}
+	if f != nil {
+		return f.(func(a, b V) int)
+	}
+	return func(a, b V) int {
+		return compareValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+// compareValues orders two values of the same type. Kinds without a
+// natural order, such as pointers and channels, compare by their
+// formatted form, which is consistent within a process but not across
+// processes.
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := range a.Len() {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolInt(!a.IsNil()), boolInt(!b.IsNil()))
+		}
+		if a.Elem().Type() == b.Elem().Type() {
+			return compareValues(a.Elem(), b.Elem())
+		}
+		return cmp.Compare(a.Elem().Type().String(), b.Elem().Type().String())
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Compare orders two vertices the way the hypergraph sorts them. It is
+// the function given to [NewHypergraphFunc] or [Hypergraph.SetCompare],
+// or by default the natural order of V.
+func (h *Hypergraph[V]) Compare(a, b V) int {
+	return h.compare(a, b)
+}
+
+// SetCompare sets the order in which the hypergraph sorts vertices, for
+// hypergraphs built by functions such as [LoadJSON] that cannot be given
+// one. A nil compare restores the default. Hypergraphs derived from h,
+// such as copies and subhypergraphs, inherit the order.
+func (h *Hypergraph[V]) SetCompare(compare func(a, b V) int) {
+	if compare == nil {
+		compare = defaultCompare[V]()
+	}
+	h.compare = compare
+}
+
+// sorted collects seq into a slice in vertex order.
+func (h *Hypergraph[V]) sorted(seq iter.Seq[V]) []V {
+	return slices.SortedFunc(seq, h.compare)
+}
+
+// sortVertices sorts vs in place in vertex order.
+func (h *Hypergraph[V]) sortVertices(vs []V) {
+	slices.SortFunc(vs, h.compare)
+}
+
+// empty returns an empty hypergraph with the same vertex order as h.
+func (h *Hypergraph[V]) empty() *Hypergraph[V] {
+	return NewHypergraphFunc(h.compare)
+}
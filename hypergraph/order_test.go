@@ -0,0 +1,157 @@
+package hypergraph
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type addr struct {
+	Host string
+	Port int
+}
+
+// ============================================================================
+// Default Order Tests
+// ============================================================================
+
+func TestDefaultCompare_Ints(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	_ = h.AddEdge("e", []int{10, 2, 1})
+	if got := slices.Collect(h.Members("e", Sorted)); !slices.Equal(got, []int{1, 2, 10}) {
+		t.Errorf("Members = %v, want [1 2 10]", got)
+	}
+}
+
+func TestDefaultCompare_Structs(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[addr]()
+	a, b, c := addr{"db", 5432}, addr{"api", 8080}, addr{"api", 443}
+	_ = h.AddEdge("e1", []addr{a, b})
+	_ = h.AddEdge("e2", []addr{b, c})
+
+	want := []addr{c, b, a}
+	if got := h.TwoSection().ConnectedComponents(); len(got) != 1 || !slices.Equal(got[0], want) {
+		t.Errorf("TwoSection components = %v, want [%v]", got, want)
+	}
+	if got := slices.Collect(h.Neighbors(b, Sorted)); !slices.Equal(got, []addr{c, a}) {
+		t.Errorf("Neighbors = %v", got)
+	}
+}
+
+func TestDefaultCompare_Kinds(t *testing.T) {
+	t.Parallel()
+	type pair struct {
+		A [2]int8
+		B bool
+		C any
+	}
+	compare := defaultCompare[pair]()
+	tests := []struct {
+		a, b pair
+		want int
+	}{
+		{pair{A: [2]int8{1, 2}}, pair{A: [2]int8{1, 3}}, -1},
+		{pair{B: true}, pair{B: false}, 1},
+		{pair{C: 2}, pair{C: 10}, -1},
+		{pair{C: nil}, pair{C: "x"}, -1},
+		{pair{C: 1}, pair{C: "x"}, -1}, // "int" < "string"
+		{pair{C: "x"}, pair{C: "x"}, 0},
+	}
+	for _, tt := range tests {
+		if got := compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("compare(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// ============================================================================
+// Custom Order Tests
+// ============================================================================
+
+func TestCompareBy(t *testing.T) {
+	t.Parallel()
+	byPort := CompareBy(func(a addr) int { return a.Port })
+	h := NewHypergraphFunc(byPort)
+	a, b, c := addr{"db", 5432}, addr{"api", 8080}, addr{"api", 443}
+	_ = h.AddEdge("e", []addr{a, b, c})
+	if got := slices.Collect(h.Members("e", Sorted)); !slices.Equal(got, []addr{c, a, b}) {
+		t.Errorf("Members = %v, want ports 443, 5432, 8080", got)
+	}
+	if h.Compare(c, a) >= 0 {
+		t.Error("Compare should use the given function")
+	}
+}
+
+func TestSetCompare_Inherited(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("e1", []string{"10", "2", "1"})
+	_ = h.AddEdge("e2", []string{"2", "30"})
+	h.SetCompare(cmpNumeric)
+
+	want := []string{"1", "2", "10", "30"}
+	if c := h.Copy(); !slices.Equal(sortedBy(c, c.Vertices()), want) {
+		t.Errorf("Copy order = %v, want %v", sortedBy(c, c.Vertices()), want)
+	}
+	if sub := h.InducedSubhypergraph(want); !slices.Equal(sortedBy(sub, sub.Vertices()), want) {
+		t.Errorf("InducedSubhypergraph order = %v, want %v", sortedBy(sub, sub.Vertices()), want)
+	}
+	if got := h.TwoSection().ConnectedComponents(); !slices.Equal(got[0], want) {
+		t.Errorf("TwoSection components = %v, want [%v]", got, want)
+	}
+	if got := h.SimplicialComplex(0).Simplices(0); !slices.EqualFunc(got, [][]string{{"1"}, {"2"}, {"10"}, {"30"}}, slices.Equal) {
+		t.Errorf("0-simplices = %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := h.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"vertices":["1","2","10","30"]`) {
+		t.Errorf("SaveJSON = %s", buf.String())
+	}
+
+	h.SetCompare(nil)
+	if got := sortedBy(h, h.Vertices()); !slices.Equal(got, []string{"1", "10", "2", "30"}) {
+		t.Errorf("after SetCompare(nil) = %v, want lexical order", got)
+	}
+}
+
+func TestMapVertices(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	_ = h.SetEdgeWeight("f1", 0.5)
+	h.AddVertex("loner")
+	upper, err := MapVertices(h, func(v string) (string, error) { return strings.ToUpper(v), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper.NumVertices() != h.NumVertices() || !upper.HasVertex("LONER") || upper.EdgeWeight("f1") != 0.5 {
+		t.Errorf("vertices %v, weight %v", upper.Vertices(), upper.EdgeWeight("f1"))
+	}
+	if got := upper.EdgeRoles("f1"); len(got) != 3 || got[0] != (RoleBinding[string]{"subject", "ALICE"}) {
+		t.Errorf("roles = %v", got)
+	}
+
+	_, err = MapVertices(h, func(v string) (int, error) { return 0, errors.New("not a number") })
+	if err == nil {
+		t.Error("expected the error from f")
+	}
+}
+
+// cmpNumeric orders decimal strings by length, then lexically.
+func cmpNumeric(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+func sortedBy[V comparable](h *Hypergraph[V], vs []V) []V {
+	h.sortVertices(vs)
+	return vs
+}
@@ -0,0 +1,295 @@
+package hypergraph
+
+import (
+	"maps"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// The parallel algorithms below only read the hypergraph, so several may run
+// at once, but none may overlap with a modification. Each takes a worker
+// count; workers <= 0 means runtime.GOMAXPROCS(0). Results do not depend on
+// the worker count or on scheduling.
+
+func workerCount(workers int) int {
+	if workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// parallelChunks splits [0, n) into at most workers contiguous chunks and
+// calls fn on each chunk in its own goroutine, with the chunk's number.
+func parallelChunks(n, workers int, fn func(chunk, lo, hi int)) {
+	workers = min(workerCount(workers), max(n, 1))
+	size := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for c := range workers {
+		lo, hi := c*size, min((c+1)*size, n)
+		if lo >= hi {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(c, lo, hi)
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelBFS performs a level-synchronous breadth-first search from
+// start, expanding each level with up to workers goroutines. It returns the
+// reachable vertices ordered by distance from start and, within a level, in
+// ascending order. Returns nil if start is not a vertex.
+func (h *Hypergraph[V]) ParallelBFS(start V, workers int) []V {
+	if !h.HasVertex(start) {
+		return nil
+	}
+	visited := map[V]struct{}{start: {}}
+	result := []V{start}
+	frontier := []V{start}
+	for len(frontier) > 0 {
+		found := make([][]V, workerCount(workers))
+		parallelChunks(len(frontier), workers, func(c, lo, hi int) {
+			for _, v := range frontier[lo:hi] {
+				for e := range h.IncidentEdges(v, Unordered) {
+					for u := range h.Members(e, Unordered) {
+						if _, ok := visited[u]; !ok {
+							found[c] = append(found[c], u)
+						}
+					}
+				}
+			}
+		})
+		var next []V
+		for _, chunk := range found {
+			for _, u := range chunk {
+				if _, ok := visited[u]; !ok {
+					visited[u] = struct{}{}
+					next = append(next, u)
+				}
+			}
+		}
+		h.sortVertices(next)
+		result = append(result, next...)
+		frontier = next
+	}
+	return result
+}
+
+// ParallelConnectedComponents returns the connected components using a
+// concurrent union-find over the edges, split among up to workers
+// goroutines. Each component is sorted and components are ordered by their
+// smallest vertex.
+func (h *Hypergraph[V]) ParallelConnectedComponents(workers int) [][]V {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int64, len(vertices))
+	for i, v := range vertices {
+		index[v] = int64(i)
+	}
+	uf := newConcurrentUnionFind(len(vertices))
+	edges := slices.Collect(maps.Keys(h.edges))
+	parallelChunks(len(edges), workers, func(_, lo, hi int) {
+		for _, id := range edges[lo:hi] {
+			first := int64(-1)
+			for v := range h.Members(id, Unordered) {
+				if first < 0 {
+					first = index[v]
+					continue
+				}
+				uf.union(first, index[v])
+			}
+		}
+	})
+
+	// Roots are the smallest index of each component, so grouping in index
+	// order yields sorted components ordered by smallest vertex.
+	var components [][]V
+	slot := make(map[int64]int)
+	for i, v := range vertices {
+		root := uf.find(int64(i))
+		c, ok := slot[root]
+		if !ok {
+			c = len(components)
+			slot[root] = c
+			components = append(components, nil)
+		}
+		components[c] = append(components[c], v)
+	}
+	return components
+}
+
+// concurrentUnionFind is a lock-free disjoint-set forest. Unions always
+// link the larger root under the smaller, so the final root of every set
+// is its smallest element whatever order unions happen in.
+type concurrentUnionFind struct {
+	parent []atomic.Int64
+}
+
+func newConcurrentUnionFind(n int) *concurrentUnionFind {
+	uf := &concurrentUnionFind{parent: make([]atomic.Int64, n)}
+	for i := range uf.parent {
+		uf.parent[i].Store(int64(i))
+	}
+	return uf
+}
+
+func (uf *concurrentUnionFind) find(x int64) int64 {
+	for {
+		p := uf.parent[x].Load()
+		if p == x {
+			return x
+		}
+		gp := uf.parent[p].Load()
+		// Path halving; losing the race only skips the shortcut.
+		uf.parent[x].CompareAndSwap(p, gp)
+		x = gp
+	}
+}
+
+func (uf *concurrentUnionFind) union(a, b int64) {
+	for {
+		ra, rb := uf.find(a), uf.find(b)
+		if ra == rb {
+			return
+		}
+		if ra < rb {
+			ra, rb = rb, ra
+		}
+		if uf.parent[ra].CompareAndSwap(ra, rb) {
+			return
+		}
+	}
+}
+
+// ParallelColoring computes a vertex coloring with the Jones–Plassmann
+// algorithm: every vertex gets a fixed pseudo-random priority, and in each
+// round the uncolored vertices whose priority beats all uncolored
+// neighbors take the smallest color unused by their neighbors. Vertices
+// chosen in one round are never adjacent, so each round is processed by up
+// to workers goroutines without locks.
+//
+// The coloring is valid in the sense of [Hypergraph.GreedyColoring] but
+// generally differs from it. Returns a map from vertex to color
+// (0-indexed integers).
+func (h *Hypergraph[V]) ParallelColoring(workers int) map[V]int {
+	vertices := h.sorted(maps.Keys(h.vertices))
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	adj := make([][]int, len(vertices))
+	parallelChunks(len(vertices), workers, func(_, lo, hi int) {
+		mark := make([]int, len(vertices))
+		for i := lo; i < hi; i++ {
+			adj[i] = h.appendNeighbors(adj[i], vertices[i], i, index, mark)
+		}
+	})
+
+	priority := make([]uint64, len(vertices))
+	for i := range priority {
+		priority[i] = splitmix64(uint64(i))
+	}
+	beats := func(i, j int) bool {
+		return priority[i] > priority[j] || (priority[i] == priority[j] && i < j)
+	}
+
+	color := make([]int, len(vertices))
+	for i := range color {
+		color[i] = -1
+	}
+	remaining := make([]int, len(vertices))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	selected := make([]bool, len(vertices))
+	for len(remaining) > 0 {
+		parallelChunks(len(remaining), workers, func(_, lo, hi int) {
+			for _, i := range remaining[lo:hi] {
+				selected[i] = !slices.ContainsFunc(adj[i], func(j int) bool {
+					return color[j] < 0 && beats(j, i)
+				})
+			}
+		})
+		parallelChunks(len(remaining), workers, func(_, lo, hi int) {
+			for _, i := range remaining[lo:hi] {
+				if !selected[i] {
+					continue
+				}
+				used := make(map[int]bool, len(adj[i]))
+				for _, j := range adj[i] {
+					if color[j] >= 0 {
+						used[color[j]] = true
+					}
+				}
+				c := 0
+				for used[c] {
+					c++
+				}
+				color[i] = c
+			}
+		})
+		remaining = slices.DeleteFunc(remaining, func(i int) bool { return color[i] >= 0 })
+	}
+
+	coloring := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		coloring[v] = color[i]
+	}
+	return coloring
+}
+
+// splitmix64 scrambles x into a well-mixed 64-bit value.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// ParallelLineGraph returns the same graph as [Hypergraph.LineGraph],
+// building it from the vertex-to-edge index with the edges split among up
+// to workers goroutines.
+func (h *Hypergraph[V]) ParallelLineGraph(workers int) *Graph[string] {
+	edges := slices.Sorted(maps.Keys(h.edges))
+	pairs := make([][][2]string, workerCount(workers))
+	parallelChunks(len(edges), workers, func(c, lo, hi int) {
+		pairs[c] = h.lineGraphPairs(edges[lo:hi])
+	})
+	g := NewGraph[string]()
+	for _, e := range edges {
+		g.AddVertex(e)
+	}
+	for _, chunk := range pairs {
+		for _, p := range chunk {
+			_ = g.AddEdge(p[0], p[1])
+		}
+	}
+	return g
+}
+
+// lineGraphPairs returns the pairs {e, f} with e in edges, e < f, and e and
+// f sharing a vertex, found through the incident edges of e's members.
+func (h *Hypergraph[V]) lineGraphPairs(edges []string) [][2]string {
+	var pairs [][2]string
+	seen := make(map[string]struct{})
+	for _, e := range edges {
+		clear(seen)
+		for v := range h.Members(e, Unordered) {
+			for f := range h.IncidentEdges(v, Unordered) {
+				if f <= e {
+					continue
+				}
+				if _, dup := seen[f]; dup {
+					continue
+				}
+				seen[f] = struct{}{}
+				pairs = append(pairs, [2]string{e, f})
+			}
+		}
+	}
+	return pairs
+}
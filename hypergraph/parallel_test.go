@@ -0,0 +1,133 @@
+package hypergraph
+
+import (
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// sparseHypergraph builds a random hypergraph with several components.
+func sparseHypergraph(seed uint64, n, m int) *Hypergraph[int] {
+	rng := rand.New(rand.NewPCG(seed, 39))
+	h := NewHypergraph[int]()
+	for v := range n {
+		h.AddVertex(v)
+	}
+	for e := range m {
+		size := 1 + rng.IntN(3)
+		members := make([]int, size)
+		for i := range members {
+			members[i] = rng.IntN(n)
+		}
+		_ = h.AddEdge("e"+fmtInt(e), members)
+	}
+	return h
+}
+
+var workerCounts = []int{0, 1, 2, 3, 8}
+
+// ============================================================================
+// Parallel BFS Tests
+// ============================================================================
+
+func TestParallelBFS_LevelOrder(t *testing.T) {
+	t.Parallel()
+	h := sparseHypergraph(1, 200, 150)
+	for _, start := range []int{0, 17, 199} {
+		dist := h.Distances(start)
+		want := slices.Collect(maps.Keys(dist))
+		slices.SortFunc(want, func(a, b int) int {
+			if dist[a] != dist[b] {
+				return dist[a] - dist[b]
+			}
+			return a - b
+		})
+		for _, w := range workerCounts {
+			if got := h.ParallelBFS(start, w); !slices.Equal(got, want) {
+				t.Fatalf("ParallelBFS(%d, %d) = %v, want %v", start, w, got, want)
+			}
+		}
+	}
+	if h.ParallelBFS(-1, 4) != nil {
+		t.Error("ParallelBFS of missing vertex should be nil")
+	}
+}
+
+// ============================================================================
+// Parallel Components Tests
+// ============================================================================
+
+func TestParallelConnectedComponents(t *testing.T) {
+	t.Parallel()
+	h := sparseHypergraph(2, 300, 180)
+	var want [][]int
+	for _, c := range h.ConnectedComponents() {
+		want = append(want, slices.Sorted(slices.Values(c)))
+	}
+	slices.SortFunc(want, func(a, b []int) int { return a[0] - b[0] })
+	for _, w := range workerCounts {
+		got := h.ParallelConnectedComponents(w)
+		if !slices.EqualFunc(got, want, slices.Equal) {
+			t.Fatalf("workers=%d: components differ", w)
+		}
+	}
+	if got := NewHypergraph[int]().ParallelConnectedComponents(4); len(got) != 0 {
+		t.Errorf("empty hypergraph components = %v", got)
+	}
+}
+
+// ============================================================================
+// Parallel Coloring Tests
+// ============================================================================
+
+func TestParallelColoring(t *testing.T) {
+	t.Parallel()
+	h := sparseHypergraph(3, 250, 300)
+	first := h.ParallelColoring(1)
+	if len(first) != h.NumVertices() {
+		t.Fatalf("colored %d of %d vertices", len(first), h.NumVertices())
+	}
+	for _, id := range h.Edges() {
+		seen := make(map[int]int)
+		for v := range h.Members(id, Unordered) {
+			if u, dup := seen[first[v]]; dup {
+				t.Fatalf("edge %s: %d and %d share color %d", id, u, v, first[v])
+			}
+			seen[first[v]] = v
+		}
+	}
+	for _, w := range workerCounts {
+		if got := h.ParallelColoring(w); !maps.Equal(got, first) {
+			t.Fatalf("workers=%d: coloring differs from workers=1", w)
+		}
+	}
+}
+
+// ============================================================================
+// Parallel Line Graph Tests
+// ============================================================================
+
+func TestParallelLineGraph(t *testing.T) {
+	t.Parallel()
+	h := sparseHypergraph(4, 80, 120)
+	// Reference: test every pair of edges.
+	var want []struct{ From, To string }
+	ids := slices.Sorted(slices.Values(h.Edges()))
+	for i, e := range ids {
+		for _, f := range ids[i+1:] {
+			if h.edgesIntersect(e, f) {
+				want = append(want, struct{ From, To string }{e, f})
+			}
+		}
+	}
+	if got := h.LineGraph().Edges(); !slices.Equal(got, want) {
+		t.Fatalf("LineGraph has %d edges, want %d", len(got), len(want))
+	}
+	for _, w := range workerCounts {
+		g := h.ParallelLineGraph(w)
+		if !slices.Equal(g.Edges(), want) || g.NumVertices() != h.NumEdges() {
+			t.Fatalf("workers=%d: line graph differs", w)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package hypergraph
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// ============================================================================
+// VertexPercolation Tests
+// ============================================================================
+
+func TestVertexPercolation_Path(t *testing.T) {
+	t.Parallel()
+	// a-b-c-d-e as 2-edges; removing c by centrality splits it in two.
+	h := fromEdges(t, []string{"a", "b"}, []string{"b", "c"}, []string{"c", "d"}, []string{"d", "e"})
+	order, curve := h.VertexPercolation(PercolationOptions{Strategy: CentralityRemoval})
+	if want := []string{"c", "b", "d", "a", "e"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	want := []PercolationPoint{
+		{Removed: 0, Fraction: 0, LargestComponent: 5, Components: 1},
+		{Removed: 1, Fraction: 0.2, LargestComponent: 2, Components: 2},
+		{Removed: 2, Fraction: 0.4, LargestComponent: 2, Components: 2},
+		{Removed: 3, Fraction: 0.6, LargestComponent: 1, Components: 2},
+		{Removed: 4, Fraction: 0.8, LargestComponent: 1, Components: 1},
+		{Removed: 5, Fraction: 1, LargestComponent: 0, Components: 0},
+	}
+	if !slices.Equal(curve, want) {
+		t.Errorf("curve = %v\nwant %v", curve, want)
+	}
+}
+
+func TestVertexPercolation_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(4, 8))
+	h := randomWeightedHypergraph(rng, 25, 30)
+	for _, strategy := range []RemovalStrategy{RandomRemoval, DegreeRemoval, CorenessRemoval, CentralityRemoval} {
+		order, curve := h.VertexPercolation(PercolationOptions{Strategy: strategy, S: 2, Seed: 3})
+		if len(order) != 25 || len(curve) != 26 {
+			t.Fatalf("strategy %d: %d removals, %d points", strategy, len(order), len(curve))
+		}
+		rest := h.Copy()
+		for i, v := range order {
+			checkPoint(t, rest, 2, curve[i])
+			rest.RemoveVertex(v)
+		}
+		checkPoint(t, rest, 2, curve[25])
+	}
+}
+
+func TestVertexPercolation_Orders(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(6, 1))
+	h := randomWeightedHypergraph(rng, 20, 25)
+	order, _ := h.VertexPercolation(PercolationOptions{Strategy: DegreeRemoval})
+	for i := 1; i < len(order); i++ {
+		if h.VertexDegree(order[i]) > h.VertexDegree(order[i-1]) {
+			t.Fatalf("degree order not descending at %d: %v", i, order)
+		}
+	}
+	core := h.Coreness()
+	order, _ = h.VertexPercolation(PercolationOptions{Strategy: CorenessRemoval})
+	for i := 1; i < len(order); i++ {
+		if core[order[i]] > core[order[i-1]] {
+			t.Fatalf("coreness order not descending at %d", i)
+		}
+	}
+
+	a, _ := h.VertexPercolation(PercolationOptions{Seed: 5})
+	b, _ := h.VertexPercolation(PercolationOptions{Seed: 5})
+	c, _ := h.VertexPercolation(PercolationOptions{Seed: 6})
+	if !slices.Equal(a, b) || slices.Equal(a, c) {
+		t.Error("random order should depend only on the seed")
+	}
+}
+
+// ============================================================================
+// EdgePercolation Tests
+// ============================================================================
+
+func TestEdgePercolation_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(2, 9))
+	h := randomWeightedHypergraph(rng, 20, 30)
+	h.AddVertex(99)
+	for _, strategy := range []RemovalStrategy{RandomRemoval, DegreeRemoval, CorenessRemoval, CentralityRemoval} {
+		order, curve := h.EdgePercolation(PercolationOptions{Strategy: strategy, S: 2, Seed: 1})
+		if len(order) != 30 || len(curve) != 31 {
+			t.Fatalf("strategy %d: %d removals, %d points", strategy, len(order), len(curve))
+		}
+		rest := h.Copy()
+		for i, id := range order {
+			checkPoint(t, rest, 2, curve[i])
+			rest.RemoveEdge(id)
+		}
+		checkPoint(t, rest, 2, curve[30])
+		if last := curve[30]; last.Components != 21 || last.LargestComponent != 1 {
+			t.Errorf("without edges: %+v", last)
+		}
+	}
+
+	order, _ := h.EdgePercolation(PercolationOptions{Strategy: DegreeRemoval})
+	for i := 1; i < len(order); i++ {
+		if len(h.EdgeMembers(order[i])) > len(h.EdgeMembers(order[i-1])) {
+			t.Fatalf("size order not descending at %d", i)
+		}
+	}
+}
+
+// checkPoint compares a curve point with connectivity recomputed from
+// scratch.
+func checkPoint(t *testing.T, h *Hypergraph[int], s int, got PercolationPoint) {
+	t.Helper()
+	components := h.ConnectedComponents()
+	largest := 0
+	for _, c := range components {
+		largest = max(largest, len(c))
+	}
+	// s-components by search over edges with at least s members.
+	var edges []string
+	for _, id := range h.Edges() {
+		if len(h.EdgeMembers(id)) >= s {
+			edges = append(edges, id)
+		}
+	}
+	seen := map[string]bool{}
+	sComponents, sLargest := 0, 0
+	for _, start := range edges {
+		if seen[start] {
+			continue
+		}
+		sComponents++
+		size := 0
+		queue := []string{start}
+		seen[start] = true
+		for len(queue) > 0 {
+			e := queue[0]
+			queue = queue[1:]
+			size++
+			for _, f := range edges {
+				if !seen[f] && len(slices.Collect(h.EdgeIntersection(e, f, Unordered))) >= s {
+					seen[f] = true
+					queue = append(queue, f)
+				}
+			}
+		}
+		sLargest = max(sLargest, size)
+	}
+	if got.LargestComponent != largest || got.Components != len(components) ||
+		got.LargestSComponent != sLargest || got.SComponents != sComponents {
+		t.Errorf("after %d removals: got %+v, want largest %d, components %d, s-largest %d, s-components %d",
+			got.Removed, got, largest, len(components), sLargest, sComponents)
+	}
+}
@@ -0,0 +1,311 @@
+package hypergraph
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// RenderStyle selects how [Hypergraph.RenderSVG] draws edges.
+type RenderStyle int
+
+const (
+	// StarStyle draws each edge as a small hub joined to its members by
+	// spokes, as in the star expansion.
+	StarStyle RenderStyle = iota
+	// HullStyle draws each edge as a rounded, translucent region around its
+	// members.
+	HullStyle
+)
+
+// Default limits applied by [Hypergraph.RenderSVG] when the corresponding
+// option is zero.
+const (
+	DefaultRenderMaxVertices = 500
+	DefaultRenderMaxEdges    = 500
+)
+
+// RenderOptions configures [Hypergraph.RenderSVG].
+type RenderOptions[V comparable] struct {
+	// Width and Height are the image size in pixels. Zero means 800×600;
+	// negative sizes are an error.
+	Width, Height int
+	// Seed fixes the initial layout; the same seed gives the same image.
+	Seed uint64
+	// Iterations is the number of force-directed layout steps. Zero means
+	// 200; negative means none, keeping the seeded initial positions.
+	Iterations int
+	// Style selects how edges are drawn.
+	Style RenderStyle
+	// Labels draws vertex names, and edge IDs at the hubs in StarStyle.
+	Labels bool
+	// VertexClasses colors vertices by class, such as the communities from
+	// [Hypergraph.Louvain] or the colors from [Hypergraph.GreedyColoring].
+	// Vertices without a class are gray. Classes may be any int; they
+	// cycle through the palette.
+	VertexClasses map[V]int
+	// EdgeClasses colors edges by class. Edges without one take the most
+	// common class of their members if VertexClasses is set, and otherwise
+	// a color of their own.
+	EdgeClasses map[string]int
+	// MaxVertices and MaxEdges make RenderSVG fail with [ErrTooLarge] on
+	// bigger inputs. Zero means the defaults above; negative means no limit.
+	MaxVertices, MaxEdges int
+}
+
+// renderPalette is a categorical palette for classes.
+var renderPalette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+// paletteColor returns the palette color of class c, wrapping negative
+// classes as well as large ones.
+func paletteColor(c int) string {
+	n := len(renderPalette)
+	return renderPalette[(c%n+n)%n]
+}
+
+type point struct{ x, y float64 }
+
+// CheckRenderSize returns the error [Hypergraph.RenderSVG] would return
+// for opts before drawing anything: an error wrapping [ErrTooLarge] if h
+// exceeds the size limits, or one for a negative image size. Callers that
+// compute vertex classes can check first, so that they do not spend that
+// work on a hypergraph too large to draw.
+func (h *Hypergraph[V]) CheckRenderSize(opts RenderOptions[V]) error {
+	if opts.Width < 0 || opts.Height < 0 {
+		return fmt.Errorf("render: negative image size %d×%d", opts.Width, opts.Height)
+	}
+	maxV := cmp.Or(opts.MaxVertices, DefaultRenderMaxVertices)
+	maxE := cmp.Or(opts.MaxEdges, DefaultRenderMaxEdges)
+	if maxV >= 0 && len(h.vertices) > maxV {
+		return fmt.Errorf("%d vertices exceeds limit %d: %w", len(h.vertices), maxV, ErrTooLarge)
+	}
+	if maxE >= 0 && len(h.edges) > maxE {
+		return fmt.Errorf("%d edges exceeds limit %d: %w", len(h.edges), maxE, ErrTooLarge)
+	}
+	return nil
+}
+
+// RenderSVG lays out h with a force-directed (Fruchterman–Reingold) layout
+// of its star expansion and writes the drawing to w as a standalone SVG
+// document. Rendering is deterministic for a given hypergraph and options.
+//
+// The layout costs O(Iterations · (|V| + |E|)²) time, which the size
+// limits keep bounded.
+func (h *Hypergraph[V]) RenderSVG(w io.Writer, opts RenderOptions[V]) error {
+	if err := h.CheckRenderSize(opts); err != nil {
+		return err
+	}
+	width := float64(cmp.Or(opts.Width, 800))
+	height := float64(cmp.Or(opts.Height, 600))
+
+	vertices := h.sorted(maps.Keys(h.vertices))
+	edges := slices.Sorted(maps.Keys(h.edges))
+	pos := h.forceLayout(vertices, edges, width, height, opts.Seed, max(cmp.Or(opts.Iterations, 200), 0))
+	vpos := pos[:len(vertices)]
+	epos := pos[len(vertices):]
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+
+	for i, id := range edges {
+		color := paletteColor(h.edgeClass(id, i, opts))
+		members := slices.Collect(h.Members(id, Sorted))
+		fmt.Fprintf(bw, `<g class="edge"><title>%s</title>`, html.EscapeString(id))
+		switch opts.Style {
+		case HullStyle:
+			pts := make([]point, len(members))
+			for j, v := range members {
+				pts[j] = vpos[index[v]]
+			}
+			fmt.Fprintf(bw, `<path d="%s" fill="%s" fill-opacity="0.15" stroke="%s" stroke-opacity="0.35"`+
+				` stroke-width="24" stroke-linejoin="round" stroke-linecap="round"/>`,
+				hullPath(pts), color, color)
+		default:
+			hub := epos[i]
+			for _, v := range members {
+				p := vpos[index[v]]
+				fmt.Fprintf(bw, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="1.5"/>`,
+					hub.x, hub.y, p.x, p.y, color)
+			}
+			fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="8" height="8" fill="%s"/>`, hub.x-4, hub.y-4, color)
+			if opts.Labels {
+				fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="10" font-family="sans-serif" fill="%s">%s</text>`,
+					hub.x+6, hub.y-6, color, html.EscapeString(id))
+			}
+		}
+		fmt.Fprintln(bw, `</g>`)
+	}
+
+	for i, v := range vertices {
+		p := vpos[i]
+		fill := "#888888"
+		if c, ok := opts.VertexClasses[v]; ok {
+			fill = paletteColor(c)
+		}
+		label := html.EscapeString(fmt.Sprint(v))
+		fmt.Fprintf(bw, `<g class="vertex"><title>%s</title><circle cx="%.1f" cy="%.1f" r="6" fill="%s" stroke="black" stroke-width="1"/>`,
+			label, p.x, p.y, fill)
+		if opts.Labels {
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-size="12" font-family="sans-serif">%s</text>`,
+				p.x+8, p.y+4, label)
+		}
+		fmt.Fprintln(bw, `</g>`)
+	}
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
+
+// edgeClass returns the color class of the i-th edge in ID order.
+func (h *Hypergraph[V]) edgeClass(id string, i int, opts RenderOptions[V]) int {
+	if c, ok := opts.EdgeClasses[id]; ok {
+		return c
+	}
+	if opts.VertexClasses == nil {
+		return i
+	}
+	count := make(map[int]int)
+	for v := range h.Members(id, Unordered) {
+		if c, ok := opts.VertexClasses[v]; ok {
+			count[c]++
+		}
+	}
+	best, bestCount := 0, 0
+	for _, c := range slices.Sorted(maps.Keys(count)) {
+		if count[c] > bestCount {
+			best, bestCount = c, count[c]
+		}
+	}
+	return best
+}
+
+// forceLayout places the vertices, then the edge hubs, of the star
+// expansion inside a width×height frame.
+func (h *Hypergraph[V]) forceLayout(vertices []V, edges []string, width, height float64, seed uint64, iterations int) []point {
+	n := len(vertices) + len(edges)
+	index := make(map[V]int, len(vertices))
+	for i, v := range vertices {
+		index[v] = i
+	}
+	var links [][2]int
+	for j, id := range edges {
+		for _, v := range slices.Collect(h.Members(id, Sorted)) {
+			links = append(links, [2]int{index[v], len(vertices) + j})
+		}
+	}
+
+	const margin = 30.0
+	rng := rand.New(rand.NewPCG(seed, seed^0x5eed))
+	pos := make([]point, n)
+	for i := range pos {
+		pos[i] = point{margin + rng.Float64()*(width-2*margin), margin + rng.Float64()*(height-2*margin)}
+	}
+	if n == 0 {
+		return pos
+	}
+
+	k := math.Sqrt((width - 2*margin) * (height - 2*margin) / float64(n))
+	disp := make([]point, n)
+	for it := range iterations {
+		clear(disp)
+		for i := range n {
+			for j := i + 1; j < n; j++ {
+				dx, dy := pos[i].x-pos[j].x, pos[i].y-pos[j].y
+				d := math.Max(math.Hypot(dx, dy), 0.01)
+				f := k * k / d
+				disp[i].x += dx / d * f
+				disp[i].y += dy / d * f
+				disp[j].x -= dx / d * f
+				disp[j].y -= dy / d * f
+			}
+		}
+		for _, l := range links {
+			a, b := l[0], l[1]
+			dx, dy := pos[a].x-pos[b].x, pos[a].y-pos[b].y
+			d := math.Max(math.Hypot(dx, dy), 0.01)
+			f := d * d / k
+			disp[a].x -= dx / d * f
+			disp[a].y -= dy / d * f
+			disp[b].x += dx / d * f
+			disp[b].y += dy / d * f
+		}
+		// Cool linearly from a tenth of the frame to nothing.
+		temp := width / 10 * (1 - float64(it)/float64(iterations))
+		for i := range pos {
+			d := math.Hypot(disp[i].x, disp[i].y)
+			if d > 0 {
+				step := math.Min(d, temp)
+				pos[i].x += disp[i].x / d * step
+				pos[i].y += disp[i].y / d * step
+			}
+			pos[i].x = math.Min(width-margin, math.Max(margin, pos[i].x))
+			pos[i].y = math.Min(height-margin, math.Max(margin, pos[i].y))
+		}
+	}
+	return pos
+}
+
+// hullPath returns an SVG path tracing the convex hull of pts. Drawn with
+// a wide round-joined stroke, the path becomes a rounded region; one or two
+// points give a dot or a capsule.
+func hullPath(pts []point) string {
+	hull := convexHull(pts)
+	var b []byte
+	for i, p := range hull {
+		cmd := "L"
+		if i == 0 {
+			cmd = "M"
+		}
+		b = fmt.Appendf(b, "%s%.1f %.1f ", cmd, p.x, p.y)
+	}
+	if len(hull) == 1 {
+		b = fmt.Appendf(b, "L%.1f %.1f ", hull[0].x, hull[0].y)
+	}
+	return string(append(b, 'Z'))
+}
+
+// convexHull returns the convex hull of pts in counter-clockwise order,
+// using Andrew's monotone chain.
+func convexHull(pts []point) []point {
+	pts = slices.Clone(pts)
+	slices.SortFunc(pts, func(a, b point) int {
+		return cmp.Or(cmp.Compare(a.x, b.x), cmp.Compare(a.y, b.y))
+	})
+	pts = slices.Compact(pts)
+	if len(pts) < 3 {
+		return pts
+	}
+	cross := func(o, a, b point) float64 {
+		return (a.x-o.x)*(b.y-o.y) - (a.y-o.y)*(b.x-o.x)
+	}
+	hull := make([]point, 0, 2*len(pts))
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
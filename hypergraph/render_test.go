@@ -0,0 +1,163 @@
+package hypergraph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func renderString(t *testing.T, h *Hypergraph[string], opts RenderOptions[string]) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := h.RenderSVG(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	// The output must be well-formed XML.
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		if _, err := dec.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v\n%s", err, buf.String())
+		}
+	}
+	return buf.String()
+}
+
+// ============================================================================
+// SVG Rendering Tests
+// ============================================================================
+
+func TestRenderSVG_Deterministic(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	a := renderString(t, h, RenderOptions[string]{Seed: 1})
+	b := renderString(t, h, RenderOptions[string]{Seed: 1})
+	if a != b {
+		t.Error("same seed gave different SVG")
+	}
+	if c := renderString(t, h, RenderOptions[string]{Seed: 2}); c == a {
+		t.Error("different seeds gave identical SVG")
+	}
+	if n := strings.Count(a, `class="edge"`); n != h.NumEdges() {
+		t.Errorf("drew %d edges, want %d", n, h.NumEdges())
+	}
+	if n := strings.Count(a, `<circle`); n != h.NumVertices() {
+		t.Errorf("drew %d vertices, want %d", n, h.NumVertices())
+	}
+}
+
+func TestRenderSVG_LabelsAndColors(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("<e&1>", []string{"a<b", "c"})
+	_ = h.AddEdge("e2", []string{"c", "d"})
+
+	plain := renderString(t, h, RenderOptions[string]{})
+	if strings.Contains(plain, "<text") {
+		t.Error("labels drawn without Labels")
+	}
+
+	svg := renderString(t, h, RenderOptions[string]{
+		Labels:        true,
+		VertexClasses: map[string]int{"a<b": 2, "c": 2, "d": 4},
+		EdgeClasses:   map[string]int{"e2": 1},
+	})
+	if !strings.Contains(svg, "a&lt;b</text>") || !strings.Contains(svg, "&lt;e&amp;1&gt;</text>") {
+		t.Error("labels missing or unescaped")
+	}
+	// "<e&1>" takes its members' majority class 2; "e2" has class 1.
+	if !strings.Contains(svg, `stroke="`+renderPalette[2]+`"`) || !strings.Contains(svg, `fill="`+renderPalette[1]+`"`) {
+		t.Error("edge class colors missing")
+	}
+	if !strings.Contains(svg, `<circle cx`) || !strings.Contains(svg, `fill="`+renderPalette[4]+`"`) {
+		t.Error("vertex class color missing")
+	}
+
+	// Negative classes wrap around the palette like large ones.
+	n := len(renderPalette)
+	svg = renderString(t, h, RenderOptions[string]{
+		VertexClasses: map[string]int{"d": -1},
+		EdgeClasses:   map[string]int{"e2": -n - 2},
+	})
+	if !strings.Contains(svg, `fill="`+renderPalette[n-1]+`"`) || !strings.Contains(svg, `stroke="`+renderPalette[n-2]+`"`) {
+		t.Error("negative class colors missing")
+	}
+}
+
+func TestRenderSVG_Options(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	var buf bytes.Buffer
+	if err := h.RenderSVG(&buf, RenderOptions[string]{Width: -1}); err == nil {
+		t.Error("expected error for negative width")
+	}
+	// Without iterations the layout is the seeded initial placement, which
+	// differs from the default layout.
+	still := renderString(t, h, RenderOptions[string]{Iterations: -1, Seed: 3})
+	moved := renderString(t, h, RenderOptions[string]{Seed: 3})
+	if still == moved {
+		t.Error("negative Iterations should skip the layout steps")
+	}
+}
+
+func TestRenderSVG_HullStyle(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	h.AddVertex("loner")
+	svg := renderString(t, h, RenderOptions[string]{Style: HullStyle, Width: 400, Height: 300})
+	if n := strings.Count(svg, `<path d="M`); n != h.NumEdges() {
+		t.Errorf("drew %d hulls, want %d", n, h.NumEdges())
+	}
+	if strings.Contains(svg, "<line") {
+		t.Error("hull style drew spokes")
+	}
+	if !strings.Contains(svg, `width="400" height="300"`) {
+		t.Error("size options ignored")
+	}
+}
+
+func TestRenderSVG_Limits(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	var buf bytes.Buffer
+	err := h.RenderSVG(&buf, RenderOptions[string]{MaxVertices: 2})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("vertex limit: err = %v, want ErrTooLarge", err)
+	}
+	err = h.RenderSVG(&buf, RenderOptions[string]{MaxEdges: 1})
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("edge limit: err = %v, want ErrTooLarge", err)
+	}
+	if buf.Len() != 0 {
+		t.Error("output written despite limit")
+	}
+	if err := h.CheckRenderSize(RenderOptions[string]{MaxVertices: 2}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("CheckRenderSize: err = %v, want ErrTooLarge", err)
+	}
+	if err := h.RenderSVG(&buf, RenderOptions[string]{MaxVertices: -1, MaxEdges: -1}); err != nil {
+		t.Errorf("unlimited: %v", err)
+	}
+	renderString(t, NewHypergraph[string](), RenderOptions[string]{})
+}
+
+func TestConvexHull(t *testing.T) {
+	t.Parallel()
+	pts := []point{{0, 0}, {2, 0}, {1, 1}, {2, 2}, {0, 2}, {1, 0}, {0, 0}}
+	hull := convexHull(pts)
+	want := []point{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	if len(hull) != len(want) {
+		t.Fatalf("hull = %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Fatalf("hull = %v, want %v", hull, want)
+		}
+	}
+	if got := convexHull([]point{{1, 1}, {1, 1}}); len(got) != 1 {
+		t.Errorf("duplicate points hull = %v", got)
+	}
+}
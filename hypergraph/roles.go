@@ -0,0 +1,152 @@
+package hypergraph
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+)
+
+// RoleBinding says that Vertex plays Role in an edge, as in the n-ary fact
+// "X is the subject of edge e". A role may be bound to several vertices
+// and a vertex may play several roles in the same edge.
+type RoleBinding[V comparable] struct {
+	Role   string `json:"role"`
+	Vertex V      `json:"vertex"`
+}
+
+// AddRoleEdge adds an edge whose members are the vertices of bindings, and
+// records the bindings in the given order. The same (role, vertex) pair
+// may not appear twice and roles must be non-empty.
+//
+// The edge behaves like any other edge in every other operation; the
+// bindings are extra information returned by [Hypergraph.EdgeRoles].
+// Copies, subhypergraphs and serialization keep them.
+func (h *Hypergraph[V]) AddRoleEdge(id string, bindings []RoleBinding[V]) error {
+	if err := validateBindings(bindings); err != nil {
+		return fmt.Errorf("edge %q: %w", id, err)
+	}
+	members := make([]V, len(bindings))
+	for i, b := range bindings {
+		members[i] = b.Vertex
+	}
+	if err := h.AddEdge(id, members); err != nil {
+		return err
+	}
+	h.roles[id] = slices.Clone(bindings)
+	return nil
+}
+
+// AddOrderedEdge adds an edge whose members form a tuple. It is
+// [Hypergraph.AddRoleEdge] with the positions "0", "1", ... as roles, so a
+// vertex may occur more than once. [Hypergraph.OrderedMembers] returns the
+// tuple.
+func (h *Hypergraph[V]) AddOrderedEdge(id string, members []V) error {
+	bindings := make([]RoleBinding[V], len(members))
+	for i, v := range members {
+		bindings[i] = RoleBinding[V]{Role: strconv.Itoa(i), Vertex: v}
+	}
+	return h.AddRoleEdge(id, bindings)
+}
+
+func validateBindings[V comparable](bindings []RoleBinding[V]) error {
+	seen := make(map[RoleBinding[V]]bool, len(bindings))
+	for _, b := range bindings {
+		if b.Role == "" {
+			return errors.New("empty role")
+		}
+		if seen[b] {
+			return fmt.Errorf("duplicate binding of role %q to %v", b.Role, b.Vertex)
+		}
+		seen[b] = true
+	}
+	return nil
+}
+
+// SetEdgeRoles replaces the role bindings of an existing edge. Every bound
+// vertex must be a member of the edge; members without a binding are
+// allowed. An empty list removes the edge's roles. Returns
+// [ErrEdgeNotFound] if the edge does not exist.
+func (h *Hypergraph[V]) SetEdgeRoles(id string, bindings []RoleBinding[V]) error {
+	edge, ok := h.edges[id]
+	if !ok {
+		return ErrEdgeNotFound
+	}
+	if err := validateBindings(bindings); err != nil {
+		return err
+	}
+	for _, b := range bindings {
+		if _, ok := edge.Set[b.Vertex]; !ok {
+			return fmt.Errorf("role %q bound to non-member %v", b.Role, b.Vertex)
+		}
+	}
+	h.setRolesOrDelete(id, slices.Clone(bindings))
+	return nil
+}
+
+// setRolesOrDelete stores bindings for an edge, or forgets the edge's
+// roles if there are none left.
+func (h *Hypergraph[V]) setRolesOrDelete(id string, bindings []RoleBinding[V]) {
+	if len(bindings) == 0 {
+		delete(h.roles, id)
+	} else {
+		h.roles[id] = bindings
+	}
+}
+
+// EdgeRoles returns the role bindings of an edge in the order they were
+// given, or nil if the edge has none.
+func (h *Hypergraph[V]) EdgeRoles(id string) []RoleBinding[V] {
+	return slices.Clone(h.roles[id])
+}
+
+// OrderedMembers returns the vertices of an edge's role bindings in order,
+// which for an edge added with [Hypergraph.AddOrderedEdge] is its tuple.
+// It returns nil if the edge has no roles.
+func (h *Hypergraph[V]) OrderedMembers(id string) []V {
+	bindings := h.roles[id]
+	if bindings == nil {
+		return nil
+	}
+	members := make([]V, len(bindings))
+	for i, b := range bindings {
+		members[i] = b.Vertex
+	}
+	return members
+}
+
+// RoleMembers returns the vertices bound to role in an edge, in binding
+// order.
+func (h *Hypergraph[V]) RoleMembers(id, role string) []V {
+	var members []V
+	for _, b := range h.roles[id] {
+		if b.Role == role {
+			members = append(members, b.Vertex)
+		}
+	}
+	return members
+}
+
+// VertexRoles returns the roles v plays in an edge, in binding order.
+func (h *Hypergraph[V]) VertexRoles(id string, v V) []string {
+	var roles []string
+	for _, b := range h.roles[id] {
+		if b.Vertex == v {
+			roles = append(roles, b.Role)
+		}
+	}
+	return roles
+}
+
+// EdgesWithRole yields the IDs of the edges in which v plays role, such as
+// every fact whose subject is v.
+func (h *Hypergraph[V]) EdgesWithRole(v V, role string, order Order) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for id := range h.IncidentEdges(v, order) {
+			if slices.Contains(h.roles[id], RoleBinding[V]{Role: role, Vertex: v}) && !yield(id) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package hypergraph
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// facts builds a small knowledge graph of n-ary facts.
+func facts(t *testing.T) *Hypergraph[string] {
+	t.Helper()
+	h := NewHypergraph[string]()
+	add := func(id string, bindings ...RoleBinding[string]) {
+		t.Helper()
+		if err := h.AddRoleEdge(id, bindings); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("f1",
+		RoleBinding[string]{"subject", "alice"},
+		RoleBinding[string]{"object", "bob"},
+		RoleBinding[string]{"witness", "carol"})
+	add("f2",
+		RoleBinding[string]{"subject", "bob"},
+		RoleBinding[string]{"object", "alice"})
+	add("f3",
+		RoleBinding[string]{"author", "alice"},
+		RoleBinding[string]{"author", "carol"})
+	_ = h.AddEdge("plain", []string{"alice", "dave"})
+	return h
+}
+
+// ============================================================================
+// Role Edge Tests
+// ============================================================================
+
+func TestRoleEdges_Queries(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+
+	if size, _ := h.EdgeSize("f1"); size != 3 {
+		t.Errorf("f1 size = %d, want 3", size)
+	}
+	if got := h.RoleMembers("f1", "subject"); !slices.Equal(got, []string{"alice"}) {
+		t.Errorf("RoleMembers(f1, subject) = %v", got)
+	}
+	if got := h.RoleMembers("f3", "author"); !slices.Equal(got, []string{"alice", "carol"}) {
+		t.Errorf("RoleMembers(f3, author) = %v", got)
+	}
+	if got := h.VertexRoles("f1", "carol"); !slices.Equal(got, []string{"witness"}) {
+		t.Errorf("VertexRoles(f1, carol) = %v", got)
+	}
+	if got := slices.Collect(h.EdgesWithRole("alice", "subject", Sorted)); !slices.Equal(got, []string{"f1"}) {
+		t.Errorf("EdgesWithRole(alice, subject) = %v, want [f1]", got)
+	}
+	if got := slices.Collect(h.EdgesWithRole("alice", "object", Sorted)); !slices.Equal(got, []string{"f2"}) {
+		t.Errorf("EdgesWithRole(alice, object) = %v, want [f2]", got)
+	}
+	if h.EdgeRoles("plain") != nil || h.OrderedMembers("plain") != nil {
+		t.Error("plain edges should have no roles")
+	}
+}
+
+func TestRoleEdges_Validation(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	if err := h.AddRoleEdge("e", []RoleBinding[string]{{"", "A"}}); err == nil {
+		t.Error("empty role should fail")
+	}
+	if err := h.AddRoleEdge("e", []RoleBinding[string]{{"r", "A"}, {"r", "A"}}); err == nil {
+		t.Error("duplicate binding should fail")
+	}
+	if err := h.AddRoleEdge("e", nil); err == nil {
+		t.Error("no bindings should fail")
+	}
+	if h.NumEdges() != 0 || h.NumVertices() != 0 {
+		t.Error("failed AddRoleEdge should not modify the hypergraph")
+	}
+}
+
+func TestOrderedEdges(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	if err := h.AddOrderedEdge("t", []string{"C", "A", "C", "B"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.OrderedMembers("t"); !slices.Equal(got, []string{"C", "A", "C", "B"}) {
+		t.Errorf("OrderedMembers = %v", got)
+	}
+	if size, _ := h.EdgeSize("t"); size != 3 {
+		t.Errorf("EdgeSize = %d, want 3 distinct members", size)
+	}
+	if got := h.VertexRoles("t", "C"); !slices.Equal(got, []string{"0", "2"}) {
+		t.Errorf("VertexRoles(t, C) = %v, want [0 2]", got)
+	}
+}
+
+func TestRoleEdges_Maintenance(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+
+	c := h.Copy()
+	if !slices.Equal(c.EdgeRoles("f1"), h.EdgeRoles("f1")) {
+		t.Error("Copy should keep roles")
+	}
+
+	h.RemoveVertex("carol")
+	if got := h.EdgeRoles("f1"); len(got) != 2 {
+		t.Errorf("roles after removing carol = %v", got)
+	}
+	if got := h.RoleMembers("f3", "author"); !slices.Equal(got, []string{"alice"}) {
+		t.Errorf("f3 authors = %v, want [alice]", got)
+	}
+	h.RemoveEdge("f2")
+	if h.EdgeRoles("f2") != nil {
+		t.Error("RemoveEdge should drop roles")
+	}
+
+	sub := c.InducedSubhypergraph([]string{"alice", "bob"})
+	if got := sub.EdgeRoles("f2"); len(got) != 2 {
+		t.Errorf("induced f2 roles = %v", got)
+	}
+	if sub.HasEdge("f1") {
+		t.Error("f1 is not inside {alice, bob}")
+	}
+}
+
+func TestRoleEdges_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	_ = h.AddOrderedEdge("tuple", []string{"bob", "bob", "dave"})
+
+	var buf bytes.Buffer
+	if err := h.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	back, err := LoadJSON[string](&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"f1", "f2", "f3", "tuple", "plain"} {
+		if !slices.Equal(back.EdgeRoles(id), h.EdgeRoles(id)) {
+			t.Errorf("roles of %s = %v, want %v", id, back.EdgeRoles(id), h.EdgeRoles(id))
+		}
+	}
+}
+
+func TestLoadJSON_InvalidRoles(t *testing.T) {
+	t.Parallel()
+	for _, data := range []string{
+		`{"vertices":[],"edges":{"e":["A"]},"roles":{"e":[{"role":"r","vertex":"B"}]}}`,
+		`{"vertices":[],"edges":{},"roles":{"ghost":[{"role":"r","vertex":"A"}]}}`,
+		`{"vertices":[],"edges":{"e":["A"]},"roles":{"e":[{"role":"","vertex":"A"}]}}`,
+	} {
+		if _, err := LoadJSON[string](strings.NewReader(data)); err == nil {
+			t.Errorf("LoadJSON(%s) should fail", data)
+		}
+	}
+}
+
+// ============================================================================
+// Role-Preserving Transform Tests
+// ============================================================================
+
+func TestDual_KeepsRoles(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	dual := h.Dual()
+
+	// alice is subject of f1, object of f2 and author of f3, and plays no
+	// role in the plain edge.
+	want := []RoleBinding[string]{{"subject", "f1"}, {"object", "f2"}, {"author", "f3"}}
+	if got := dual.EdgeRoles("alice"); !slices.Equal(got, want) {
+		t.Errorf("dual roles of alice = %v, want %v", got, want)
+	}
+	if got := slices.Collect(dual.EdgesWithRole("f1", "witness", Sorted)); !slices.Equal(got, []string{"carol"}) {
+		t.Errorf("dual edges where f1 is witness = %v, want [carol]", got)
+	}
+	if dual.EdgeRoles("dave") != nil {
+		t.Error("dave has no roles")
+	}
+}
+
+func TestTwoSection_Annotations(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	g := h.TwoSection()
+
+	want := []EdgeAnnotation{
+		{Edge: "f1", FromRole: "subject", ToRole: "object"},
+		{Edge: "f2", FromRole: "object", ToRole: "subject"},
+	}
+	if got := g.Annotations("bob", "alice"); !slices.Equal(got, want) {
+		t.Errorf("Annotations(alice, bob) = %v, want %v", got, want)
+	}
+	if got := g.Annotations("alice", "dave"); got != nil {
+		t.Errorf("plain edge should have no annotations, got %v", got)
+	}
+}
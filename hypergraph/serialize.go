@@ -1,50 +1,179 @@
 package hypergraph
 
 import (
-	"cmp"
 	"encoding/json"
+	"fmt"
 	"io"
 	"slices"
 )
 
 // SaveJSON saves the hypergraph to JSON.
 // Vertices and edge members are sorted for stable output; JSON map key order is not guaranteed.
+// Edge weights other than the default of 1 are written under "weights", and
+// the role bindings of role-labeled edges under "roles", in binding order.
+// Hypergraphs with integer vertices record "vertex_type": "int".
 func (h *Hypergraph[V]) SaveJSON(w io.Writer) error {
-	vertices := h.Vertices()
-	slices.Sort(vertices)
-	edges := make(map[string][]V)
-	for id, edge := range h.edges {
-		members := make([]V, 0)
-		for v := range edge.Set {
-			members = append(members, v)
+	return SaveJSONFunc(w, h, keepVertex[V])
+}
+
+// SaveJSONFunc saves h like [Hypergraph.SaveJSON] with every vertex
+// converted by f, which should be one-to-one, so that the file records
+// vertex type W without building a converted copy of h.
+func SaveJSONFunc[V, W comparable](w io.Writer, h *Hypergraph[V], f func(V) (W, error)) error {
+	vs := h.Vertices()
+	h.sortVertices(vs)
+	vertices, err := convertVertices(vs, f)
+	if err != nil {
+		return err
+	}
+	edges := make(map[string][]W, len(h.edges))
+	for id := range h.edges {
+		if edges[id], err = convertVertices(slices.Collect(h.Members(id, Sorted)), f); err != nil {
+			return err
 		}
-		slices.Sort(members)
-		edges[id] = members
 	}
 	data := map[string]interface{}{
 		"vertices": vertices,
 		"edges":    edges,
 	}
+	if len(h.weights) > 0 {
+		data["weights"] = h.weights
+	}
+	if len(h.roles) > 0 {
+		roles := make(map[string][]RoleBinding[W], len(h.roles))
+		for id, bindings := range h.roles {
+			if roles[id], err = convertBindings(bindings, f); err != nil {
+				return err
+			}
+		}
+		data["roles"] = roles
+	}
+	if t := vertexTypeOf[W](); t != VertexTypeString {
+		data["vertex_type"] = t
+	}
 	return json.NewEncoder(w).Encode(data)
 }
 
 // LoadJSON loads the hypergraph from JSON.
-func LoadJSON[V cmp.Ordered](r io.Reader) (*Hypergraph[V], error) {
+func LoadJSON[V comparable](r io.Reader) (*Hypergraph[V], error) {
+	return LoadJSONFunc(r, keepVertex[V])
+}
+
+// LoadJSONFunc loads a document of vertex type W like [LoadJSON] into a
+// hypergraph over V, converting every vertex with f, which should be
+// one-to-one.
+func LoadJSONFunc[V, W comparable](r io.Reader, f func(W) (V, error)) (*Hypergraph[V], error) {
 	var data struct {
-		Vertices []V            `json:"vertices"`
-		Edges    map[string][]V `json:"edges"`
+		VertexType string                      `json:"vertex_type"`
+		Vertices   []W                         `json:"vertices"`
+		Edges      map[string][]W              `json:"edges"`
+		Weights    map[string]float64          `json:"weights"`
+		Roles      map[string][]RoleBinding[W] `json:"roles"`
 	}
 	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, checkVertexType[W](data.VertexType, err)
+	}
+	if err := checkVertexType[W](data.VertexType, nil); err != nil {
 		return nil, err
 	}
 	h := NewHypergraph[V]()
-	for _, v := range data.Vertices {
+	for _, x := range data.Vertices {
+		v, err := f(x)
+		if err != nil {
+			return nil, err
+		}
 		h.AddVertex(v)
 	}
 	for id, members := range data.Edges {
-		if err := h.AddEdge(id, members); err != nil {
+		vs, err := convertVertices(members, f)
+		if err != nil {
+			return nil, fmt.Errorf("edge %q: %w", id, err)
+		}
+		if err := h.AddEdge(id, vs); err != nil {
 			return nil, err
 		}
 	}
+	for id, w := range data.Weights {
+		if err := h.SetEdgeWeight(id, w); err != nil {
+			return nil, fmt.Errorf("weight for edge %q: %w", id, err)
+		}
+	}
+	for id, bindings := range data.Roles {
+		bs, err := convertBindings(bindings, f)
+		if err == nil {
+			err = h.SetEdgeRoles(id, bs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("roles for edge %q: %w", id, err)
+		}
+	}
 	return h, nil
 }
+
+// keepVertex is the conversion of the functions that read and write
+// vertices as they are.
+func keepVertex[V any](v V) (V, error) { return v, nil }
+
+// convertVertices converts vs with f; nil stays nil.
+func convertVertices[V, W any](vs []V, f func(V) (W, error)) ([]W, error) {
+	if vs == nil {
+		return nil, nil
+	}
+	out := make([]W, len(vs))
+	for i, v := range vs {
+		w, err := f(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = w
+	}
+	return out, nil
+}
+
+// convertBindings converts the vertices of role bindings with f.
+func convertBindings[V, W comparable](bindings []RoleBinding[V], f func(V) (W, error)) ([]RoleBinding[W], error) {
+	out := make([]RoleBinding[W], len(bindings))
+	for i, b := range bindings {
+		w, err := f(b.Vertex)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = RoleBinding[W]{b.Role, w}
+	}
+	return out, nil
+}
+
+// Vertex types recorded under "vertex_type" in hypergraph files. Files
+// without one have string vertices, or vertices of some other type that
+// the reader must know.
+const (
+	VertexTypeString = "string"
+	VertexTypeInt    = "int"
+)
+
+// vertexTypeOf returns the vertex type recorded for hypergraphs over V:
+// [VertexTypeInt] for integer types and [VertexTypeString] otherwise, so
+// that only integer files carry the field.
+func vertexTypeOf[V comparable]() string {
+	switch any(*new(V)).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return VertexTypeInt
+	}
+	return VertexTypeString
+}
+
+// checkVertexType reports whether a file recording vertex type t can be
+// read as hypergraphs over V. It returns a descriptive error if not, and
+// otherwise decodeErr, the error from decoding the file, if any.
+func checkVertexType[V comparable](t string, decodeErr error) error {
+	switch t {
+	case "":
+		return decodeErr
+	case VertexTypeString, VertexTypeInt:
+		if want := vertexTypeOf[V](); t != want && (t == VertexTypeInt || want == VertexTypeInt) {
+			return fmt.Errorf("file has %s vertices, not %s", t, want)
+		}
+		return decodeErr
+	}
+	return fmt.Errorf("unknown vertex type %q", t)
+}
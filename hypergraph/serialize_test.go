@@ -2,6 +2,8 @@ package hypergraph
 
 import (
 	"bytes"
+	"errors"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -118,6 +120,76 @@ func TestSaveLoadJSON_IntVertices(t *testing.T) {
 	}
 }
 
+func TestSaveLoadJSON_VertexType(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	_ = h.AddEdge("E1", []int{10, 2})
+
+	var buf bytes.Buffer
+	if err := h.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"vertex_type":"int","vertices":[2,10]`) {
+		t.Errorf("SaveJSON = %s", buf.String())
+	}
+	if _, err := LoadJSON[string](bytes.NewReader(buf.Bytes())); err == nil || err.Error() != "file has int vertices, not string" {
+		t.Errorf("LoadJSON[string] error = %v", err)
+	}
+	if _, err := LoadJSON[int64](bytes.NewReader(buf.Bytes())); err != nil {
+		t.Errorf("LoadJSON[int64] error = %v", err)
+	}
+
+	buf.Reset()
+	_ = NewHypergraph[string]().SaveJSON(&buf)
+	if strings.Contains(buf.String(), "vertex_type") {
+		t.Errorf("string hypergraph recorded a vertex type: %s", buf.String())
+	}
+	for in, want := range map[string]string{
+		`{"vertex_type": "string", "vertices": ["a"]}`: "file has string vertices, not int",
+		`{"vertex_type": "uuid"}`:                      `unknown vertex type "uuid"`,
+	} {
+		if _, err := LoadJSON[int](strings.NewReader(in)); err == nil || err.Error() != want {
+			t.Errorf("LoadJSON(%s) error = %v, want %q", in, err, want)
+		}
+	}
+}
+
+func TestSaveLoadJSONFunc(t *testing.T) {
+	t.Parallel()
+	numeric := CompareBy(func(v string) int { n, _ := strconv.Atoi(v); return n })
+	h := NewHypergraphFunc(numeric)
+	_ = h.AddRoleEdge("E1", []RoleBinding[string]{{"from", "10"}, {"to", "2"}})
+
+	var buf bytes.Buffer
+	if err := SaveJSONFunc(&buf, h, strconv.Atoi); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"vertex_type":"int","vertices":[2,10]`) || !strings.Contains(buf.String(), `{"role":"from","vertex":10}`) {
+		t.Errorf("SaveJSONFunc = %s", buf.String())
+	}
+	got, err := LoadJSONFunc(bytes.NewReader(buf.Bytes()), func(v int) (string, error) { return strconv.Itoa(v), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Diff(h, got); !d.IsEmpty() {
+		t.Errorf("round trip differs: %+v", d)
+	}
+
+	h.AddVertex("x")
+	if err := SaveJSONFunc(&buf, h, strconv.Atoi); err == nil {
+		t.Error("SaveJSONFunc should return the conversion error")
+	}
+	_, err = LoadJSONFunc(strings.NewReader(`{"vertex_type": "int", "edges": {"E": [1, -1]}}`), func(v int) (uint, error) {
+		if v < 0 {
+			return 0, errors.New("negative")
+		}
+		return uint(v), nil
+	})
+	if err == nil || err.Error() != `edge "E": negative` {
+		t.Errorf("LoadJSONFunc error = %v", err)
+	}
+}
+
 func TestSaveJSON_DeterministicOutput(t *testing.T) {
 	t.Parallel()
 	h := NewHypergraph[string]()
@@ -198,6 +270,38 @@ func TestSaveLoadJSON_PreservesEdgeMembers(t *testing.T) {
 	}
 }
 
+func TestSaveLoadJSON_Weights(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B"})
+	_ = h.AddEdge("E2", []string{"B", "C"})
+	_ = h.SetEdgeWeight("E1", 2.5)
+
+	var buf bytes.Buffer
+	if err := h.SaveJSON(&buf); err != nil {
+		t.Fatalf("SaveJSON failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"weights":{"E1":2.5}`) {
+		t.Errorf("only non-default weights should be written, got %s", buf.String())
+	}
+
+	loaded, err := LoadJSON[string](&buf)
+	if err != nil {
+		t.Fatalf("LoadJSON failed: %v", err)
+	}
+	if loaded.EdgeWeight("E1") != 2.5 || loaded.EdgeWeight("E2") != 1 {
+		t.Errorf("weights = %v, %v; want 2.5, 1", loaded.EdgeWeight("E1"), loaded.EdgeWeight("E2"))
+	}
+
+	unweighted := NewHypergraph[string]()
+	_ = unweighted.AddEdge("E1", []string{"A"})
+	buf.Reset()
+	_ = unweighted.SaveJSON(&buf)
+	if strings.Contains(buf.String(), "weights") {
+		t.Errorf("unweighted graph should not write weights, got %s", buf.String())
+	}
+}
+
 // ============================================================================
 // LoadJSON Error Cases
 // ============================================================================
@@ -322,6 +426,19 @@ func TestLoadJSON_VertexCreatedByEdge(t *testing.T) {
 	}
 }
 
+func TestLoadJSON_InvalidWeights(t *testing.T) {
+	t.Parallel()
+	tests := []string{
+		`{"vertices":[],"edges":{"E1":["A"]},"weights":{"E2":2}}`,
+		`{"vertices":[],"edges":{"E1":["A"]},"weights":{"E1":-1}}`,
+	}
+	for _, input := range tests {
+		if _, err := LoadJSON[string](strings.NewReader(input)); err == nil {
+			t.Errorf("LoadJSON(%s) should fail", input)
+		}
+	}
+}
+
 // ============================================================================
 // Benchmarks
 // ============================================================================
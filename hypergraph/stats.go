@@ -0,0 +1,336 @@
+package hypergraph
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Stats summarizes the structure of a hypergraph. Distributions map a
+// value to the number of vertices, edges or components having it.
+type Stats struct {
+	Vertices             int               `json:"vertices"`
+	Edges                int               `json:"edges"`
+	Density              float64           `json:"density"`
+	MeanDegree           float64           `json:"mean_degree"`
+	MaxDegree            int               `json:"max_degree"`
+	MeanEdgeSize         float64           `json:"mean_edge_size"`
+	MaxEdgeSize          int               `json:"max_edge_size"`
+	DegreeDistribution   map[int]int       `json:"degree_distribution"`
+	EdgeSizeDistribution map[int]int       `json:"edge_size_distribution"`
+	JointDegreeSize      []DegreeSizeCount `json:"joint_degree_size"`
+	Clustering           ClusteringSummary `json:"clustering"`
+	Assortativity        float64           `json:"assortativity"`
+	Components           int               `json:"components"`
+	ComponentSizes       map[int]int       `json:"component_sizes"`
+}
+
+// DegreeSizeCount is one cell of the joint degree–size distribution: the
+// number of memberships in which a vertex of the given degree belongs to
+// an edge of the given size.
+type DegreeSizeCount struct {
+	Degree int `json:"degree"`
+	Size   int `json:"size"`
+	Count  int `json:"count"`
+}
+
+// ClusteringSummary holds the pairwise clustering coefficients of the
+// 2-section and their higher-order counterparts; see
+// [Hypergraph.HigherOrderClustering]. Averages are over the vertices whose
+// coefficient is defined; global values are ratios of totals.
+type ClusteringSummary struct {
+	PairwiseAverage    float64 `json:"pairwise_average"`
+	PairwiseGlobal     float64 `json:"pairwise_global"`
+	HigherOrderAverage float64 `json:"higher_order_average"`
+	HigherOrderGlobal  float64 `json:"higher_order_global"`
+}
+
+// Stats computes every statistic in [Stats].
+func (h *Hypergraph[V]) Stats() Stats {
+	s := Stats{
+		Vertices:             len(h.vertices),
+		Edges:                len(h.edges),
+		Density:              h.Density(),
+		DegreeDistribution:   h.DegreeDistribution(),
+		EdgeSizeDistribution: h.EdgeSizeDistribution(),
+		JointDegreeSize:      h.JointDegreeSizeDistribution(),
+		Clustering:           h.Clustering(),
+		Assortativity:        h.DegreeAssortativity(),
+		ComponentSizes:       h.ComponentSizeHistogram(),
+	}
+	s.MeanDegree, s.MaxDegree = meanMax(s.DegreeDistribution)
+	s.MeanEdgeSize, s.MaxEdgeSize = meanMax(s.EdgeSizeDistribution)
+	for _, n := range s.ComponentSizes {
+		s.Components += n
+	}
+	return s
+}
+
+// meanMax returns the mean and largest value of a distribution.
+func meanMax(dist map[int]int) (float64, int) {
+	total, count, largest := 0, 0, 0
+	for value, n := range dist {
+		total += value * n
+		count += n
+		largest = max(largest, value)
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return float64(total) / float64(count), largest
+}
+
+// DegreeDistribution maps each vertex degree to the number of vertices
+// having it.
+func (h *Hypergraph[V]) DegreeDistribution() map[int]int {
+	dist := make(map[int]int)
+	for v := range h.vertices {
+		dist[len(h.vertexToEdges[v])]++
+	}
+	return dist
+}
+
+// EdgeSizeDistribution maps each edge size to the number of edges having
+// it.
+func (h *Hypergraph[V]) EdgeSizeDistribution() map[int]int {
+	dist := make(map[int]int)
+	for _, e := range h.edges {
+		dist[len(e.Set)]++
+	}
+	return dist
+}
+
+// Density returns the fraction of the incidence matrix that is filled: the
+// number of memberships divided by |V|·|E|. It is 0 when there are no
+// vertices or no edges.
+func (h *Hypergraph[V]) Density() float64 {
+	if len(h.vertices) == 0 || len(h.edges) == 0 {
+		return 0
+	}
+	memberships := 0
+	for _, e := range h.edges {
+		memberships += len(e.Set)
+	}
+	return float64(memberships) / float64(len(h.vertices)*len(h.edges))
+}
+
+// JointDegreeSizeDistribution counts memberships by the degree of the
+// vertex and the size of the edge, sorted by degree and then size.
+func (h *Hypergraph[V]) JointDegreeSizeDistribution() []DegreeSizeCount {
+	counts := make(map[[2]int]int)
+	for _, e := range h.edges {
+		for v := range e.Set {
+			counts[[2]int{len(h.vertexToEdges[v]), len(e.Set)}]++
+		}
+	}
+	dist := make([]DegreeSizeCount, 0, len(counts))
+	for key, n := range counts {
+		dist = append(dist, DegreeSizeCount{Degree: key[0], Size: key[1], Count: n})
+	}
+	slices.SortFunc(dist, func(a, b DegreeSizeCount) int {
+		return cmp.Or(cmp.Compare(a.Degree, b.Degree), cmp.Compare(a.Size, b.Size))
+	})
+	return dist
+}
+
+// ComponentSizeHistogram maps each connected component size to the number
+// of components of that size. Isolated vertices are components of size 1.
+func (h *Hypergraph[V]) ComponentSizeHistogram() map[int]int {
+	hist := make(map[int]int)
+	for _, c := range h.ConnectedComponents() {
+		hist[len(c)]++
+	}
+	return hist
+}
+
+// DegreeAssortativity returns the Pearson correlation between the degrees
+// of two distinct vertices sharing an edge, taken over every edge and every
+// pair of its members in both orders. Positive values mean high-degree
+// vertices tend to share edges with each other. It returns 0 when the
+// correlation is undefined, such as when all such degrees are equal.
+func (h *Hypergraph[V]) DegreeAssortativity() float64 {
+	var n, sum, sumSq, sumProd float64
+	for id := range h.edges {
+		members := h.EdgeMembers(id)
+		for i, u := range members {
+			du := float64(len(h.vertexToEdges[u]))
+			for _, w := range members[i+1:] {
+				dw := float64(len(h.vertexToEdges[w]))
+				n += 2
+				sum += du + dw
+				sumSq += du*du + dw*dw
+				sumProd += 2 * du * dw
+			}
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+	if variance <= 1e-12 {
+		return 0
+	}
+	return (sumProd/n - mean*mean) / variance
+}
+
+// Clustering computes the pairwise and higher-order clustering
+// coefficients.
+func (h *Hypergraph[V]) Clustering() ClusteringSummary {
+	g := h.TwoSection()
+	var s ClusteringSummary
+	s.PairwiseAverage = g.AverageClustering()
+	s.PairwiseGlobal = g.Transitivity()
+
+	// Summing in vertex order keeps the float result reproducible.
+	var closedTotal, openTotal, sum float64
+	defined := 0
+	for _, v := range h.sorted(maps.Keys(h.vertices)) {
+		closed, wedges := h.higherOrderTriangles(v)
+		if wedges == 0 {
+			continue
+		}
+		closedTotal += float64(closed)
+		openTotal += float64(wedges)
+		sum += float64(closed) / float64(wedges)
+		defined++
+	}
+	if defined > 0 {
+		s.HigherOrderAverage = sum / float64(defined)
+		s.HigherOrderGlobal = closedTotal / openTotal
+	}
+	return s
+}
+
+// HigherOrderClustering returns the hypergraph clustering coefficient of v,
+// which, unlike the clustering of the 2-section, does not count triangles
+// that come from a single edge.
+//
+// A wedge at v is a pair of neighbors u, w reachable through two different
+// edges containing v. The wedge is closed if some third edge, different
+// from both, contains u and w. The coefficient is the fraction of wedges at
+// v that are closed, or NaN if v has no wedges.
+func (h *Hypergraph[V]) HigherOrderClustering(v V) float64 {
+	closed, wedges := h.higherOrderTriangles(v)
+	if wedges == 0 {
+		return math.NaN()
+	}
+	return float64(closed) / float64(wedges)
+}
+
+// higherOrderTriangles counts the wedges at v and how many are closed.
+func (h *Hypergraph[V]) higherOrderTriangles(v V) (closed, wedges int) {
+	nbrs := slices.Collect(h.Neighbors(v, Sorted))
+	shared := make(map[V][]string, len(nbrs))
+	for _, u := range nbrs {
+		shared[u] = h.commonEdges(v, u)
+	}
+	for i, u := range nbrs {
+		for _, w := range nbrs[i+1:] {
+			vu, vw := shared[u], shared[w]
+			if len(unionStrings(vu, vw)) < 2 {
+				continue
+			}
+			wedges++
+			uw := h.commonEdges(u, w)
+			// Three distinct edges, one per side, exist exactly when Hall's
+			// condition holds for the three sets.
+			if len(uw) > 0 && len(unionStrings(vu, uw)) >= 2 && len(unionStrings(vw, uw)) >= 2 &&
+				len(unionStrings(unionStrings(vu, vw), uw)) >= 3 {
+				closed++
+			}
+		}
+	}
+	return closed, wedges
+}
+
+// commonEdges returns the sorted IDs of the edges containing both u and w.
+func (h *Hypergraph[V]) commonEdges(u, w V) []string {
+	var ids []string
+	for e := range h.vertexToEdges[u] {
+		if _, ok := h.vertexToEdges[w][e]; ok {
+			ids = append(ids, e)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// unionStrings merges two sorted, duplicate-free slices.
+func unionStrings(a, b []string) []string {
+	out := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			out = append(out, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// LocalClustering returns the fraction of pairs of neighbors of v that are
+// adjacent, or NaN if v has fewer than two neighbors.
+func (g *Graph[V]) LocalClustering(v V) float64 {
+	links, pairs := g.triangles(v)
+	if pairs == 0 {
+		return math.NaN()
+	}
+	return float64(links) / float64(pairs)
+}
+
+// AverageClustering returns the mean [Graph.LocalClustering] over the
+// vertices with at least two neighbors, or 0 if there are none.
+func (g *Graph[V]) AverageClustering() float64 {
+	sum, n := 0.0, 0
+	for _, v := range g.sorted(maps.Keys(g.adj)) {
+		if links, pairs := g.triangles(v); pairs > 0 {
+			sum += float64(links) / float64(pairs)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// Transitivity returns the global clustering coefficient: three times the
+// number of triangles divided by the number of connected triples, or 0 if
+// there are no connected triples.
+func (g *Graph[V]) Transitivity() float64 {
+	links, pairs := 0, 0
+	for v := range g.adj {
+		l, p := g.triangles(v)
+		links += l
+		pairs += p
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return float64(links) / float64(pairs)
+}
+
+// triangles returns the number of adjacent pairs among the neighbors of v
+// and the number of pairs of neighbors.
+func (g *Graph[V]) triangles(v V) (links, pairs int) {
+	nbrs := slices.Collect(maps.Keys(g.adj[v]))
+	for i, u := range nbrs {
+		for _, w := range nbrs[i+1:] {
+			if g.HasEdge(u, w) {
+				links++
+			}
+		}
+	}
+	d := len(nbrs)
+	return links, d * (d - 1) / 2
+}
@@ -0,0 +1,193 @@
+package hypergraph
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// ============================================================================
+// Distribution Tests
+// ============================================================================
+
+func TestStats_Distributions(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"A", "B", "C"}, []string{"C", "D"}, []string{"D", "E"})
+	h.AddVertex("F")
+	s := h.Stats()
+
+	if s.Vertices != 6 || s.Edges != 3 {
+		t.Fatalf("counts = %d, %d", s.Vertices, s.Edges)
+	}
+	if want := map[int]int{0: 1, 1: 3, 2: 2}; !maps.Equal(s.DegreeDistribution, want) {
+		t.Errorf("DegreeDistribution = %v, want %v", s.DegreeDistribution, want)
+	}
+	if want := map[int]int{2: 2, 3: 1}; !maps.Equal(s.EdgeSizeDistribution, want) {
+		t.Errorf("EdgeSizeDistribution = %v, want %v", s.EdgeSizeDistribution, want)
+	}
+	if !approxEqual(s.Density, 7.0/18) {
+		t.Errorf("Density = %v, want 7/18", s.Density)
+	}
+	if !approxEqual(s.MeanDegree, 7.0/6) || s.MaxDegree != 2 {
+		t.Errorf("degree mean/max = %v, %d", s.MeanDegree, s.MaxDegree)
+	}
+	if !approxEqual(s.MeanEdgeSize, 7.0/3) || s.MaxEdgeSize != 3 {
+		t.Errorf("edge size mean/max = %v, %d", s.MeanEdgeSize, s.MaxEdgeSize)
+	}
+	wantJoint := []DegreeSizeCount{{1, 2, 1}, {1, 3, 2}, {2, 2, 3}, {2, 3, 1}}
+	if !slices.Equal(s.JointDegreeSize, wantJoint) {
+		t.Errorf("JointDegreeSize = %v, want %v", s.JointDegreeSize, wantJoint)
+	}
+	if s.Components != 2 || !maps.Equal(s.ComponentSizes, map[int]int{1: 1, 5: 1}) {
+		t.Errorf("components = %d, %v", s.Components, s.ComponentSizes)
+	}
+}
+
+func TestStats_Empty(t *testing.T) {
+	t.Parallel()
+	s := NewHypergraph[int]().Stats()
+	if s.Density != 0 || s.MeanDegree != 0 || s.Components != 0 || s.Assortativity != 0 {
+		t.Errorf("empty stats = %+v", s)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Errorf("empty stats do not marshal: %v", err)
+	}
+}
+
+// ============================================================================
+// Clustering Tests
+// ============================================================================
+
+func TestClustering_SingleEdgeVsTriangle(t *testing.T) {
+	t.Parallel()
+	// One 3-edge: a triangle in the 2-section, but no higher-order wedges.
+	single := fromEdges(t, []string{"A", "B", "C"})
+	c := single.Clustering()
+	if c.PairwiseAverage != 1 || c.PairwiseGlobal != 1 {
+		t.Errorf("single edge pairwise = %+v", c)
+	}
+	if c.HigherOrderAverage != 0 || !math.IsNaN(single.HigherOrderClustering("A")) {
+		t.Errorf("single edge higher-order = %+v", c)
+	}
+
+	// Three 2-edges: a triangle built from distinct edges.
+	tri := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"})
+	c = tri.Clustering()
+	if c.HigherOrderAverage != 1 || c.HigherOrderGlobal != 1 || tri.HigherOrderClustering("B") != 1 {
+		t.Errorf("triangle higher-order = %+v", c)
+	}
+
+	// Two edges through A leave the wedge B-A-C open.
+	path := fromEdges(t, []string{"A", "B"}, []string{"A", "C"})
+	if got := path.HigherOrderClustering("A"); got != 0 {
+		t.Errorf("open wedge = %v, want 0", got)
+	}
+	if got := path.TwoSection().LocalClustering("A"); got != 0 {
+		t.Errorf("pairwise open wedge = %v, want 0", got)
+	}
+}
+
+func TestClustering_Reproducible(t *testing.T) {
+	t.Parallel()
+	// Map order changes between runs; the sums must not.
+	h := randomWeightedHypergraph(rand.New(rand.NewPCG(3, 5)), 60, 120)
+	want := h.Clustering()
+	for range 10 {
+		if got := h.Clustering(); got != want {
+			t.Fatalf("Clustering = %+v, then %+v", want, got)
+		}
+	}
+}
+
+func TestClustering_HallCondition(t *testing.T) {
+	t.Parallel()
+	// A-B and A-C come from edges x and y; the only edge holding B and C
+	// is y itself, so the wedge at A is not closed by a third edge.
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("x", []string{"A", "B"})
+	_ = h.AddEdge("y", []string{"A", "B", "C"})
+	if got := h.HigherOrderClustering("A"); got != 0 {
+		t.Errorf("HigherOrderClustering(A) = %v, want 0", got)
+	}
+	_ = h.AddEdge("z", []string{"B", "C"})
+	if got := h.HigherOrderClustering("A"); got != 1 {
+		t.Errorf("with z: HigherOrderClustering(A) = %v, want 1", got)
+	}
+}
+
+func TestGraph_Transitivity(t *testing.T) {
+	t.Parallel()
+	// A triangle with a pendant: 1 triangle, 5 connected triples.
+	g := NewGraph[string]()
+	for _, e := range [][2]string{{"A", "B"}, {"B", "C"}, {"A", "C"}, {"C", "D"}} {
+		_ = g.AddEdge(e[0], e[1])
+	}
+	if got := g.Transitivity(); !approxEqual(got, 3.0/5) {
+		t.Errorf("Transitivity = %v, want 0.6", got)
+	}
+	if got := g.LocalClustering("C"); !approxEqual(got, 1.0/3) {
+		t.Errorf("LocalClustering(C) = %v, want 1/3", got)
+	}
+	if got := g.AverageClustering(); !approxEqual(got, (1+1+1.0/3)/3) {
+		t.Errorf("AverageClustering = %v", got)
+	}
+	if !math.IsNaN(g.LocalClustering("D")) {
+		t.Error("LocalClustering of a leaf should be NaN")
+	}
+}
+
+// ============================================================================
+// Assortativity Tests
+// ============================================================================
+
+func TestDegreeAssortativity(t *testing.T) {
+	t.Parallel()
+	// A star: the hub (degree 3) only meets leaves (degree 1).
+	star := fromEdges(t, []string{"H", "A"}, []string{"H", "B"}, []string{"H", "C"})
+	if got := star.DegreeAssortativity(); !approxEqual(got, -1) {
+		t.Errorf("star assortativity = %v, want -1", got)
+	}
+	regular := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"C", "A"})
+	if got := regular.DegreeAssortativity(); got != 0 {
+		t.Errorf("regular assortativity = %v, want 0", got)
+	}
+}
+
+func TestDegreeAssortativity_MatchesPearson(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(38, 1))
+	h := randomWeightedHypergraph(rng, 12, 15)
+	var xs, ys []float64
+	for _, id := range h.Edges() {
+		members := h.EdgeMembers(id)
+		for _, u := range members {
+			for _, w := range members {
+				if u != w {
+					xs = append(xs, float64(h.VertexDegree(u)))
+					ys = append(ys, float64(h.VertexDegree(w)))
+				}
+			}
+		}
+	}
+	mean := func(s []float64) float64 {
+		total := 0.0
+		for _, x := range s {
+			total += x
+		}
+		return total / float64(len(s))
+	}
+	mx, my := mean(xs), mean(ys)
+	var cov, vx, vy float64
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+		vx += (xs[i] - mx) * (xs[i] - mx)
+		vy += (ys[i] - my) * (ys[i] - my)
+	}
+	want := cov / math.Sqrt(vx*vy)
+	if got := h.DegreeAssortativity(); !approxEqual(got, want) {
+		t.Errorf("DegreeAssortativity = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,457 @@
+package hypergraph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	snapshotFile = "snapshot.json"
+	walFile      = "wal.log"
+	lockFile     = "lock"
+)
+
+// DefaultSnapshotEvery is the number of logged mutations after which a
+// [Store] compacts its log unless [StoreOptions] says otherwise.
+const DefaultSnapshotEvery = 1000
+
+var errStoreClosed = errors.New("store is closed")
+
+// StoreOptions configures [OpenStore].
+type StoreOptions struct {
+	// SnapshotEvery is the number of logged mutations after which the log
+	// is compacted into a new snapshot. Zero means DefaultSnapshotEvery;
+	// a negative value disables automatic compaction.
+	SnapshotEvery int
+	// NoSync skips the fsync after each log append. Mutations then survive
+	// a crash of the process but not of the operating system.
+	NoSync bool
+}
+
+// Store is a hypergraph kept in a directory on disk. Each mutation is
+// appended to a write-ahead log (wal.log) before the call returns, so a
+// change costs one small write however large the hypergraph is. The log is
+// compacted from time to time into a snapshot (snapshot.json), and opening
+// the store loads the snapshot and replays the log after it.
+//
+// Mutations have the same semantics and errors as the [Hypergraph]
+// methods of the same name. An invalid mutation is not logged. If a log
+// write fails, the hypergraph in memory is ahead of the disk, and every
+// later mutation returns the write error.
+//
+// A Store is not safe for concurrent use. A directory can be open in only
+// one store at a time, in any process: the store holds an operating system
+// lock on the file "lock" there until [Store.Close] or the process exits.
+type Store[V comparable] struct {
+	dir    string
+	opts   StoreOptions
+	h      *Hypergraph[V]
+	wal    *os.File
+	lock   *os.File
+	seq    uint64 // sequence number of the last logged mutation
+	logged int    // records in the log, including ones the snapshot covers
+	err    error  // set once the log can no longer be trusted
+}
+
+// walRecord is one logged mutation. Seq numbers records consecutively
+// across snapshots; a snapshot stores the Seq of the last record it
+// includes.
+type walRecord[V comparable] struct {
+	Seq     uint64           `json:"seq"`
+	Op      string           `json:"op"`
+	Vertex  *V               `json:"vertex,omitempty"`
+	Edge    string           `json:"edge,omitempty"`
+	Members []V              `json:"members,omitempty"`
+	Roles   []RoleBinding[V] `json:"roles,omitempty"`
+	Weight  *float64         `json:"weight,omitempty"`
+}
+
+const (
+	opAddVertex    = "add_vertex"
+	opRemoveVertex = "remove_vertex"
+	opAddEdge      = "add_edge"
+	opAddRoleEdge  = "add_role_edge"
+	opRemoveEdge   = "remove_edge"
+	opSetWeight    = "set_weight"
+	opSetRoles     = "set_roles"
+)
+
+// Open opens the store in dir with default options, creating the
+// directory if it does not exist.
+func Open[V comparable](dir string) (*Store[V], error) {
+	return OpenStore[V](dir, StoreOptions{})
+}
+
+// OpenStore opens the store in dir, creating the directory if it does not
+// exist, and recovers the hypergraph from its snapshot and log.
+//
+// A final log record that was only partly written, as happens when the
+// process dies during an append, is discarded. Any other damage to the
+// snapshot or log returns an error wrapping [ErrCorruptStore].
+//
+// If another store has the directory open, OpenStore returns an error
+// wrapping [ErrStoreLocked]. The lock ends with the process that holds it,
+// so a store left open by a process that died opens normally.
+func OpenStore[V comparable](dir string, opts StoreOptions) (*Store[V], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	lock, err := lockStore(filepath.Join(dir, lockFile))
+	if err != nil {
+		return nil, err
+	}
+	// The pid is only for people wondering who holds the lock.
+	err = lock.Truncate(0)
+	if err == nil {
+		_, err = fmt.Fprintf(lock, "%d\n", os.Getpid())
+	}
+	if err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	s := &Store[V]{dir: dir, opts: opts, lock: lock}
+	if err := s.open(); err != nil {
+		if s.wal != nil {
+			_ = s.wal.Close()
+		}
+		_ = lock.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// open recovers the hypergraph and opens the log for appending.
+func (s *Store[V]) open() error {
+	// A snapshot left half-written by a crash was never renamed into
+	// place, so it can simply go.
+	_ = os.Remove(filepath.Join(s.dir, snapshotFile+".tmp"))
+
+	if err := s.loadSnapshot(); err != nil {
+		return err
+	}
+	valid, err := s.replay()
+	if err != nil {
+		return err
+	}
+	s.wal, err = os.OpenFile(filepath.Join(s.dir, walFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if valid >= 0 {
+		if err := s.wal.Truncate(valid); err != nil {
+			return err
+		}
+	}
+	if every := s.snapshotEvery(); every > 0 && s.logged >= every {
+		return s.Snapshot()
+	}
+	return nil
+}
+
+func (s *Store[V]) corrupt(file string, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrCorruptStore, filepath.Join(s.dir, file), fmt.Sprintf(format, args...))
+}
+
+func (s *Store[V]) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(s.dir, snapshotFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		s.h = NewHypergraph[V]()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var snap struct {
+		Seq        uint64          `json:"seq"`
+		Hypergraph json.RawMessage `json:"hypergraph"`
+	}
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return s.corrupt(snapshotFile, "%v", err)
+	}
+	if snap.Hypergraph == nil {
+		return s.corrupt(snapshotFile, "no hypergraph")
+	}
+	if s.h, err = LoadJSON[V](bytes.NewReader(snap.Hypergraph)); err != nil {
+		return s.corrupt(snapshotFile, "%v", err)
+	}
+	s.seq = snap.Seq
+	return nil
+}
+
+// replay applies the log records after the snapshot. It returns the
+// length of the valid prefix of the log if a torn final record must be
+// cut off, and -1 otherwise. Records must be numbered consecutively; only
+// leading ones may be covered by the snapshot.
+func (s *Store[V]) replay() (int64, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, walFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return -1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	off := 0
+	var last uint64 // Seq of the previous record, 0 before the first
+	for line := 1; off < len(data); line++ {
+		end := bytes.IndexByte(data[off:], '\n')
+		if end < 0 {
+			return int64(off), nil
+		}
+		rec, ok := parseWALLine[V](data[off : off+end])
+		if !ok {
+			if off+end+1 == len(data) {
+				return int64(off), nil
+			}
+			return -1, s.corrupt(walFile, "line %d: damaged record", line)
+		}
+		switch {
+		case last != 0 && rec.Seq != last+1:
+			return -1, s.corrupt(walFile, "line %d: record %d follows record %d", line, rec.Seq, last)
+		case rec.Seq <= s.seq:
+			// Already in the snapshot: the process stopped between
+			// writing a snapshot and truncating the log.
+		case rec.Seq != s.seq+1:
+			return -1, s.corrupt(walFile, "line %d: record %d follows record %d", line, rec.Seq, s.seq)
+		default:
+			if err := s.h.applyWAL(rec); err != nil {
+				return -1, s.corrupt(walFile, "line %d: %v", line, err)
+			}
+			s.seq = rec.Seq
+		}
+		last = rec.Seq
+		s.logged++
+		off += end + 1
+	}
+	return -1, nil
+}
+
+// parseWALLine decodes a log line, which is the CRC-32 of the record's
+// JSON in hex, a space, and the JSON.
+func parseWALLine[V comparable](line []byte) (walRecord[V], bool) {
+	var rec walRecord[V]
+	sum, data, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return rec, false
+	}
+	want, err := strconv.ParseUint(string(sum), 16, 32)
+	if err != nil || uint32(want) != crc32.ChecksumIEEE(data) {
+		return rec, false
+	}
+	return rec, json.Unmarshal(data, &rec) == nil
+}
+
+func (h *Hypergraph[V]) applyWAL(rec walRecord[V]) error {
+	switch rec.Op {
+	case opAddVertex, opRemoveVertex:
+		if rec.Vertex == nil {
+			return fmt.Errorf("%s without a vertex", rec.Op)
+		}
+		if rec.Op == opAddVertex {
+			h.AddVertex(*rec.Vertex)
+		} else {
+			h.RemoveVertex(*rec.Vertex)
+		}
+		return nil
+	case opAddEdge:
+		return h.AddEdge(rec.Edge, rec.Members)
+	case opAddRoleEdge:
+		return h.AddRoleEdge(rec.Edge, rec.Roles)
+	case opRemoveEdge:
+		h.RemoveEdge(rec.Edge)
+		return nil
+	case opSetWeight:
+		if rec.Weight == nil {
+			return fmt.Errorf("%s without a weight", rec.Op)
+		}
+		return h.SetEdgeWeight(rec.Edge, *rec.Weight)
+	case opSetRoles:
+		return h.SetEdgeRoles(rec.Edge, rec.Roles)
+	}
+	return fmt.Errorf("unknown operation %q", rec.Op)
+}
+
+func (s *Store[V]) snapshotEvery() int {
+	if s.opts.SnapshotEvery == 0 {
+		return DefaultSnapshotEvery
+	}
+	return s.opts.SnapshotEvery
+}
+
+// Graph returns the hypergraph held by the store, for queries and
+// algorithms. It is the store's own copy, not a clone: changes made to it
+// directly are not logged and are lost when the store is reopened.
+func (s *Store[V]) Graph() *Hypergraph[V] {
+	return s.h
+}
+
+// apply applies rec in memory and, if that succeeds, logs it.
+func (s *Store[V]) apply(rec walRecord[V]) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.h.applyWAL(rec); err != nil {
+		return err
+	}
+	return s.append(rec)
+}
+
+func (s *Store[V]) append(rec walRecord[V]) error {
+	rec.Seq = s.seq + 1
+	data, err := json.Marshal(rec)
+	if err == nil {
+		// One Write per record, so a crash tears at most the last line.
+		_, err = fmt.Fprintf(s.wal, "%08x %s\n", crc32.ChecksumIEEE(data), data)
+	}
+	if err == nil && !s.opts.NoSync {
+		err = s.wal.Sync()
+	}
+	if err != nil {
+		s.err = fmt.Errorf("store %s: %w", s.dir, err)
+		return s.err
+	}
+	s.seq = rec.Seq
+	s.logged++
+	if every := s.snapshotEvery(); every > 0 && s.logged >= every {
+		return s.Snapshot()
+	}
+	return nil
+}
+
+// AddVertex adds a vertex. Adding an existing vertex is not logged.
+func (s *Store[V]) AddVertex(v V) error {
+	if s.err == nil && s.h.HasVertex(v) {
+		return nil
+	}
+	return s.apply(walRecord[V]{Op: opAddVertex, Vertex: &v})
+}
+
+// RemoveVertex removes a vertex; see [Hypergraph.RemoveVertex]. Removing
+// a missing vertex is not logged.
+func (s *Store[V]) RemoveVertex(v V) error {
+	if s.err == nil && !s.h.HasVertex(v) {
+		return nil
+	}
+	return s.apply(walRecord[V]{Op: opRemoveVertex, Vertex: &v})
+}
+
+// AddEdge adds an edge; see [Hypergraph.AddEdge].
+func (s *Store[V]) AddEdge(id string, members []V) error {
+	return s.apply(walRecord[V]{Op: opAddEdge, Edge: id, Members: members})
+}
+
+// AddRoleEdge adds a role-labeled edge; see [Hypergraph.AddRoleEdge].
+func (s *Store[V]) AddRoleEdge(id string, bindings []RoleBinding[V]) error {
+	return s.apply(walRecord[V]{Op: opAddRoleEdge, Edge: id, Roles: bindings})
+}
+
+// AddOrderedEdge adds an edge whose members form a tuple; see
+// [Hypergraph.AddOrderedEdge].
+func (s *Store[V]) AddOrderedEdge(id string, members []V) error {
+	bindings := make([]RoleBinding[V], len(members))
+	for i, v := range members {
+		bindings[i] = RoleBinding[V]{Role: strconv.Itoa(i), Vertex: v}
+	}
+	return s.AddRoleEdge(id, bindings)
+}
+
+// RemoveEdge removes an edge. Removing a missing edge is not logged.
+func (s *Store[V]) RemoveEdge(id string) error {
+	if s.err == nil && !s.h.HasEdge(id) {
+		return nil
+	}
+	return s.apply(walRecord[V]{Op: opRemoveEdge, Edge: id})
+}
+
+// SetEdgeWeight sets the weight of an edge; see [Hypergraph.SetEdgeWeight].
+func (s *Store[V]) SetEdgeWeight(id string, w float64) error {
+	return s.apply(walRecord[V]{Op: opSetWeight, Edge: id, Weight: &w})
+}
+
+// SetEdgeRoles replaces the role bindings of an edge; see
+// [Hypergraph.SetEdgeRoles].
+func (s *Store[V]) SetEdgeRoles(id string, bindings []RoleBinding[V]) error {
+	return s.apply(walRecord[V]{Op: opSetRoles, Edge: id, Roles: bindings})
+}
+
+// Snapshot compacts the store: it writes the whole hypergraph to a new
+// snapshot, then empties the log. Stores call it automatically every
+// [StoreOptions.SnapshotEvery] mutations.
+func (s *Store[V]) Snapshot() error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.writeSnapshot(); err != nil {
+		return err
+	}
+	// If the process stops before the truncation, the records the snapshot
+	// covers are skipped on replay by their sequence numbers.
+	if err := s.wal.Truncate(0); err != nil {
+		s.err = fmt.Errorf("store %s: %w", s.dir, err)
+		return s.err
+	}
+	s.logged = 0
+	return nil
+}
+
+// writeSnapshot writes the hypergraph and the last sequence number to a
+// temporary file, syncs it and renames it over the old snapshot. It
+// always syncs, even with NoSync: the log is emptied next, so a lost
+// snapshot would lose data.
+func (s *Store[V]) writeSnapshot() error {
+	tmp := filepath.Join(s.dir, snapshotFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	fmt.Fprintf(bw, `{"seq":%d,"hypergraph":`, s.seq)
+	err = s.h.SaveJSON(bw)
+	if err == nil {
+		_, _ = bw.WriteString("}\n")
+		err = bw.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err = errors.Join(err, f.Close()); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, snapshotFile)); err != nil {
+		return err
+	}
+	syncDir(s.dir)
+	return nil
+}
+
+// syncDir makes a rename in dir durable. Not every platform can sync a
+// directory, so errors are ignored.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
+
+// Close closes the log and releases the directory. The store cannot be
+// mutated afterwards, but [Store.Graph] stays usable.
+func (s *Store[V]) Close() error {
+	if s.wal == nil {
+		return nil
+	}
+	// The lock file stays: removing it would let a store that opened the
+	// old file and one that creates a new file both hold a lock.
+	err := s.wal.Close()
+	s.wal = nil
+	s.err = errStoreClosed
+	return errors.Join(err, s.lock.Close())
+}
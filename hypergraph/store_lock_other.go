@@ -0,0 +1,11 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package hypergraph
+
+import "os"
+
+// lockStore opens the lock file at path, creating it if needed. This
+// system has no advisory file locks, so the directory is not locked.
+func lockStore(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+}
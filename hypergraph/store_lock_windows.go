@@ -0,0 +1,30 @@
+package hypergraph
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// errorSharingViolation is ERROR_SHARING_VIOLATION, which CreateFile
+// returns when another handle has the file open without sharing.
+const errorSharingViolation syscall.Errno = 32
+
+// lockStore opens the lock file at path, creating it if needed, without
+// sharing it, so no other handle can open it until the file is closed or
+// the process exits.
+func lockStore(path string) (*os.File, error) {
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	h, err := syscall.CreateFile(name, syscall.GENERIC_READ|syscall.GENERIC_WRITE, 0, nil,
+		syscall.OPEN_ALWAYS, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err == errorSharingViolation {
+		return nil, fmt.Errorf("%w: %s is held", ErrStoreLocked, path)
+	}
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(h), path), nil
+}
@@ -0,0 +1,301 @@
+package hypergraph
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// mutateStore applies the same changes to a store and a plain hypergraph.
+func mutateStore(t *testing.T, s *Store[string], want *Hypergraph[string]) {
+	t.Helper()
+	steps := []struct {
+		store func() error
+		plain func() error
+	}{
+		{func() error { return s.AddVertex("x") }, func() error { want.AddVertex("x"); return nil }},
+		{func() error { return s.AddEdge("e1", []string{"a", "b"}) }, func() error { return want.AddEdge("e1", []string{"a", "b"}) }},
+		{func() error { return s.AddEdge("e2", []string{"b", "c", "d"}) }, func() error { return want.AddEdge("e2", []string{"b", "c", "d"}) }},
+		{func() error { return s.SetEdgeWeight("e2", 2.5) }, func() error { return want.SetEdgeWeight("e2", 2.5) }},
+		{func() error { return s.AddOrderedEdge("t", []string{"c", "a", "c"}) }, func() error { return want.AddOrderedEdge("t", []string{"c", "a", "c"}) }},
+		{func() error {
+			return s.SetEdgeRoles("e1", []RoleBinding[string]{{Role: "src", Vertex: "a"}})
+		}, func() error {
+			return want.SetEdgeRoles("e1", []RoleBinding[string]{{Role: "src", Vertex: "a"}})
+		}},
+		{func() error { return s.RemoveVertex("d") }, func() error { want.RemoveVertex("d"); return nil }},
+		{func() error { return s.RemoveEdge("t") }, func() error { want.RemoveEdge("t"); return nil }},
+	}
+	for i, step := range steps {
+		if err := step.store(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if err := step.plain(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+}
+
+func reopen(t *testing.T, dir string, opts StoreOptions) *Store[string] {
+	t.Helper()
+	s, err := OpenStore[string](dir, opts)
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func checkSame(t *testing.T, got, want *Hypergraph[string]) {
+	t.Helper()
+	if d := Diff(want, got); !d.IsEmpty() {
+		t.Fatalf("recovered hypergraph differs: %+v", d)
+	}
+}
+
+// ============================================================================
+// Store Tests
+// ============================================================================
+
+func TestStore_Recovery(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := Open[string](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NewHypergraph[string]()
+	mutateStore(t, s, want)
+	checkSame(t, s.Graph(), want)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddVertex("y"); err == nil {
+		t.Error("mutating a closed store should fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile)); !os.IsNotExist(err) {
+		t.Error("a short log should not have been compacted")
+	}
+
+	checkSame(t, reopen(t, dir, StoreOptions{}).Graph(), want)
+}
+
+func TestStore_Compaction(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{SnapshotEvery: 3, NoSync: true})
+	want := NewHypergraph[string]()
+	mutateStore(t, s, want)
+	_ = s.Close()
+
+	// Eight mutations with a snapshot every three leave two in the log.
+	data, _ := os.ReadFile(filepath.Join(dir, walFile))
+	if n := strings.Count(string(data), "\n"); n != 2 {
+		t.Errorf("log has %d records, want 2", n)
+	}
+	s = reopen(t, dir, StoreOptions{})
+	checkSame(t, s.Graph(), want)
+
+	if err := s.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(filepath.Join(dir, walFile)); info.Size() != 0 {
+		t.Error("Snapshot should empty the log")
+	}
+	_ = s.Close()
+	checkSame(t, reopen(t, dir, StoreOptions{}).Graph(), want)
+}
+
+func TestStore_InvalidMutation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddEdge("e", []string{"a"})
+	if err := s.AddEdge("e", []string{"b"}); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("duplicate edge: %v", err)
+	}
+	if err := s.SetEdgeWeight("nope", 2); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("missing edge: %v", err)
+	}
+	if err := s.SetEdgeWeight("e", -1); err == nil {
+		t.Error("negative weight should fail")
+	}
+	// Rejected mutations and no-ops are not logged.
+	_ = s.AddVertex("a")
+	_ = s.RemoveEdge("nope")
+	_ = s.Close()
+	data, _ := os.ReadFile(filepath.Join(dir, walFile))
+	if n := strings.Count(string(data), "\n"); n != 1 {
+		t.Errorf("log has %d records, want 1:\n%s", n, data)
+	}
+}
+
+func TestStore_TornWrite(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddEdge("e1", []string{"a", "b"})
+	_ = s.AddEdge("e2", []string{"b", "c"})
+	_ = s.Close()
+
+	// Cut the last record in half, as a crash during the append would.
+	wal := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(wal)
+	keep := strings.Index(string(data), "\n") + 1
+	_ = os.WriteFile(wal, data[:keep+10], 0o644)
+
+	s = reopen(t, dir, StoreOptions{})
+	if !s.Graph().HasEdge("e1") || s.Graph().HasEdge("e2") {
+		t.Fatalf("edges after recovery: %v", s.Graph().Edges())
+	}
+	if err := s.AddEdge("e3", []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Close()
+	s = reopen(t, dir, StoreOptions{})
+	if !s.Graph().HasEdge("e3") || s.Graph().NumEdges() != 2 {
+		t.Errorf("edges after second recovery: %v", s.Graph().Edges())
+	}
+}
+
+func TestStore_Corruption(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddEdge("e1", []string{"a", "b"})
+	_ = s.AddEdge("e2", []string{"b", "c"})
+	_ = s.Close()
+
+	wal := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(wal)
+	damaged := []byte(strings.Replace(string(data), `"a"`, `"z"`, 1))
+	_ = os.WriteFile(wal, damaged, 0o644)
+	if _, err := Open[string](dir); !errors.Is(err, ErrCorruptStore) || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("damaged record: %v", err)
+	}
+
+	_ = os.WriteFile(wal, data, 0o644)
+	_ = os.WriteFile(filepath.Join(dir, snapshotFile), []byte(`{"seq": 1}`), 0o644)
+	if _, err := Open[string](dir); !errors.Is(err, ErrCorruptStore) {
+		t.Errorf("snapshot without hypergraph: %v", err)
+	}
+}
+
+func TestStore_CrashAfterSnapshot(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddEdge("e1", []string{"a", "b"})
+	_ = s.AddEdge("e2", []string{"b", "c"})
+	wal := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(wal)
+	if err := s.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.AddEdge("e3", []string{"c", "d"})
+	_ = s.Close()
+
+	// Put back the records the snapshot covers, as if the process had
+	// stopped before truncating the log.
+	tail, _ := os.ReadFile(wal)
+	_ = os.WriteFile(wal, append(data, tail...), 0o644)
+
+	s = reopen(t, dir, StoreOptions{})
+	if s.Graph().NumEdges() != 3 {
+		t.Errorf("edges: %v", s.Graph().Edges())
+	}
+}
+
+func TestStore_RepeatedSeq(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddEdge("e1", []string{"a", "b"})
+	_ = s.AddEdge("e2", []string{"b", "c"})
+	_ = s.Close()
+
+	// Two writers appending at once would both number their record 2.
+	wal := filepath.Join(dir, walFile)
+	data, _ := os.ReadFile(wal)
+	second := data[strings.Index(string(data), "\n")+1:]
+	_ = os.WriteFile(wal, append(data, second...), 0o644)
+	if _, err := Open[string](dir); !errors.Is(err, ErrCorruptStore) || !strings.Contains(err.Error(), "line 3: record 2 follows record 2") {
+		t.Errorf("repeated record: %v", err)
+	}
+}
+
+func TestStore_Lock(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	if _, err := Open[string](dir); !errors.Is(err, ErrStoreLocked) {
+		t.Fatalf("second open: %v", err)
+	}
+	_ = s.AddVertex("a")
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s = reopen(t, dir, StoreOptions{})
+	if !s.Graph().HasVertex("a") {
+		t.Error("vertex lost")
+	}
+	_ = s.Close()
+
+	// A store that fails to open releases the lock.
+	snapshot := filepath.Join(dir, snapshotFile)
+	_ = os.WriteFile(snapshot, []byte("{"), 0o644)
+	if _, err := Open[string](dir); !errors.Is(err, ErrCorruptStore) {
+		t.Fatalf("damaged snapshot: %v", err)
+	}
+	_ = os.Remove(snapshot)
+	s = reopen(t, dir, StoreOptions{})
+	_ = s.Close()
+}
+
+func TestStore_StaleLock(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s := reopen(t, dir, StoreOptions{})
+	_ = s.AddVertex("a")
+	_ = s.Close()
+
+	// A process that died with the store open leaves its lock file, with
+	// a pid that no longer runs, but not its lock.
+	if err := os.WriteFile(filepath.Join(dir, lockFile), []byte("2147483646\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s = reopen(t, dir, StoreOptions{})
+	if !s.Graph().HasVertex("a") {
+		t.Error("vertex lost")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, lockFile)); string(data) != strconv.Itoa(os.Getpid())+"\n" {
+		t.Errorf("lock file = %q", data)
+	}
+	if _, err := Open[string](dir); !errors.Is(err, ErrStoreLocked) {
+		t.Errorf("second open: %v", err)
+	}
+}
+
+func TestStore_IntVertices(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := Open[int](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = s.AddEdge("e", []int{10, 2})
+	_ = s.AddVertex(0)
+	_ = s.Close()
+
+	s, err = Open[int](dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = s.Close() }()
+	if !s.Graph().HasVertex(0) || s.Graph().NumVertices() != 3 {
+		t.Errorf("vertices: %v", s.Graph().Vertices())
+	}
+}
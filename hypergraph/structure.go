@@ -0,0 +1,286 @@
+package hypergraph
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// IsKUniform reports whether every edge has exactly k members. If not, it
+// returns the ID of the smallest edge whose size differs from k. An empty
+// hypergraph is k-uniform for every k.
+func (h *Hypergraph[V]) IsKUniform(k int) (bool, string) {
+	for _, id := range slices.Sorted(maps.Keys(h.edges)) {
+		if len(h.edges[id].Set) != k {
+			return false, id
+		}
+	}
+	return true, ""
+}
+
+// IsLinear reports whether every two distinct edges share at most one
+// vertex. If not, it returns two edges sharing two or more vertices.
+func (h *Hypergraph[V]) IsLinear() (bool, [2]string) {
+	ids := slices.Sorted(maps.Keys(h.edges))
+	for i, e1 := range ids {
+		for _, e2 := range ids[i+1:] {
+			shared := 0
+			for range h.EdgeIntersection(e1, e2, Unordered) {
+				shared++
+				if shared > 1 {
+					return false, [2]string{e1, e2}
+				}
+			}
+		}
+	}
+	return true, [2]string{}
+}
+
+// IsSimple reports whether no edge is contained in another, that is,
+// whether the edges form a Sperner family. Two edges with the same members
+// violate it. If not simple, it returns IDs {sub, super} with the members
+// of sub contained in those of super.
+func (h *Hypergraph[V]) IsSimple() (bool, [2]string) {
+	ids := slices.Sorted(maps.Keys(h.edges))
+	for _, sub := range ids {
+		for _, super := range ids {
+			if sub != super && h.edgeSubset(sub, super) {
+				return false, [2]string{sub, super}
+			}
+		}
+	}
+	return true, [2]string{}
+}
+
+// edgeSubset reports whether the members of e1 are all members of e2.
+func (h *Hypergraph[V]) edgeSubset(e1, e2 string) bool {
+	set := h.edges[e2].Set
+	if len(h.edges[e1].Set) > len(set) {
+		return false
+	}
+	for v := range h.edges[e1].Set {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsConformal reports whether every maximal clique of the primal graph is
+// contained in some edge. If not, it returns such a clique, sorted.
+// Cliques of one vertex are ignored, so vertices in no edge do not count.
+// Time complexity: that of [FromMaximalCliques] on the primal graph.
+func (h *Hypergraph[V]) IsConformal() (bool, []V) {
+	cliques := FromMaximalCliques(h.Primal())
+	for _, id := range slices.Sorted(maps.Keys(cliques.edges)) {
+		clique := slices.Collect(cliques.Members(id, Sorted))
+		if len(clique) < 2 {
+			continue
+		}
+		contained := false
+		for e := range h.IncidentEdges(clique[0], Unordered) {
+			if h.containsAll(e, clique) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			return false, clique
+		}
+	}
+	return true, nil
+}
+
+// containsAll reports whether edge id contains every vertex in vs.
+func (h *Hypergraph[V]) containsAll(id string, vs []V) bool {
+	set := h.edges[id].Set
+	for _, v := range vs {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHelly reports whether every family of pairwise intersecting edges has
+// a common vertex. If not, it returns such a family with empty
+// intersection, sorted.
+//
+// It uses Berge's criterion: h is Helly if and only if for every three
+// vertices a, b, c, the edges containing at least two of them share a
+// vertex. Only triples in which one vertex shares an edge with both others
+// can fail.
+// Time complexity: O(Σ deg(v)² · Δ) in the primal graph, times the cost of
+// intersecting the family.
+func (h *Hypergraph[V]) IsHelly() (bool, []string) {
+	for _, a := range h.sorted(maps.Keys(h.vertices)) {
+		nbrs := slices.Collect(h.Neighbors(a, Sorted))
+		for i, b := range nbrs {
+			for _, c := range nbrs[i+1:] {
+				family := h.edgesWithTwoOf(a, b, c)
+				if !h.haveCommonVertex(family) {
+					return false, family
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// edgesWithTwoOf returns the sorted IDs of edges containing at least two of
+// a, b and c.
+func (h *Hypergraph[V]) edgesWithTwoOf(a, b, c V) []string {
+	count := make(map[string]int)
+	for _, v := range []V{a, b, c} {
+		for e := range h.IncidentEdges(v, Unordered) {
+			count[e]++
+		}
+	}
+	var family []string
+	for e, n := range count {
+		if n >= 2 {
+			family = append(family, e)
+		}
+	}
+	slices.Sort(family)
+	return family
+}
+
+// haveCommonVertex reports whether the given edges share a vertex. An empty
+// family trivially does.
+func (h *Hypergraph[V]) haveCommonVertex(ids []string) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for v := range h.edges[ids[0]].Set {
+		if !slices.ContainsFunc(ids[1:], func(id string) bool {
+			_, ok := h.edges[id].Set[v]
+			return !ok
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// SpecialCycle is a cycle x0, E0, x1, E1, ..., xk-1, Ek-1, x0 of distinct
+// vertices and distinct edges in which each edge Ei contains xi and
+// x(i+1 mod k) and no other vertex of the cycle.
+type SpecialCycle[V comparable] struct {
+	Vertices []V
+	Edges    []string
+}
+
+// IsBalanced reports whether h is balanced in Berge's sense: it has no
+// special cycle of odd length. If not, it returns an odd special cycle
+// starting at its smallest vertex.
+//
+// The search is exponential in the worst case. If maxTime > 0 and is
+// exceeded, it stops and returns true with [ErrCutoff], meaning no odd
+// special cycle was found in the part searched.
+func (h *Hypergraph[V]) IsBalanced(maxTime time.Duration) (bool, SpecialCycle[V], error) {
+	s := specialCycleSearch[V]{h: h, onCycle: make(map[V]bool), usedEdge: make(map[string]bool)}
+	if maxTime > 0 {
+		s.deadline = time.Now().Add(maxTime)
+	}
+	for _, start := range h.sorted(maps.Keys(h.vertices)) {
+		s.start = start
+		s.vertices = []V{start}
+		s.onCycle[start] = true
+		found := s.extend()
+		delete(s.onCycle, start)
+		if found {
+			return false, SpecialCycle[V]{Vertices: s.vertices, Edges: s.edges}, nil
+		}
+		if s.cutoff {
+			return true, SpecialCycle[V]{}, ErrCutoff
+		}
+	}
+	return true, SpecialCycle[V]{}, nil
+}
+
+// specialCycleSearch grows a path x0, E0, x1, ..., xj from start, where
+// every vertex is larger than start, and each edge so far meets the path
+// only in its two endpoints.
+type specialCycleSearch[V comparable] struct {
+	h        *Hypergraph[V]
+	start    V
+	vertices []V
+	edges    []string
+	onCycle  map[V]bool
+	usedEdge map[string]bool
+	deadline time.Time
+	steps    int
+	cutoff   bool
+}
+
+func (s *specialCycleSearch[V]) extend() bool {
+	s.steps++
+	if s.steps%1024 == 0 && !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		s.cutoff = true
+	}
+	if s.cutoff {
+		return false
+	}
+	last := s.vertices[len(s.vertices)-1]
+	for _, e := range slices.Sorted(s.h.IncidentEdges(last, Unordered)) {
+		if s.usedEdge[e] {
+			continue
+		}
+		// Besides last, e may contain at most one other path vertex, and
+		// only start, which closes the cycle.
+		closes, ok := false, true
+		for v := range s.h.edges[e].Set {
+			if v == last || !s.onCycle[v] {
+				continue
+			}
+			if v == s.start && len(s.vertices) > 1 {
+				closes = true
+			} else {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		s.edges = append(s.edges, e)
+		if closes {
+			if len(s.vertices)%2 == 1 {
+				return true
+			}
+			s.edges = s.edges[:len(s.edges)-1]
+			continue
+		}
+		s.usedEdge[e] = true
+		for _, next := range slices.Collect(s.h.Members(e, Sorted)) {
+			if s.h.compare(next, s.start) <= 0 || s.onCycle[next] || s.inEarlierEdge(next) {
+				continue
+			}
+			s.vertices = append(s.vertices, next)
+			s.onCycle[next] = true
+			if s.extend() {
+				return true
+			}
+			delete(s.onCycle, next)
+			s.vertices = s.vertices[:len(s.vertices)-1]
+		}
+		delete(s.usedEdge, e)
+		s.edges = s.edges[:len(s.edges)-1]
+		if s.cutoff {
+			return false
+		}
+	}
+	return false
+}
+
+// inEarlierEdge reports whether v belongs to a path edge other than the
+// newest one, which would give that edge a third cycle vertex.
+func (s *specialCycleSearch[V]) inEarlierEdge(v V) bool {
+	for _, e := range s.edges[:len(s.edges)-1] {
+		if _, ok := s.h.edges[e].Set[v]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package hypergraph
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+// edgesIntersect reports whether two edges share at least one vertex.
+func (h *Hypergraph[V]) edgesIntersect(e1, e2 string) bool {
+	for range h.EdgeIntersection(e1, e2, Unordered) {
+		return true
+	}
+	return false
+}
+
+// fromEdges builds a hypergraph with edges e0, e1, ... from member lists.
+func fromEdges(t *testing.T, edges ...[]string) *Hypergraph[string] {
+	t.Helper()
+	h := NewHypergraph[string]()
+	for i, members := range edges {
+		if err := h.AddEdge("e"+fmtInt(i), members); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return h
+}
+
+// ============================================================================
+// Uniformity, Linearity and Simplicity Tests
+// ============================================================================
+
+func TestIsKUniform(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"C", "D", "E"})
+	if ok, _ := h.IsKUniform(2); ok {
+		t.Error("mixed sizes reported 2-uniform")
+	}
+	if _, w := h.IsKUniform(2); w != "e2" {
+		t.Errorf("witness = %q, want e2", w)
+	}
+	if _, w := h.IsKUniform(3); w != "e0" {
+		t.Errorf("witness = %q, want e0", w)
+	}
+	h.RemoveEdge("e2")
+	if ok, _ := h.IsKUniform(2); !ok {
+		t.Error("graph not reported 2-uniform")
+	}
+	if ok, _ := NewHypergraph[int]().IsKUniform(5); !ok {
+		t.Error("empty hypergraph should be uniform")
+	}
+}
+
+func TestIsLinear(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"A", "B", "C"}, []string{"C", "D"}, []string{"A", "D"})
+	if ok, _ := h.IsLinear(); !ok {
+		t.Error("linear hypergraph reported non-linear")
+	}
+	_ = h.AddEdge("x", []string{"B", "C", "D"})
+	ok, w := h.IsLinear()
+	if ok || w != [2]string{"e0", "x"} {
+		t.Errorf("IsLinear = %v, %v; want false, [e0 x]", ok, w)
+	}
+}
+
+func TestIsSimple(t *testing.T) {
+	t.Parallel()
+	h := fromEdges(t, []string{"A", "B"}, []string{"B", "C"})
+	if ok, _ := h.IsSimple(); !ok {
+		t.Error("Sperner family reported non-simple")
+	}
+	_ = h.AddEdge("big", []string{"A", "B", "C"})
+	ok, w := h.IsSimple()
+	if ok || w != [2]string{"e0", "big"} {
+		t.Errorf("IsSimple = %v, %v; want false, [e0 big]", ok, w)
+	}
+
+	dup := fromEdges(t, []string{"A", "B"}, []string{"B", "A"})
+	if ok, w := dup.IsSimple(); ok || w != [2]string{"e0", "e1"} {
+		t.Errorf("repeated edge: IsSimple = %v, %v", ok, w)
+	}
+}
+
+// ============================================================================
+// Conformality and Helly Tests
+// ============================================================================
+
+func TestIsConformal(t *testing.T) {
+	t.Parallel()
+	// The triangle's edges form a clique {A, B, C} that no edge contains.
+	tri := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"})
+	ok, clique := tri.IsConformal()
+	if ok || !slices.Equal(clique, []string{"A", "B", "C"}) {
+		t.Errorf("triangle: IsConformal = %v, %v", ok, clique)
+	}
+	_ = tri.AddEdge("all", []string{"A", "B", "C"})
+	if ok, _ := tri.IsConformal(); !ok {
+		t.Error("triangle with covering edge reported non-conformal")
+	}
+
+	h := fromEdges(t, []string{"A", "B", "C"}, []string{"C", "D"})
+	h.AddVertex("isolated")
+	if ok, c := h.IsConformal(); !ok {
+		t.Errorf("conformal hypergraph reported clique %v", c)
+	}
+}
+
+func TestIsHelly(t *testing.T) {
+	t.Parallel()
+	tri := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"})
+	ok, family := tri.IsHelly()
+	if ok || !slices.Equal(family, []string{"e0", "e1", "e2"}) {
+		t.Errorf("triangle: IsHelly = %v, %v", ok, family)
+	}
+
+	star := fromEdges(t, []string{"X", "A", "B"}, []string{"X", "B", "C"}, []string{"X", "A", "C"})
+	if ok, f := star.IsHelly(); !ok {
+		t.Errorf("star reported non-Helly with %v", f)
+	}
+}
+
+// bruteForceHelly checks every family of pairwise intersecting edges.
+func bruteForceHelly(h *Hypergraph[int]) bool {
+	ids := h.Edges()
+	slices.Sort(ids)
+	for mask := 1; mask < 1<<len(ids); mask++ {
+		var family []string
+		for i, id := range ids {
+			if mask&(1<<i) != 0 {
+				family = append(family, id)
+			}
+		}
+		pairwise := true
+		for i := range family {
+			for j := range i {
+				if !h.edgesIntersect(family[i], family[j]) {
+					pairwise = false
+				}
+			}
+		}
+		if pairwise && !h.haveCommonVertex(family) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIsHelly_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(37, 1))
+	for trial := range 200 {
+		h := randomWeightedHypergraph(rng, 6, 1+rng.IntN(6))
+		want := bruteForceHelly(h)
+		ok, family := h.IsHelly()
+		if ok != want {
+			t.Fatalf("trial %d: IsHelly = %v, brute force = %v", trial, ok, want)
+		}
+		if !ok && h.haveCommonVertex(family) {
+			t.Fatalf("trial %d: witness %v has a common vertex", trial, family)
+		}
+	}
+}
+
+// ============================================================================
+// Balancedness Tests
+// ============================================================================
+
+func TestIsBalanced(t *testing.T) {
+	t.Parallel()
+	tri := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"A", "C"})
+	ok, cycle, err := tri.IsBalanced(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || !slices.Equal(cycle.Vertices, []string{"A", "B", "C"}) ||
+		!slices.Equal(cycle.Edges, []string{"e0", "e1", "e2"}) {
+		t.Errorf("triangle: IsBalanced = %v, %+v", ok, cycle)
+	}
+
+	// A triangle whose vertices all lie in one more edge is still
+	// unbalanced: the special cycle avoids that edge.
+	_ = tri.AddEdge("all", []string{"A", "B", "C"})
+	if ok, _, _ := tri.IsBalanced(0); ok {
+		t.Error("triangle plus covering edge reported balanced")
+	}
+
+	// An even cycle is balanced.
+	square := fromEdges(t, []string{"A", "B"}, []string{"B", "C"}, []string{"C", "D"}, []string{"D", "A"})
+	if ok, c, _ := square.IsBalanced(0); !ok {
+		t.Errorf("4-cycle reported odd cycle %+v", c)
+	}
+	// A triangle through a 3-edge is not special: that edge holds three
+	// cycle vertices.
+	fan := fromEdges(t, []string{"A", "B", "C"}, []string{"A", "B"})
+	if ok, c, _ := fan.IsBalanced(0); !ok {
+		t.Errorf("fan reported odd cycle %+v", c)
+	}
+}
+
+// bruteForceBalanced looks for an odd square submatrix of the incidence
+// matrix whose rows and columns all sum to 2.
+func bruteForceBalanced(h *Hypergraph[int]) bool {
+	vs := h.Vertices()
+	slices.Sort(vs)
+	ids := h.Edges()
+	slices.Sort(ids)
+	for vmask := 1; vmask < 1<<len(vs); vmask++ {
+		k := bits.OnesCount(uint(vmask))
+		if k < 3 || k%2 == 0 {
+			continue
+		}
+		for emask := 1; emask < 1<<len(ids); emask++ {
+			if bits.OnesCount(uint(emask)) != k {
+				continue
+			}
+			deg := make(map[int]int)
+			good := true
+			for j, id := range ids {
+				if emask&(1<<j) == 0 {
+					continue
+				}
+				n := 0
+				for i, v := range vs {
+					if vmask&(1<<i) != 0 && h.containsAll(id, []int{v}) {
+						n++
+						deg[v]++
+					}
+				}
+				good = good && n == 2
+			}
+			for i, v := range vs {
+				if vmask&(1<<i) != 0 && deg[v] != 2 {
+					good = false
+				}
+			}
+			if good {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestIsBalanced_BruteForce(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(37, 2))
+	for trial := range 200 {
+		h := randomWeightedHypergraph(rng, 6, 1+rng.IntN(6))
+		want := bruteForceBalanced(h)
+		ok, cycle, err := h.IsBalanced(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != want {
+			t.Fatalf("trial %d: IsBalanced = %v, brute force = %v", trial, ok, want)
+		}
+		if ok {
+			continue
+		}
+		k := len(cycle.Vertices)
+		if k%2 == 0 || len(cycle.Edges) != k {
+			t.Fatalf("trial %d: bad witness %+v", trial, cycle)
+		}
+		for i, e := range cycle.Edges {
+			for j, v := range cycle.Vertices {
+				in := h.containsAll(e, []int{v})
+				if want := j == i || j == (i+1)%k; in != want {
+					t.Fatalf("trial %d: witness %+v: edge %s and vertex %d", trial, cycle, e, v)
+				}
+			}
+		}
+	}
+}
+
+func TestIsBalanced_Cutoff(t *testing.T) {
+	t.Parallel()
+	// A complete 2-uniform bipartite graph has many even special cycles and
+	// no odd one, so the search must explore widely.
+	h := NewHypergraph[int]()
+	for a := range 9 {
+		for b := 9; b < 18; b++ {
+			_ = h.AddEdge(fmtInt(a)+"-"+fmtInt(b), []int{a, b})
+		}
+	}
+	ok, _, err := h.IsBalanced(time.Millisecond)
+	if !ok || !errors.Is(err, ErrCutoff) {
+		t.Errorf("IsBalanced = %v, %v; want true, ErrCutoff", ok, err)
+	}
+}
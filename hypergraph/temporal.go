@@ -0,0 +1,386 @@
+package hypergraph
+
+import (
+	"cmp"
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Interval is a closed time interval [Start, End]. Times are plain integers
+// in whatever unit the caller chooses, such as Unix seconds. An instant is
+// an interval with Start == End.
+type Interval struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// Contains reports whether t lies in the interval.
+func (iv Interval) Contains(t int64) bool {
+	return iv.Start <= t && t <= iv.End
+}
+
+// Overlaps reports whether the two intervals share at least one time.
+func (iv Interval) Overlaps(other Interval) bool {
+	return iv.Start <= other.End && other.Start <= iv.End
+}
+
+// TemporalHypergraph is a hypergraph whose edges are active during one or
+// more time intervals. Members and weights do not change over time; only
+// whether an edge is active does.
+type TemporalHypergraph[V comparable] struct {
+	static *Hypergraph[V]
+	times  map[string][]Interval // sorted by Start, then End
+}
+
+// NewTemporalHypergraph creates an empty temporal hypergraph.
+func NewTemporalHypergraph[V comparable]() *TemporalHypergraph[V] {
+	return &TemporalHypergraph[V]{
+		static: NewHypergraph[V](),
+		times:  make(map[string][]Interval),
+	}
+}
+
+// AddVertex adds a vertex.
+func (t *TemporalHypergraph[V]) AddVertex(v V) {
+	t.static.AddVertex(v)
+}
+
+// AddEdge adds an edge with the given members, active during each of the
+// given intervals. More activations can be added with AddActivation.
+func (t *TemporalHypergraph[V]) AddEdge(id string, members []V, times ...Interval) error {
+	for _, iv := range times {
+		if iv.End < iv.Start {
+			return fmt.Errorf("edge %q: interval end %d before start %d", id, iv.End, iv.Start)
+		}
+	}
+	if err := t.static.AddEdge(id, members); err != nil {
+		return err
+	}
+	t.times[id] = nil
+	for _, iv := range times {
+		t.insertActivation(id, iv)
+	}
+	return nil
+}
+
+// AddActivation records that an existing edge is active during iv.
+// Returns [ErrEdgeNotFound] if the edge does not exist.
+func (t *TemporalHypergraph[V]) AddActivation(id string, iv Interval) error {
+	if !t.static.HasEdge(id) {
+		return ErrEdgeNotFound
+	}
+	if iv.End < iv.Start {
+		return fmt.Errorf("edge %q: interval end %d before start %d", id, iv.End, iv.Start)
+	}
+	t.insertActivation(id, iv)
+	return nil
+}
+
+func (t *TemporalHypergraph[V]) insertActivation(id string, iv Interval) {
+	times := t.times[id]
+	i, _ := slices.BinarySearchFunc(times, iv, compareIntervals)
+	t.times[id] = slices.Insert(times, i, iv)
+}
+
+func compareIntervals(a, b Interval) int {
+	return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.End, b.End))
+}
+
+// RemoveEdge removes an edge and its activations.
+func (t *TemporalHypergraph[V]) RemoveEdge(id string) {
+	t.static.RemoveEdge(id)
+	delete(t.times, id)
+}
+
+// SetEdgeWeight sets the weight of an edge; see [Hypergraph.SetEdgeWeight].
+func (t *TemporalHypergraph[V]) SetEdgeWeight(id string, w float64) error {
+	return t.static.SetEdgeWeight(id, w)
+}
+
+// Activations returns the activation intervals of an edge, sorted by start
+// time, or nil if the edge does not exist.
+func (t *TemporalHypergraph[V]) Activations(id string) []Interval {
+	return slices.Clone(t.times[id])
+}
+
+// NumVertices returns the number of vertices.
+func (t *TemporalHypergraph[V]) NumVertices() int { return t.static.NumVertices() }
+
+// NumEdges returns the number of edges.
+func (t *TemporalHypergraph[V]) NumEdges() int { return t.static.NumEdges() }
+
+// Span returns the smallest interval containing every activation, and false
+// if there are none.
+func (t *TemporalHypergraph[V]) Span() (Interval, bool) {
+	var span Interval
+	found := false
+	for _, times := range t.times {
+		for _, iv := range times {
+			if !found {
+				span, found = iv, true
+				continue
+			}
+			span.Start = min(span.Start, iv.Start)
+			span.End = max(span.End, iv.End)
+		}
+	}
+	return span, found
+}
+
+// SetCompare sets the order of the vertices, like
+// [Hypergraph.SetCompare]. Hypergraphs derived from t afterwards, such as
+// windows and snapshots, keep it.
+func (t *TemporalHypergraph[V]) SetCompare(compare func(a, b V) int) {
+	t.static.SetCompare(compare)
+}
+
+// Static returns the underlying hypergraph with every edge, ignoring time.
+func (t *TemporalHypergraph[V]) Static() *Hypergraph[V] {
+	return t.static.Copy()
+}
+
+// Snapshot returns the hypergraph of edges active at time at. All vertices
+// are kept, so snapshots of the same temporal hypergraph share a vertex set.
+// Edge weights are preserved.
+func (t *TemporalHypergraph[V]) Snapshot(at int64) *Hypergraph[V] {
+	return t.Window(at, at)
+}
+
+// Window returns the hypergraph of edges active at some time in
+// [from, to]. All vertices are kept and edge weights are preserved.
+func (t *TemporalHypergraph[V]) Window(from, to int64) *Hypergraph[V] {
+	window := Interval{from, to}
+	out := t.static.empty()
+	for v := range t.static.vertices {
+		out.AddVertex(v)
+	}
+	for id, times := range t.times {
+		if !slices.ContainsFunc(times, window.Overlaps) {
+			continue
+		}
+		_ = out.AddEdge(id, t.static.EdgeMembers(id))
+		out.copyEdgeAttributes(t.static, id)
+	}
+	return out
+}
+
+// AggregateMode selects how [TemporalHypergraph.Aggregate] weights edges.
+type AggregateMode int
+
+const (
+	// ActivationCount weights an edge by the number of its activations
+	// overlapping the window.
+	ActivationCount AggregateMode = iota
+	// ActiveDuration weights an edge by the total length of its
+	// activations clipped to the window. Instants contribute 0.
+	ActiveDuration
+)
+
+// Aggregate returns the edges active in [from, to], like Window, with each
+// edge's weight replaced by a measure of its activity in the window.
+func (t *TemporalHypergraph[V]) Aggregate(from, to int64, mode AggregateMode) *Hypergraph[V] {
+	window := Interval{from, to}
+	out := t.Window(from, to)
+	for id := range out.edges {
+		total := 0.0
+		for _, iv := range t.times[id] {
+			if !iv.Overlaps(window) {
+				continue
+			}
+			switch mode {
+			case ActivationCount:
+				total++
+			case ActiveDuration:
+				total += float64(min(iv.End, to) - max(iv.Start, from))
+			}
+		}
+		_ = out.SetEdgeWeight(id, total)
+	}
+	return out
+}
+
+// TemporalHop is one step of a time-respecting path: at Time the path
+// moves from From to To along Edge.
+type TemporalHop[V comparable] struct {
+	Edge     string
+	From, To V
+	Time     int64
+}
+
+// EarliestArrival returns, for every vertex reachable from source by a
+// time-respecting path leaving no earlier than start, the earliest time it
+// can be reached. A path may wait at a vertex and then cross any edge that
+// is active at or after the current time, reaching all its members
+// instantly. The source itself is reached at start.
+//
+// Returns nil if source is not a vertex.
+// Time complexity: O((|V| + A) log |V|) where A is the number of
+// (member, activation) pairs examined.
+func (t *TemporalHypergraph[V]) EarliestArrival(source V, start int64) map[V]int64 {
+	arrival, _ := t.earliestArrival(source, start)
+	return arrival
+}
+
+// EarliestArrivalPath returns a time-respecting path from source to target
+// that reaches target as early as possible, leaving no earlier than start.
+// It returns false if target is unreachable. The path is empty when source
+// equals target.
+func (t *TemporalHypergraph[V]) EarliestArrivalPath(source, target V, start int64) ([]TemporalHop[V], bool) {
+	arrival, pred := t.earliestArrival(source, start)
+	if _, ok := arrival[target]; !ok {
+		return nil, false
+	}
+	var path []TemporalHop[V]
+	for v := target; v != source; {
+		hop := pred[v]
+		path = append(path, hop)
+		v = hop.From
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+func (t *TemporalHypergraph[V]) earliestArrival(source V, start int64) (map[V]int64, map[V]TemporalHop[V]) {
+	if !t.static.HasVertex(source) {
+		return nil, nil
+	}
+	arrival := map[V]int64{source: start}
+	pred := make(map[V]TemporalHop[V])
+	done := make(map[V]bool)
+	queue := &arrivalQueue[V]{items: []arrivalItem[V]{{source, start}}, compare: t.static.compare}
+	for queue.Len() > 0 {
+		cur := heap.Pop(queue).(arrivalItem[V])
+		if done[cur.v] {
+			continue
+		}
+		done[cur.v] = true
+		for _, id := range slices.Sorted(t.static.IncidentEdges(cur.v, Unordered)) {
+			// Activations are sorted by start, so the first one still
+			// active at cur.t gives the earliest departure.
+			i := slices.IndexFunc(t.times[id], func(iv Interval) bool { return iv.End >= cur.t })
+			if i < 0 {
+				continue
+			}
+			depart := max(cur.t, t.times[id][i].Start)
+			for u := range t.static.Members(id, Sorted) {
+				if old, ok := arrival[u]; ok && old <= depart {
+					continue
+				}
+				arrival[u] = depart
+				pred[u] = TemporalHop[V]{Edge: id, From: cur.v, To: u, Time: depart}
+				heap.Push(queue, arrivalItem[V]{u, depart})
+			}
+		}
+	}
+	return arrival, pred
+}
+
+type arrivalItem[V comparable] struct {
+	v V
+	t int64
+}
+
+// arrivalQueue is a min-heap of arrivals ordered by time, then vertex.
+type arrivalQueue[V comparable] struct {
+	items   []arrivalItem[V]
+	compare func(a, b V) int
+}
+
+func (q *arrivalQueue[V]) Len() int { return len(q.items) }
+func (q *arrivalQueue[V]) Less(i, j int) bool {
+	return cmp.Or(cmp.Compare(q.items[i].t, q.items[j].t), q.compare(q.items[i].v, q.items[j].v)) < 0
+}
+func (q *arrivalQueue[V]) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *arrivalQueue[V]) Push(x any)    { q.items = append(q.items, x.(arrivalItem[V])) }
+func (q *arrivalQueue[V]) Pop() any {
+	item := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return item
+}
+
+// SaveJSON writes the temporal hypergraph in the same format as
+// [Hypergraph.SaveJSON] plus a "times" object mapping each edge ID to its
+// activation intervals. [LoadJSON] reads such a file as the static
+// hypergraph.
+func (t *TemporalHypergraph[V]) SaveJSON(w io.Writer) error {
+	vertices := t.static.sorted(maps.Keys(t.static.vertices))
+	edges := make(map[string][]V)
+	times := make(map[string][]Interval)
+	for id := range t.static.edges {
+		edges[id] = slices.Collect(t.static.Members(id, Sorted))
+		times[id] = t.times[id]
+		if times[id] == nil {
+			times[id] = []Interval{}
+		}
+	}
+	data := map[string]interface{}{
+		"vertices": vertices,
+		"edges":    edges,
+		"times":    times,
+	}
+	if len(t.static.weights) > 0 {
+		data["weights"] = t.static.weights
+	}
+	if vt := vertexTypeOf[V](); vt != VertexTypeString {
+		data["vertex_type"] = vt
+	}
+	return json.NewEncoder(w).Encode(data)
+}
+
+// LoadTemporalJSON reads a temporal hypergraph written by
+// [TemporalHypergraph.SaveJSON]. A plain hypergraph file loads with no
+// activations.
+func LoadTemporalJSON[V comparable](r io.Reader) (*TemporalHypergraph[V], error) {
+	return LoadTemporalJSONFunc(r, keepVertex[V])
+}
+
+// LoadTemporalJSONFunc reads a file of vertex type W like
+// [LoadTemporalJSON] into a temporal hypergraph over V, converting every
+// vertex with f, which should be one-to-one.
+func LoadTemporalJSONFunc[V, W comparable](r io.Reader, f func(W) (V, error)) (*TemporalHypergraph[V], error) {
+	var data struct {
+		VertexType string                `json:"vertex_type"`
+		Vertices   []W                   `json:"vertices"`
+		Edges      map[string][]W        `json:"edges"`
+		Weights    map[string]float64    `json:"weights"`
+		Times      map[string][]Interval `json:"times"`
+	}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, checkVertexType[W](data.VertexType, err)
+	}
+	if err := checkVertexType[W](data.VertexType, nil); err != nil {
+		return nil, err
+	}
+	t := NewTemporalHypergraph[V]()
+	for _, x := range data.Vertices {
+		v, err := f(x)
+		if err != nil {
+			return nil, err
+		}
+		t.AddVertex(v)
+	}
+	for id, members := range data.Edges {
+		vs, err := convertVertices(members, f)
+		if err != nil {
+			return nil, fmt.Errorf("edge %q: %w", id, err)
+		}
+		if err := t.AddEdge(id, vs, data.Times[id]...); err != nil {
+			return nil, err
+		}
+	}
+	for id := range data.Times {
+		if !t.static.HasEdge(id) {
+			return nil, fmt.Errorf("times for edge %q: %w", id, ErrEdgeNotFound)
+		}
+	}
+	for id, w := range data.Weights {
+		if err := t.SetEdgeWeight(id, w); err != nil {
+			return nil, fmt.Errorf("weight for edge %q: %w", id, err)
+		}
+	}
+	return t, nil
+}
@@ -0,0 +1,250 @@
+package hypergraph
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// meetings builds a small temporal hypergraph of group interactions.
+//
+//	m1 {A,B}   active [1,2]
+//	m2 {B,C,D} active [5,5] and [9,12]
+//	m3 {D,E}   active [3,4]
+//	m4 {C,E}   active [6,8]
+func meetings(t *testing.T) *TemporalHypergraph[string] {
+	t.Helper()
+	th := NewTemporalHypergraph[string]()
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	must(th.AddEdge("m1", []string{"A", "B"}, Interval{1, 2}))
+	must(th.AddEdge("m2", []string{"B", "C", "D"}, Interval{9, 12}, Interval{5, 5}))
+	must(th.AddEdge("m3", []string{"D", "E"}, Interval{3, 4}))
+	must(th.AddEdge("m4", []string{"C", "E"}))
+	must(th.AddActivation("m4", Interval{6, 8}))
+	th.AddVertex("Z")
+	return th
+}
+
+// ============================================================================
+// Construction Tests
+// ============================================================================
+
+func TestTemporal_Construction(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+	if th.NumVertices() != 6 || th.NumEdges() != 4 {
+		t.Fatalf("got %d vertices and %d edges, want 6 and 4", th.NumVertices(), th.NumEdges())
+	}
+	if got := th.Activations("m2"); !slices.Equal(got, []Interval{{5, 5}, {9, 12}}) {
+		t.Errorf("Activations(m2) = %v, want sorted intervals", got)
+	}
+	if span, ok := th.Span(); !ok || span != (Interval{1, 12}) {
+		t.Errorf("Span = %v, %v, want [1,12]", span, ok)
+	}
+
+	if err := th.AddEdge("bad", []string{"A"}, Interval{5, 4}); err == nil {
+		t.Error("interval with end before start should fail")
+	}
+	if th.Static().HasEdge("bad") {
+		t.Error("failed AddEdge should not add the edge")
+	}
+	if err := th.AddActivation("missing", Interval{1, 1}); !errors.Is(err, ErrEdgeNotFound) {
+		t.Errorf("AddActivation on missing edge: err = %v", err)
+	}
+	if err := th.AddEdge("m1", []string{"A"}); !errors.Is(err, ErrDuplicateEdge) {
+		t.Errorf("duplicate edge: err = %v", err)
+	}
+
+	th.RemoveEdge("m1")
+	if th.Activations("m1") != nil || th.NumEdges() != 3 {
+		t.Error("RemoveEdge should drop the edge and its activations")
+	}
+}
+
+// ============================================================================
+// Slicing Tests
+// ============================================================================
+
+func TestTemporal_SnapshotAndWindow(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+	_ = th.SetEdgeWeight("m2", 3)
+
+	tests := []struct {
+		from, to int64
+		want     []string
+	}{
+		{5, 5, []string{"m2"}},
+		{2, 3, []string{"m1", "m3"}},
+		{6, 9, []string{"m2", "m4"}},
+		{13, 20, nil},
+	}
+	for _, tt := range tests {
+		w := th.Window(tt.from, tt.to)
+		if got := sortedCopy(w.Edges()); !slices.Equal(got, tt.want) {
+			t.Errorf("Window(%d, %d) edges = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+		if w.NumVertices() != 6 {
+			t.Errorf("Window(%d, %d) should keep all vertices, got %d", tt.from, tt.to, w.NumVertices())
+		}
+	}
+	snap := th.Snapshot(10)
+	if got := snap.Edges(); !slices.Equal(got, []string{"m2"}) || snap.EdgeWeight("m2") != 3 {
+		t.Errorf("Snapshot(10) = %v (weight %v), want [m2] with weight 3", got, snap.EdgeWeight("m2"))
+	}
+}
+
+func TestTemporal_Aggregate(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+
+	count := th.Aggregate(0, 20, ActivationCount)
+	if count.EdgeWeight("m2") != 2 || count.EdgeWeight("m1") != 1 {
+		t.Errorf("counts: m2=%v m1=%v, want 2 and 1", count.EdgeWeight("m2"), count.EdgeWeight("m1"))
+	}
+
+	dur := th.Aggregate(7, 10, ActiveDuration)
+	if got := sortedCopy(dur.Edges()); !slices.Equal(got, []string{"m2", "m4"}) {
+		t.Fatalf("edges = %v, want [m2 m4]", got)
+	}
+	// m2 is active on [9,10] within the window, m4 on [7,8].
+	if dur.EdgeWeight("m2") != 1 || dur.EdgeWeight("m4") != 1 {
+		t.Errorf("durations: m2=%v m4=%v, want 1 and 1", dur.EdgeWeight("m2"), dur.EdgeWeight("m4"))
+	}
+}
+
+// ============================================================================
+// Earliest Arrival Tests
+// ============================================================================
+
+func TestTemporal_EarliestArrival(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+
+	// From A at time 0: m1 reaches B at 1, m2 reaches C and D at 5, m4
+	// reaches E at 6. The D-E meeting at [3,4] is already over by then.
+	want := map[string]int64{"A": 0, "B": 1, "C": 5, "D": 5, "E": 6}
+	if got := th.EarliestArrival("A", 0); !maps.Equal(got, want) {
+		t.Errorf("EarliestArrival(A, 0) = %v, want %v", got, want)
+	}
+
+	// Leaving after m1 has ended, A is stuck.
+	if got := th.EarliestArrival("A", 3); len(got) != 1 {
+		t.Errorf("EarliestArrival(A, 3) = %v, want only A", got)
+	}
+	if th.EarliestArrival("missing", 0) != nil {
+		t.Error("unknown source should return nil")
+	}
+
+	path, ok := th.EarliestArrivalPath("A", "E", 0)
+	if !ok {
+		t.Fatal("E should be reachable from A")
+	}
+	wantPath := []TemporalHop[string]{
+		{Edge: "m1", From: "A", To: "B", Time: 1},
+		{Edge: "m2", From: "B", To: "C", Time: 5},
+		{Edge: "m4", From: "C", To: "E", Time: 6},
+	}
+	if !slices.Equal(path, wantPath) {
+		t.Errorf("path = %+v, want %+v", path, wantPath)
+	}
+	if _, ok := th.EarliestArrivalPath("A", "Z", 0); ok {
+		t.Error("isolated Z should be unreachable")
+	}
+	if p, ok := th.EarliestArrivalPath("A", "A", 0); !ok || len(p) != 0 {
+		t.Errorf("path to self = %v, %v, want empty", p, ok)
+	}
+}
+
+func TestTemporal_ArrivalNotSymmetric(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+	// E reaches D at 3 via m3, then B at 5 via m2, but A's only meeting
+	// ended at 2.
+	got := th.EarliestArrival("E", 0)
+	if got["D"] != 3 || got["B"] != 5 {
+		t.Errorf("EarliestArrival(E, 0) = %v", got)
+	}
+	if _, ok := got["A"]; ok {
+		t.Error("A should not be reachable from E")
+	}
+}
+
+// ============================================================================
+// Serialization Tests
+// ============================================================================
+
+func TestTemporal_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+	th := meetings(t)
+	_ = th.SetEdgeWeight("m3", 2)
+
+	var buf bytes.Buffer
+	if err := th.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.String()
+
+	back, err := LoadTemporalJSON[string](strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.NumVertices() != 6 || back.NumEdges() != 4 {
+		t.Fatalf("round trip lost data: %d vertices, %d edges", back.NumVertices(), back.NumEdges())
+	}
+	for _, id := range []string{"m1", "m2", "m3", "m4"} {
+		if !slices.Equal(back.Activations(id), th.Activations(id)) {
+			t.Errorf("Activations(%s) = %v, want %v", id, back.Activations(id), th.Activations(id))
+		}
+	}
+	if back.Snapshot(3).EdgeWeight("m3") != 2 {
+		t.Error("weights should survive the round trip")
+	}
+
+	// The file is also a valid static hypergraph.
+	static, err := LoadJSON[string](strings.NewReader(data))
+	if err != nil || static.NumEdges() != 4 {
+		t.Errorf("LoadJSON on temporal file: %v", err)
+	}
+}
+
+func TestTemporal_JSONFuncAndCompare(t *testing.T) {
+	t.Parallel()
+	data := `{"vertex_type":"int","vertices":[10,2],"edges":{"e":[2,10,9]},"times":{"e":[{"start":1,"end":2}]}}`
+	th, err := LoadTemporalJSONFunc(strings.NewReader(data), func(v int) (string, error) { return strconv.Itoa(v), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	th.SetCompare(CompareBy(func(v string) int { n, _ := strconv.Atoi(v); return n }))
+	w := th.Window(0, 5)
+	got := w.Vertices()
+	slices.SortFunc(got, w.Compare)
+	if !slices.Equal(got, []string{"2", "9", "10"}) {
+		t.Errorf("window vertices = %v, want numeric order", got)
+	}
+	if _, err := LoadTemporalJSON[string](strings.NewReader(data)); err == nil {
+		t.Error("LoadTemporalJSON[string] should reject int vertices")
+	}
+}
+
+func TestLoadTemporalJSON_Invalid(t *testing.T) {
+	t.Parallel()
+	for _, data := range []string{
+		`{"vertices":[],"edges":{"e":["A"]},"times":{"e":[{"start":3,"end":1}]}}`,
+		`{"vertices":[],"edges":{},"times":{"ghost":[{"start":1,"end":2}]}}`,
+		`{"vertices":[`,
+	} {
+		if _, err := LoadTemporalJSON[string](strings.NewReader(data)); err == nil {
+			t.Errorf("LoadTemporalJSON(%s) should fail", data)
+		}
+	}
+}
@@ -1,98 +1,77 @@
 package hypergraph
 
 import (
-	"cmp"
 	"fmt"
+	"slices"
 )
 
-// Graph represents a simple undirected graph.
-type Graph[V cmp.Ordered] struct {
-	vertices map[V]struct{}
-	edges    map[string]struct{ From, To V }
-}
-
-// NewGraph creates a new graph.
-func NewGraph[V cmp.Ordered]() *Graph[V] {
-	return &Graph[V]{
-		vertices: make(map[V]struct{}),
-		edges:    make(map[string]struct{ From, To V }),
-	}
-}
-
 // Dual returns the dual hypergraph where vertices become edges and vice versa.
+// Role bindings carry over: if v plays role r in edge e, then e plays r in
+// the dual edge v.
 func (h *Hypergraph[V]) Dual() *Hypergraph[string] {
 	dual := NewHypergraph[string]()
 	for _, e := range h.Edges() {
 		dual.AddVertex(e)
 	}
 	for v := range h.vertices {
-		members := make([]string, 0)
-		for e := range h.vertexToEdges[v] {
-			members = append(members, e)
+		members := slices.Collect(h.IncidentEdges(v, Sorted))
+		if len(members) == 0 {
+			continue
+		}
+		id := fmt.Sprintf("%v", v)
+		dual.AddEdge(id, members) //nolint:errcheck // IDs unique by construction
+		var bindings []RoleBinding[string]
+		for _, e := range members {
+			for _, role := range h.VertexRoles(e, v) {
+				bindings = append(bindings, RoleBinding[string]{Role: role, Vertex: e})
+			}
 		}
-		if len(members) > 0 {
-			dual.AddEdge(fmt.Sprintf("%v", v), members) //nolint:errcheck // IDs unique by construction
+		if len(bindings) > 0 {
+			dual.roles[id] = bindings
 		}
 	}
 	return dual
 }
 
 // TwoSection returns the 2-section graph where vertices are connected if they share an edge.
+// For role-labeled hyperedges, each graph edge is annotated with the roles
+// its endpoints play there; see [Graph.Annotations].
 func (h *Hypergraph[V]) TwoSection() *Graph[V] {
-	g := NewGraph[V]()
+	g := NewGraphFunc(h.compare)
 	for v := range h.vertices {
-		g.vertices[v] = struct{}{}
+		g.AddVertex(v)
 	}
-	for _, e := range h.edges {
-		vs := make([]V, 0)
-		for v := range e.Set {
-			vs = append(vs, v)
-		}
+	for id := range h.edges {
+		vs := h.EdgeMembers(id)
 		for i := 0; i < len(vs); i++ {
 			for j := i + 1; j < len(vs); j++ {
-				from, to := vs[i], vs[j]
-				if from > to {
-					from, to = to, from
-				}
-				edgeID := fmt.Sprintf("%v-%v", from, to)
-				if _, exists := g.edges[edgeID]; !exists {
-					g.edges[edgeID] = struct{ From, To V }{from, to}
+				key := g.edgeKey(vs[i], vs[j])
+				_ = g.AddEdge(key[0], key[1])
+				for _, fromRole := range h.VertexRoles(id, key[0]) {
+					for _, toRole := range h.VertexRoles(id, key[1]) {
+						g.annotations[key] = append(g.annotations[key], EdgeAnnotation{id, fromRole, toRole})
+					}
 				}
 			}
 		}
 	}
+	for _, anns := range g.annotations {
+		sortAnnotations(anns)
+	}
 	return g
 }
 
 // LineGraph returns the line graph where vertices are edges, connected if they share a vertex.
+// Pairs are found through the incident edges of each member rather than by
+// testing every pair of edges.
+// Time complexity: O(Σ_v deg(v)²).
 func (h *Hypergraph[V]) LineGraph() *Graph[string] {
 	g := NewGraph[string]()
 	for _, e := range h.Edges() {
-		g.vertices[e] = struct{}{}
+		g.AddVertex(e)
 	}
-	for _, e1 := range h.Edges() {
-		for _, e2 := range h.Edges() {
-			if e1 >= e2 {
-				continue
-			}
-			shared := false
-			for v := range h.edges[e1].Set {
-				if _, exists := h.edges[e2].Set[v]; exists {
-					shared = true
-					break
-				}
-			}
-			if shared {
-				from, to := e1, e2
-				if from > to {
-					from, to = to, from
-				}
-				edgeID := fmt.Sprintf("%s-%s", from, to)
-				if _, exists := g.edges[edgeID]; !exists {
-					g.edges[edgeID] = struct{ From, To string }{from, to}
-				}
-			}
-		}
+	for _, p := range h.lineGraphPairs(h.Edges()) {
+		_ = g.AddEdge(p[0], p[1])
 	}
 	return g
 }
@@ -103,21 +82,3 @@ func (h *Hypergraph[V]) LineGraph() *Graph[string] {
 func (h *Hypergraph[V]) Primal() *Graph[V] {
 	return h.TwoSection()
 }
-
-// Vertices returns all vertices of the graph.
-func (g *Graph[V]) Vertices() []V {
-	vs := make([]V, 0, len(g.vertices))
-	for v := range g.vertices {
-		vs = append(vs, v)
-	}
-	return vs
-}
-
-// Edges returns all edges of the graph as pairs.
-func (g *Graph[V]) Edges() []struct{ From, To V } {
-	es := make([]struct{ From, To V }, 0, len(g.edges))
-	for _, e := range g.edges {
-		es = append(es, e)
-	}
-	return es
-}
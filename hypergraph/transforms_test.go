@@ -324,9 +324,9 @@ func TestLineGraph_Chain(t *testing.T) {
 func TestGraph_VerticesAndEdges(t *testing.T) {
 	t.Parallel()
 	g := NewGraph[string]()
-	g.vertices["A"] = struct{}{}
-	g.vertices["B"] = struct{}{}
-	g.edges["A-B"] = struct{ From, To string }{"A", "B"}
+	if err := g.AddEdge("B", "A"); err != nil {
+		t.Fatal(err)
+	}
 
 	verts := g.Vertices()
 	if len(verts) != 2 {
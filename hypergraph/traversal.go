@@ -12,12 +12,18 @@ func (h *Hypergraph[V]) BFS(start V) []V {
 	visited[start] = struct{}{}
 	result := make([]V, 0, len(h.vertices))
 	result = append(result, start)
+	// An edge reached once has all its members queued, so it is not
+	// scanned again.
+	expanded := make(map[string]struct{})
 	for len(queue) > 0 {
 		current := queue[0]
 		queue = queue[1:]
-		for edgeID := range h.vertexToEdges[current] {
-			members := h.edges[edgeID].Set
-			for v := range members {
+		for e := range h.IncidentEdges(current, Unordered) {
+			if _, done := expanded[e]; done {
+				continue
+			}
+			expanded[e] = struct{}{}
+			for v := range h.Members(e, Unordered) {
 				if _, exists := visited[v]; !exists {
 					visited[v] = struct{}{}
 					queue = append(queue, v)
@@ -44,9 +50,8 @@ func (h *Hypergraph[V]) DFS(start V) []V {
 		if _, exists := visited[current]; !exists {
 			visited[current] = struct{}{}
 			result = append(result, current)
-			for edgeID := range h.vertexToEdges[current] {
-				members := h.edges[edgeID].Set
-				for v := range members {
+			for e := range h.IncidentEdges(current, Unordered) {
+				for v := range h.Members(e, Unordered) {
 					if _, exists := visited[v]; !exists {
 						stack = append(stack, v)
 					}
@@ -72,3 +77,17 @@ func (h *Hypergraph[V]) ConnectedComponents() [][]V {
 	}
 	return components
 }
+
+// Distances returns the hop distance from start to every reachable vertex.
+// Two vertices are one hop apart if they share a hyperedge; start itself is
+// at distance 0. Unreachable vertices are absent from the result.
+func (h *Hypergraph[V]) Distances(start V) map[V]int {
+	if !h.HasVertex(start) {
+		return nil
+	}
+	dist := make(map[V]int)
+	for v, d := range h.KHopNeighborhood(start, len(h.vertices), Unordered) {
+		dist[v] = d
+	}
+	return dist
+}
@@ -477,6 +477,42 @@ func TestConnectedComponents_MixedIsolatedAndConnected(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Distances Tests
+// ============================================================================
+
+func TestDistances_Chain(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[string]()
+	_ = h.AddEdge("E1", []string{"A", "B", "C"})
+	_ = h.AddEdge("E2", []string{"C", "D"})
+	_ = h.AddEdge("E3", []string{"D", "E"})
+	h.AddVertex("Z")
+
+	dist := h.Distances("A")
+	want := map[string]int{"A": 0, "B": 1, "C": 1, "D": 2, "E": 3}
+	if len(dist) != len(want) {
+		t.Fatalf("Distances = %v, want %v", dist, want)
+	}
+	for v, d := range want {
+		if dist[v] != d {
+			t.Errorf("Distances[%s] = %d, want %d", v, dist[v], d)
+		}
+	}
+	if _, ok := dist["Z"]; ok {
+		t.Error("unreachable vertex Z should be absent")
+	}
+}
+
+func TestDistances_NonExistentStart(t *testing.T) {
+	t.Parallel()
+	h := NewHypergraph[int]()
+	_ = h.AddEdge("E1", []int{1, 2})
+	if dist := h.Distances(99); dist != nil {
+		t.Fatalf("Distances for missing vertex = %v, want nil", dist)
+	}
+}
+
 // ============================================================================
 // Benchmarks
 // ============================================================================
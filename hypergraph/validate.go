@@ -0,0 +1,395 @@
+package hypergraph
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Severity grades a [Diagnostic].
+type Severity int
+
+const (
+	// SeverityWarning marks content that loads but is probably a mistake.
+	SeverityWarning Severity = iota
+	// SeverityError marks content that [LoadJSON] rejects or silently
+	// loses.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Diagnostic is a problem found by [ValidateJSON]. Line and Column are
+// 1-based; columns count bytes.
+type Diagnostic struct {
+	Line, Column int
+	Severity     Severity
+	Message      string
+}
+
+// String formats d as "line:column: severity: message".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, d.Severity, d.Message)
+}
+
+// ValidateJSON checks a hypergraph document in the format read by
+// [LoadJSON] and reports every problem it finds, ordered by position,
+// instead of stopping at the first.
+//
+// Errors are what LoadJSON rejects (bad syntax, wrong types, a
+// "vertex_type" other than V's, empty edges, weights or roles for unknown
+// edges, invalid weights and role bindings)
+// or silently loses (duplicate edge IDs and top-level keys). Warnings are
+// what LoadJSON accepts but is likely a mistake: duplicate vertices,
+// duplicate members within an edge, members missing from "vertices", edge
+// IDs equal to a vertex name, and unknown keys.
+//
+// If there are no errors, ValidateJSON also returns the hypergraph.
+// Writing it with [Hypergraph.SaveJSON] gives the canonical form of the
+// document: keys and vertices sorted, members sorted and deduplicated, and
+// every member listed as a vertex.
+func ValidateJSON[V comparable](data []byte) (*Hypergraph[V], []Diagnostic) {
+	jv := &jsonValidator[V]{data: data, lines: []int{0}, h: NewHypergraph[V]()}
+	for i, c := range data {
+		if c == '\n' {
+			jv.lines = append(jv.lines, i+1)
+		}
+	}
+	if top, ok := jv.document(); ok {
+		jv.hypergraph(top)
+	}
+	slices.SortStableFunc(jv.diags, func(a, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	if slices.ContainsFunc(jv.diags, func(d Diagnostic) bool { return d.Severity == SeverityError }) {
+		return nil, jv.diags
+	}
+	return jv.h, jv.diags
+}
+
+// jsonNode is a raw JSON value and the offset of its first byte in the
+// document.
+type jsonNode struct {
+	raw json.RawMessage
+	off int
+}
+
+type jsonValidator[V comparable] struct {
+	data  []byte
+	lines []int // offset of the start of each line
+	diags []Diagnostic
+	h     *Hypergraph[V]
+}
+
+func (jv *jsonValidator[V]) report(off int, sev Severity, format string, args ...any) {
+	line := sort.Search(len(jv.lines), func(i int) bool { return jv.lines[i] > off })
+	jv.diags = append(jv.diags, Diagnostic{
+		Line:     line,
+		Column:   off - jv.lines[line-1] + 1,
+		Severity: sev,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// skipSeparators returns the offset of the next value or key in raw at or
+// after off.
+func skipSeparators(raw []byte, off int) int {
+	for off < len(raw) && bytes.IndexByte([]byte(" \t\r\n,:"), raw[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+// document parses the whole input as one JSON value.
+func (jv *jsonValidator[V]) document() (jsonNode, bool) {
+	dec := json.NewDecoder(bytes.NewReader(jv.data))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		var syntax *json.SyntaxError
+		switch {
+		case errors.As(err, &syntax):
+			jv.report(max(int(syntax.Offset)-1, 0), SeverityError, "invalid JSON: %v", err)
+		case err == io.EOF:
+			jv.report(0, SeverityError, "empty document")
+		default:
+			jv.report(len(jv.data), SeverityError, "invalid JSON: %v", err)
+		}
+		return jsonNode{}, false
+	}
+	top := jsonNode{raw, skipSeparators(jv.data, 0)}
+	rest := skipSeparators(jv.data, int(dec.InputOffset()))
+	if _, err := dec.Token(); err != io.EOF {
+		jv.report(rest, SeverityError, "unexpected data after the hypergraph object")
+	}
+	return top, true
+}
+
+// object calls fn for each key and value of the object n, or reports an
+// error if n is not an object.
+func (jv *jsonValidator[V]) object(n jsonNode, what string, fn func(key string, keyOff int, val jsonNode)) {
+	if n.raw[0] != '{' {
+		jv.report(n.off, SeverityError, "%s must be an object, not %s", what, jsonKind(n.raw))
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(n.raw))
+	_, _ = dec.Token()
+	for dec.More() {
+		keyOff := skipSeparators(n.raw, int(dec.InputOffset()))
+		key, _ := dec.Token()
+		valOff := skipSeparators(n.raw, int(dec.InputOffset()))
+		var val json.RawMessage
+		_ = dec.Decode(&val)
+		fn(key.(string), n.off+keyOff, jsonNode{val, n.off + valOff})
+	}
+}
+
+// array calls fn for each element of the array n, or reports an error if
+// n is not an array.
+func (jv *jsonValidator[V]) array(n jsonNode, what string, fn func(val jsonNode)) {
+	if n.raw[0] != '[' {
+		jv.report(n.off, SeverityError, "%s must be an array, not %s", what, jsonKind(n.raw))
+		return
+	}
+	dec := json.NewDecoder(bytes.NewReader(n.raw))
+	_, _ = dec.Token()
+	for dec.More() {
+		off := skipSeparators(n.raw, int(dec.InputOffset()))
+		var val json.RawMessage
+		_ = dec.Decode(&val)
+		fn(jsonNode{val, n.off + off})
+	}
+}
+
+// decode unmarshals n into *dst, reporting an error on a type mismatch.
+func decode[T any, V comparable](jv *jsonValidator[V], n jsonNode, what string, dst *T) bool {
+	want := jsonKindOf(reflect.TypeFor[T]())
+	if got := jsonKind(n.raw); want != "" && got != want {
+		jv.report(n.off, SeverityError, "%s must be %s, not %s", what, want, got)
+		return false
+	}
+	if err := json.Unmarshal(n.raw, dst); err != nil {
+		jv.report(n.off, SeverityError, "%s: %v", what, err)
+		return false
+	}
+	return true
+}
+
+func jsonKind(raw []byte) string {
+	switch raw[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case '"':
+		return "a string"
+	case 't', 'f':
+		return "a boolean"
+	case 'n':
+		return "null"
+	default:
+		return "a number"
+	}
+}
+
+// jsonKindOf returns the kind of JSON value that decodes into t, in the
+// words of jsonKind, or "" if values of any kind may, as for interfaces
+// and types that decode themselves.
+func jsonKindOf(t reflect.Type) string {
+	p := reflect.PointerTo(t)
+	if p.Implements(reflect.TypeFor[json.Unmarshaler]()) || p.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Array, reflect.Slice:
+		return "an array"
+	case reflect.Pointer:
+		return jsonKindOf(t.Elem())
+	}
+	return ""
+}
+
+func (jv *jsonValidator[V]) hypergraph(top jsonNode) {
+	fields := make(map[string]jsonNode)
+	jv.object(top, "the hypergraph", func(key string, keyOff int, val jsonNode) {
+		switch key {
+		case "vertices", "edges", "weights", "roles", "vertex_type":
+			if _, dup := fields[key]; dup {
+				jv.report(keyOff, SeverityError, "duplicate key %q", key)
+			}
+			fields[key] = val
+		default:
+			jv.report(keyOff, SeverityWarning, "unknown key %q is ignored", key)
+		}
+	})
+
+	if n, ok := fields["vertex_type"]; ok {
+		var t string
+		if decode(jv, n, `"vertex_type"`, &t) {
+			if err := checkVertexType[V](t, nil); err != nil {
+				jv.report(n.off, SeverityError, "%v", err)
+				return
+			}
+		}
+	}
+
+	listed := make(map[V]bool)
+	if n, ok := fields["vertices"]; ok {
+		jv.array(n, `"vertices"`, func(val jsonNode) {
+			var v V
+			if !decode(jv, val, "vertex", &v) {
+				return
+			}
+			if listed[v] {
+				jv.report(val.off, SeverityWarning, "duplicate vertex %v", v)
+			}
+			listed[v] = true
+			jv.h.AddVertex(v)
+		})
+	}
+
+	edgeKeys := make(map[string]int)
+	if n, ok := fields["edges"]; ok {
+		jv.object(n, `"edges"`, func(id string, keyOff int, val jsonNode) {
+			if _, dup := edgeKeys[id]; dup {
+				jv.report(keyOff, SeverityError, "duplicate edge ID %q", id)
+				return
+			}
+			edgeKeys[id] = keyOff
+			members := make(map[V]bool)
+			var order []V
+			count := 0
+			jv.array(val, fmt.Sprintf("edge %q", id), func(m jsonNode) {
+				count++
+				var v V
+				if !decode(jv, m, fmt.Sprintf("member of edge %q", id), &v) {
+					return
+				}
+				switch {
+				case members[v]:
+					jv.report(m.off, SeverityWarning, "vertex %v appears more than once in edge %q", v, id)
+				case !listed[v]:
+					jv.report(m.off, SeverityWarning, "vertex %v in edge %q is not listed in \"vertices\"", v, id)
+				}
+				if !members[v] {
+					members[v] = true
+					order = append(order, v)
+				}
+			})
+			if val.raw[0] == '[' && count == 0 {
+				jv.report(val.off, SeverityError, "edge %q has no members", id)
+			}
+			if len(order) > 0 {
+				_ = jv.h.AddEdge(id, order)
+			}
+		})
+	}
+	names := make(map[string]bool, len(jv.h.vertices))
+	for v := range jv.h.vertices {
+		names[fmt.Sprint(v)] = true
+	}
+	for _, id := range slices.Sorted(maps.Keys(edgeKeys)) {
+		if names[id] {
+			jv.report(edgeKeys[id], SeverityWarning, "edge ID %q is also a vertex name", id)
+		}
+	}
+
+	if n, ok := fields["weights"]; ok {
+		jv.object(n, `"weights"`, func(id string, keyOff int, val jsonNode) {
+			if _, ok := edgeKeys[id]; !ok {
+				jv.report(keyOff, SeverityError, "weight for unknown edge %q", id)
+				return
+			}
+			var w float64
+			if !decode(jv, val, fmt.Sprintf("weight of edge %q", id), &w) || !jv.h.HasEdge(id) {
+				return
+			}
+			if err := jv.h.SetEdgeWeight(id, w); err != nil {
+				jv.report(val.off, SeverityError, "edge %q: %v", id, err)
+			}
+		})
+	}
+
+	if n, ok := fields["roles"]; ok {
+		jv.object(n, `"roles"`, func(id string, keyOff int, val jsonNode) {
+			if _, ok := edgeKeys[id]; !ok {
+				jv.report(keyOff, SeverityError, "roles for unknown edge %q", id)
+				return
+			}
+			jv.roles(id, val)
+		})
+	}
+}
+
+func isMember[V comparable](h *Hypergraph[V], id string, v V) bool {
+	_, ok := h.edges[id].Set[v]
+	return ok
+}
+
+// roles checks the role bindings of edge id and sets them if they are
+// valid.
+func (jv *jsonValidator[V]) roles(id string, n jsonNode) {
+	var bindings []RoleBinding[V]
+	valid := true
+	seen := make(map[RoleBinding[V]]bool)
+	jv.array(n, fmt.Sprintf("roles of edge %q", id), func(val jsonNode) {
+		var b RoleBinding[V]
+		var sawRole, sawVertex, ok = false, false, true
+		jv.object(val, "a role binding", func(key string, keyOff int, field jsonNode) {
+			switch key {
+			case "role":
+				sawRole = true
+				if !decode(jv, field, "role", &b.Role) {
+					ok = false
+				} else if b.Role == "" {
+					jv.report(field.off, SeverityError, "empty role in edge %q", id)
+					ok = false
+				}
+			case "vertex":
+				sawVertex = true
+				ok = decode(jv, field, "role vertex", &b.Vertex) && ok
+			default:
+				jv.report(keyOff, SeverityWarning, "unknown key %q is ignored", key)
+			}
+		})
+		switch {
+		case val.raw[0] != '{' || !ok:
+		case !sawRole || !sawVertex:
+			jv.report(val.off, SeverityError, "role binding in edge %q needs a role and a vertex", id)
+		case seen[b]:
+			jv.report(val.off, SeverityError, "duplicate binding of role %q to %v in edge %q", b.Role, b.Vertex, id)
+		case jv.h.HasEdge(id) && !isMember(jv.h, id, b.Vertex):
+			jv.report(val.off, SeverityError, "role %q in edge %q is bound to non-member %v", b.Role, id, b.Vertex)
+		default:
+			seen[b] = true
+			bindings = append(bindings, b)
+			return
+		}
+		valid = false
+	})
+	if valid && len(bindings) > 0 && jv.h.HasEdge(id) {
+		_ = jv.h.SetEdgeRoles(id, bindings)
+	}
+}
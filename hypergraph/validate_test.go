@@ -0,0 +1,156 @@
+package hypergraph
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func diagStrings(diags []Diagnostic) []string {
+	out := make([]string, len(diags))
+	for i, d := range diags {
+		out[i] = d.String()
+	}
+	return out
+}
+
+// ============================================================================
+// Validation Tests
+// ============================================================================
+
+func TestValidateJSON_Clean(t *testing.T) {
+	t.Parallel()
+	h := facts(t)
+	_ = h.SetEdgeWeight("f1", 2.5)
+	var buf bytes.Buffer
+	if err := h.SaveJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, diags := ValidateJSON[string](buf.Bytes())
+	if len(diags) != 0 {
+		t.Fatalf("diagnostics for SaveJSON output: %v", diagStrings(diags))
+	}
+	if d := Diff(h, got); !d.IsEmpty() {
+		t.Errorf("validated graph differs: %+v", d)
+	}
+}
+
+func TestValidateJSON_Warnings(t *testing.T) {
+	t.Parallel()
+	doc := `{
+  "vertices": ["a", "b", "a", "e1"],
+  "edges": {
+    "e1": ["a", "b", "b"],
+    "e2": ["b", "c"]
+  },
+  "comment": "x"
+}`
+	h, diags := ValidateJSON[string]([]byte(doc))
+	want := []string{
+		`2:26: warning: duplicate vertex a`,
+		`4:5: warning: edge ID "e1" is also a vertex name`,
+		`4:22: warning: vertex b appears more than once in edge "e1"`,
+		`5:17: warning: vertex c in edge "e2" is not listed in "vertices"`,
+		`7:3: warning: unknown key "comment" is ignored`,
+	}
+	if got := diagStrings(diags); !slices.Equal(got, want) {
+		t.Fatalf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if h == nil || !h.HasVertex("c") || len(h.EdgeMembers("e1")) != 2 {
+		t.Fatal("warnings should still return the repaired hypergraph")
+	}
+
+	// The canonical form validates cleanly apart from the name collision.
+	var buf bytes.Buffer
+	_ = h.SaveJSON(&buf)
+	if _, diags := ValidateJSON[string](buf.Bytes()); len(diags) != 1 {
+		t.Errorf("canonical form diagnostics: %v", diagStrings(diags))
+	}
+}
+
+func TestValidateJSON_Errors(t *testing.T) {
+	t.Parallel()
+	doc := `{"vertices": [1, "b", null],
+ "edges": {"e1": ["b"], "e2": [], "e1": ["c"], "e3": 7},
+ "weights": {"e1": -1, "nope": 2, "e3": "heavy"},
+ "roles": {"e1": [{"role": "", "vertex": "b"}, {"role": "r", "vertex": "z"}, {"role": "r"}]}}`
+	h, diags := ValidateJSON[string]([]byte(doc))
+	if h != nil {
+		t.Error("errors should not return a hypergraph")
+	}
+	want := []string{
+		`1:15: error: vertex must be a string, not a number`,
+		`1:23: error: vertex must be a string, not null`,
+		`2:31: error: edge "e2" has no members`,
+		`2:35: error: duplicate edge ID "e1"`,
+		`2:54: error: edge "e3" must be an array, not a number`,
+		`3:20: error: edge "e1": invalid edge weight -1`,
+		`3:24: error: weight for unknown edge "nope"`,
+		`3:41: error: weight of edge "e3" must be a number, not a string`,
+		`4:28: error: empty role in edge "e1"`,
+		`4:48: error: role "r" in edge "e1" is bound to non-member z`,
+		`4:78: error: role binding in edge "e1" needs a role and a vertex`,
+	}
+	if got := diagStrings(diags); !slices.Equal(got, want) {
+		t.Fatalf("diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateJSON_Syntax(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		doc, want string
+	}{
+		{"", "1:1: error: empty document"},
+		{"{\n  \"vertices\": [\"a\",]\n}", "2:20: error: invalid JSON"},
+		{`{"edges": {"e": ["a"]}`, "1:23: error: invalid JSON: unexpected EOF"},
+		{`{} {}`, "1:4: error: unexpected data after the hypergraph object"},
+		{`["a"]`, "1:1: error: the hypergraph must be an object, not an array"},
+	}
+	for _, tt := range tests {
+		_, diags := ValidateJSON[string]([]byte(tt.doc))
+		if len(diags) != 1 || !strings.HasPrefix(diags[0].String(), tt.want) {
+			t.Errorf("ValidateJSON(%q) = %v, want %q", tt.doc, diagStrings(diags), tt.want)
+		}
+	}
+}
+
+func TestValidateJSON_IntVertices(t *testing.T) {
+	t.Parallel()
+	h, diags := ValidateJSON[int]([]byte(`{"vertices": [1, 2], "edges": {"e": [2, 3, 1.5]}}`))
+	if h != nil || len(diags) != 2 {
+		t.Fatalf("diagnostics: %v", diagStrings(diags))
+	}
+	if !strings.Contains(diags[0].Message, "vertex 3") || !strings.Contains(diags[1].Message, "cannot unmarshal number 1.5") {
+		t.Errorf("diagnostics: %v", diagStrings(diags))
+	}
+}
+
+func TestValidateJSON_VertexKinds(t *testing.T) {
+	t.Parallel()
+	type point struct{ X, Y int }
+	_, diags := ValidateJSON[bool]([]byte(`{"vertices": [true, 1]}`))
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "vertex must be a boolean, not a number") {
+		t.Errorf("bool diagnostics: %v", diagStrings(diags))
+	}
+	_, diags = ValidateJSON[point]([]byte(`{"vertices": [{"X": 1, "Y": 2}, "p"]}`))
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "vertex must be an object, not a string") {
+		t.Errorf("struct diagnostics: %v", diagStrings(diags))
+	}
+	_, diags = ValidateJSON[[2]int]([]byte(`{"vertices": [[1, 2], 3]}`))
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "vertex must be an array, not a number") {
+		t.Errorf("array diagnostics: %v", diagStrings(diags))
+	}
+}
+
+func TestValidateJSON_VertexType(t *testing.T) {
+	t.Parallel()
+	if _, diags := ValidateJSON[int]([]byte(`{"vertex_type": "int", "vertices": [1]}`)); len(diags) != 0 {
+		t.Errorf("diagnostics: %v", diagStrings(diags))
+	}
+	h, diags := ValidateJSON[string]([]byte(`{"vertex_type": "int", "vertices": [1]}`))
+	if h != nil || len(diags) != 1 || diags[0].String() != "1:17: error: file has int vertices, not string" {
+		t.Errorf("diagnostics: %v", diagStrings(diags))
+	}
+}